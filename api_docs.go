@@ -0,0 +1,296 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/health"
+	"github.com/dityuiri/go-baseline/controller/middleware"
+	"github.com/dityuiri/go-baseline/controller/openapi"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+const (
+	apiVersion     = "1.0.0"
+	apiDescription = "REST API of the service. Every JSON response is wrapped in an APIResponse."
+
+	placeholderTag = "placeholder"
+
+	bearerAuth = "bearerAuth"
+)
+
+// maxIdempotencyKeyLength is addressable for the schema
+var maxIdempotencyKeyLength = middleware.MaxIdempotencyKeyLength
+
+// apiEndpoints documents every route registered in registerRoutes.
+// TestAPIDocument fails whenever a route is added without its documentation here.
+var apiEndpoints = openapi.Endpoints{
+	openapi.Route(http.MethodGet, "/ping"): {
+		OperationID: "ping",
+		Summary:     "Health check",
+		Tags:        []string{"health"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK: {Description: "Service status", Body: map[string]string{}},
+		},
+	},
+	openapi.Route(http.MethodGet, "/livez"): {
+		OperationID: "livez",
+		Summary:     "Liveness probe",
+		Description: "Reports the process is alive, without probing the dependencies. The state of the dependencies is served on the admin port.",
+		Tags:        []string{"health"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK: {Description: "Process is alive", Body: health.Report{}},
+		},
+	},
+	openapi.Route(http.MethodGet, "/readyz"): {
+		OperationID: "readyz",
+		Summary:     "Readiness probe",
+		Description: "Reports the status of the dependencies probed within the last seconds. Failing non-critical dependencies only degrade the status. The state of each dependency is served on the admin port.",
+		Tags:        []string{"health"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                 {Description: "Ready to serve traffic", Body: health.Report{}},
+			http.StatusServiceUnavailable: {Description: "A critical dependency is down, or the app is starting or shutting down", Body: health.Report{}},
+		},
+	},
+	openapi.Route(http.MethodGet, "/startupz"): {
+		OperationID: "startupz",
+		Summary:     "Startup probe",
+		Description: "Reports whether the app is done waiting for its dependencies at startup.",
+		Tags:        []string{"health"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                 {Description: "Dependencies were available at startup", Body: health.Report{}},
+			http.StatusServiceUnavailable: {Description: "Still waiting for the dependencies", Body: health.Report{}},
+		},
+	},
+	openapi.Route(http.MethodGet, "/openapi.json"): {
+		OperationID: "getOpenAPIDocument",
+		Summary:     "OpenAPI document of this API",
+		Tags:        []string{"docs"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK: {Description: "OpenAPI 3 document", Body: &openapi.Schema{Type: "object"}},
+		},
+	},
+	openapi.Route(http.MethodGet, "/docs"): {
+		OperationID: "getDocs",
+		Summary:     "Human readable docs rendering the OpenAPI document",
+		Tags:        []string{"docs"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK: {Description: "HTML docs page"},
+		},
+	},
+	openapi.Route(http.MethodGet, "/v1/placeholder"): {
+		OperationID: "listPlaceholders",
+		Summary:     "List placeholders",
+		Description: "Filters, sorts and paginates placeholders. Requests with placeholder_id are answered as a single placeholder get.",
+		Tags:        []string{placeholderTag},
+		Security:    []string{bearerAuth},
+		Parameters: []openapi.Parameter{
+			queryParameter("name_prefix", "Placeholder name starts with", &openapi.Schema{Type: "string"}),
+			queryParameter("min_amount", "Minimum amount, inclusive", &openapi.Schema{Type: "integer"}),
+			queryParameter("max_amount", "Maximum amount, inclusive", &openapi.Schema{Type: "integer"}),
+			queryParameter("created_from", "Created at or after, RFC 3339", &openapi.Schema{Type: "string", Format: "date-time"}),
+			queryParameter("created_to", "Created before, RFC 3339", &openapi.Schema{Type: "string", Format: "date-time"}),
+			queryParameter("sort_by", "Sort column, created_at by default", &openapi.Schema{Type: "string", Enum: []string{"name", "amount", "created_at", "updated_at"}}),
+			queryParameter("sort_order", "Sort order, desc by default", &openapi.Schema{Type: "string", Enum: []string{common.SortOrderAsc, common.SortOrderDesc}}),
+			queryParameter("page", "Page number, starts from 1", &openapi.Schema{Type: "integer"}),
+			queryParameter("page_size", "Items per page", &openapi.Schema{Type: "integer"}),
+			{
+				Name:        "placeholder_id",
+				In:          "query",
+				Description: "Get a single placeholder instead, use GET /v1/placeholder/{placeholderID}",
+				Deprecated:  true,
+				Schema:      &openapi.Schema{Type: "string", Format: "uuid"},
+			},
+		},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                  {Description: "Page of placeholders", Body: openapi.Result(common.PlaceholdersKey, model.PlaceholderListResponse{})},
+			http.StatusBadRequest:          errorReply("Invalid filter, pagination or sort parameter"),
+			http.StatusUnauthorized:        errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:           errorReply("Insufficient role or scope"),
+			http.StatusTooManyRequests:     errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusInternalServerError: errorReply("Internal server error"),
+			http.StatusGatewayTimeout:      errorReply("Request timeout"),
+		},
+	},
+	openapi.Route(http.MethodPost, "/v1/placeholder"): {
+		OperationID: "createPlaceholder",
+		Summary:     "Create a placeholder",
+		Description: "Requests sent again with the same Idempotency-Key replay the response of the first one.",
+		Tags:        []string{placeholderTag},
+		Security:    []string{bearerAuth},
+		Parameters: []openapi.Parameter{
+			{
+				Name:        middleware.IdempotencyKeyHeader,
+				In:          "header",
+				Description: "Client generated key, like a UUID, making the request safe to retry",
+				Schema:      &openapi.Schema{Type: "string", MaxLength: &maxIdempotencyKeyLength},
+			},
+		},
+		Request: model.PlaceholderCreateRequest{},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                  {Description: "Created placeholder", Body: openapi.Result(common.PlaceholderKey, model.PlaceholderCreateResponse{})},
+			http.StatusBadRequest:          errorReply("Invalid request body"),
+			http.StatusUnauthorized:        errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:           errorReply("Insufficient role or scope"),
+			http.StatusTooManyRequests:     errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusUnprocessableEntity: errorReply("Request validation failed, or the Idempotency-Key was used with another request"),
+			http.StatusConflict:            errorReply("A request with the same Idempotency-Key is in progress"),
+			http.StatusInternalServerError: errorReply("Internal server error"),
+			http.StatusGatewayTimeout:      errorReply("Request timeout"),
+		},
+	},
+	openapi.Route(http.MethodGet, "/v1/placeholder/{placeholderID}"): {
+		OperationID: "getPlaceholder",
+		Summary:     "Get a placeholder",
+		Description: "The ETag header identifies the version of the placeholder, send it back as If-Match to update it.",
+		Tags:        []string{placeholderTag},
+		Security:    []string{bearerAuth},
+		Parameters:  []openapi.Parameter{placeholderIDParameter, ifNoneMatchParameter},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                  {Description: "Placeholder", Body: openapi.Result(common.PlaceholderKey, model.PlaceholderGetResponse{})},
+			http.StatusNotModified:         {Description: "Placeholder still matches If-None-Match"},
+			http.StatusBadRequest:          errorReply("Invalid placeholder id"),
+			http.StatusUnauthorized:        errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:           errorReply("Insufficient role or scope"),
+			http.StatusTooManyRequests:     errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusNotFound:            errorReply("Placeholder not found"),
+			http.StatusInternalServerError: errorReply("Internal server error"),
+			http.StatusBadGateway:          errorReply("Placeholder status is not available"),
+			http.StatusGatewayTimeout:      errorReply("Request timeout"),
+		},
+	},
+	openapi.Route(http.MethodPut, "/v1/placeholder/{placeholderID}"): {
+		OperationID: "updatePlaceholder",
+		Summary:     "Replace a placeholder",
+		Tags:        []string{placeholderTag},
+		Security:    []string{bearerAuth},
+		Parameters:  []openapi.Parameter{placeholderIDParameter, ifMatchParameter},
+		Request:     model.PlaceholderUpdateRequest{},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                   {Description: "Updated placeholder", Body: openapi.Result(common.PlaceholderKey, model.PlaceholderUpdateResponse{})},
+			http.StatusBadRequest:           errorReply("Invalid placeholder id or request body"),
+			http.StatusUnauthorized:         errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:            errorReply("Insufficient role or scope, or not the creator of the placeholder"),
+			http.StatusTooManyRequests:      errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusNotFound:             errorReply("Placeholder not found"),
+			http.StatusUnprocessableEntity:  errorReply("Request validation failed, see error details"),
+			http.StatusPreconditionFailed:   errorReply("Placeholder was modified since the If-Match version"),
+			http.StatusPreconditionRequired: errorReply("Missing If-Match"),
+			http.StatusInternalServerError:  errorReply("Internal server error"),
+			http.StatusGatewayTimeout:       errorReply("Request timeout"),
+		},
+	},
+	openapi.Route(http.MethodPatch, "/v1/placeholder/{placeholderID}"): {
+		OperationID:  "patchPlaceholder",
+		Summary:      "Partially update a placeholder",
+		Description:  "Applies a JSON Merge Patch (RFC 7386). The patched placeholder is validated like a replacement.",
+		Tags:         []string{placeholderTag},
+		Security:     []string{bearerAuth},
+		Parameters:   []openapi.Parameter{placeholderIDParameter, ifMatchParameter},
+		Request:      placeholderPatchSchema,
+		RequestTypes: []string{"application/merge-patch+json", "application/json"},
+		Replies: map[int]openapi.Reply{
+			http.StatusOK:                   {Description: "Updated placeholder", Body: openapi.Result(common.PlaceholderKey, model.PlaceholderUpdateResponse{})},
+			http.StatusBadRequest:           errorReply("Invalid placeholder id or merge patch"),
+			http.StatusUnauthorized:         errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:            errorReply("Insufficient role or scope, or not the creator of the placeholder"),
+			http.StatusTooManyRequests:      errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusNotFound:             errorReply("Placeholder not found"),
+			http.StatusUnsupportedMediaType: errorReply("Unsupported content type"),
+			http.StatusUnprocessableEntity:  errorReply("Patched placeholder failed the validation, see error details"),
+			http.StatusPreconditionFailed:   errorReply("Placeholder was modified since the If-Match version"),
+			http.StatusPreconditionRequired: errorReply("Missing If-Match"),
+			http.StatusInternalServerError:  errorReply("Internal server error"),
+			http.StatusGatewayTimeout:       errorReply("Request timeout"),
+		},
+	},
+	openapi.Route(http.MethodDelete, "/v1/placeholder/{placeholderID}"): {
+		OperationID: "deletePlaceholder",
+		Summary:     "Delete a placeholder",
+		Tags:        []string{placeholderTag},
+		Security:    []string{bearerAuth},
+		Parameters:  []openapi.Parameter{placeholderIDParameter},
+		Replies: map[int]openapi.Reply{
+			http.StatusNoContent:           {Description: "Placeholder deleted"},
+			http.StatusBadRequest:          errorReply("Invalid placeholder id"),
+			http.StatusUnauthorized:        errorReply("Missing or invalid bearer token"),
+			http.StatusForbidden:           errorReply("Insufficient role or scope"),
+			http.StatusTooManyRequests:     errorReply("Rate limit exceeded, retry after Retry-After seconds"),
+			http.StatusNotFound:            errorReply("Placeholder not found"),
+			http.StatusInternalServerError: errorReply("Internal server error"),
+			http.StatusGatewayTimeout:      errorReply("Request timeout"),
+		},
+	},
+}
+
+var (
+	placeholderIDParameter = openapi.Parameter{
+		Name:     "placeholderID",
+		In:       "path",
+		Required: true,
+		Schema:   &openapi.Schema{Type: "string", Format: "uuid"},
+	}
+
+	ifNoneMatchParameter = openapi.Parameter{
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "ETag of the placeholder held by the client, answered with 304 while it is current",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+
+	ifMatchParameter = openapi.Parameter{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "ETag of the placeholder being modified, the update is rejected once the placeholder has changed. * updates any version",
+		Required:    true,
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+
+	// placeholderPatchSchema has no required member, absent members are left untouched
+	placeholderPatchSchema = &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"name":   {Type: "string"},
+			"amount": {Type: "integer"},
+		},
+	}
+)
+
+// buildAPIDocument documents the routes registered on the router with apiEndpoints
+func buildAPIDocument(title string, routes chi.Routes) (*openapi.Document, error) {
+	info := openapi.Info{
+		Title:       title,
+		Description: apiDescription,
+		Version:     apiVersion,
+	}
+
+	doc, err := openapi.Build(info, routes, apiEndpoints)
+	doc.Components.SecuritySchemes = map[string]openapi.SecurityScheme{
+		bearerAuth: {
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "Required unless the authentication is disabled with AUTH_ENABLED=false",
+		},
+	}
+
+	return doc, err
+}
+
+func queryParameter(name string, description string, schema *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      schema,
+	}
+}
+
+func errorReply(description string) openapi.Reply {
+	return openapi.Reply{
+		Description: description,
+		Body:        model.APIResponse{},
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+)
+
+var (
+	readPlaceholder = auth.Requirement{
+		Roles:  []string{common.RoleAdmin},
+		Scopes: []string{common.ScopePlaceholderRead, common.ScopePlaceholderWrite},
+	}
+
+	writePlaceholder = auth.Requirement{
+		Roles:  []string{common.RoleAdmin},
+		Scopes: []string{common.ScopePlaceholderWrite},
+	}
+
+	adminOnly = auth.Requirement{
+		Roles: []string{common.RoleAdmin},
+	}
+)
+
+// apiPolicy holds the requirement of every authenticated route. Routes without one are denied,
+// TestAPIPolicy fails whenever an authenticated route is added without its requirement here.
+var apiPolicy = auth.Policy{
+	auth.Route(http.MethodGet, "/v1/placeholder"):                    readPlaceholder,
+	auth.Route(http.MethodPost, "/v1/placeholder"):                   writePlaceholder,
+	auth.Route(http.MethodGet, "/v1/placeholder/{placeholderID}"):    readPlaceholder,
+	auth.Route(http.MethodPut, "/v1/placeholder/{placeholderID}"):    writePlaceholder,
+	auth.Route(http.MethodPatch, "/v1/placeholder/{placeholderID}"):  writePlaceholder,
+	auth.Route(http.MethodDelete, "/v1/placeholder/{placeholderID}"): adminOnly,
+}
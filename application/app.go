@@ -2,53 +2,185 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis"
+
+	"github.com/dityuiri/go-adapter/client"
 	"github.com/dityuiri/go-adapter/db"
 
 	"github.com/dityuiri/go-adapter/kafka/consumer"
+	"github.com/dityuiri/go-adapter/kafka/producer"
 	"github.com/dityuiri/go-adapter/logger"
 	"github.com/dityuiri/go-adapter/redis"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/config"
 )
 
+const tracingShutdownTimeout = 5 * time.Second
+
 type App struct {
 	Context  context.Context
 	Config   *config.Configuration
 	Consumer consumer.IConsumer
+	Producer producer.IProducer
 	Redis    redis.IRedis
 	Logger   logger.ILogger
 	DB       db.IDatabase
+	Verifier auth.IVerifier
+	Metrics  *metrics.Metrics
+	Tracing  *tracing.Provider
+	LogLevel *logging.Level
+
+	// HTTPClient calls the external services of the proxies
+	HTTPClient client.IClient
+
+	// RedisClient connects to the same redis as Redis, for the atomic commands the adapter doesn't expose
+	RedisClient *goredis.Client
+
+	// LiveConfig holds the running configuration, Config with the reloaded settings
+	LiveConfig *config.Live
 }
 
-func SetupApplication(ctx context.Context) (*App, error) {
+// Adapter is an adapter the app connects to, each command sets up only the adapters it needs
+type Adapter int
+
+const (
+	AdapterDatabase Adapter = iota
+	// AdapterMigrationDatabase connects to the database as DB_MIGRATION_USER, when it is set, to change the schema
+	AdapterMigrationDatabase
+	AdapterRedis
+	AdapterKafkaConsumer
+	// AdapterKafkaProducer sets up the producer shared by the consumers' feeds, retries and dead letters
+	AdapterKafkaProducer
+	AdapterTracing
+	AdapterAuth
+	// AdapterHTTPClient sets up the client of the external services called by the HTTP API, like Alpha
+	AdapterHTTPClient
+)
+
+// SetupApplication loads the configuration and sets up the logger, the metrics and the given adapters
+func SetupApplication(ctx context.Context, adapters ...Adapter) (*App, error) {
+	configuration, err := config.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
 	app := &App{
 		Context: ctx,
-		Config:  config.LoadConfiguration(),
+		Config:  configuration,
+		Metrics: metrics.New(),
 	}
 
-	app.Consumer = consumer.NewConsumer(app.Config.Kafka.Consumer)
-	app.Redis = redis.NewRedis(app.Config.Redis)
-
 	loggerInstance, err := logger.NewLogger(logger.WithAppName(app.Config.AppName))
 	if err != nil {
 		return nil, err
 	}
 
 	app.Logger = loggerInstance
+	app.LogLevel = logging.NewLevel(loggerInstance, app.Config.LogLevel)
+	app.LiveConfig = config.NewLive(app.Config)
+
+	for _, adapter := range adapters {
+		if err = app.setup(adapter); err != nil {
+			return nil, err
+		}
+	}
+
+	return app, nil
+}
+
+func (app *App) setup(adapter Adapter) error {
+	switch adapter {
+	case AdapterDatabase:
+		return app.setupDatabase(app.Config.DatabaseConfig())
+	case AdapterMigrationDatabase:
+		return app.setupDatabase(migrationDatabaseConfig(app.Config.DatabaseConfig()))
+	case AdapterRedis:
+		redisConfig := app.Config.RedisConfig()
+		app.Redis = redis.NewRedis(redisConfig)
+		app.RedisClient = goredis.NewClient(&goredis.Options{
+			Addr:     fmt.Sprintf("%s:%d", redisConfig.Host, redisConfig.Port),
+			Password: redisConfig.Password,
+			DB:       redisConfig.Index,
+		})
+	case AdapterHTTPClient:
+		app.HTTPClient = client.NewClient(app.Context, app.Config.HTTPClient.ClientConfig)
+	case AdapterKafkaConsumer:
+		app.Consumer = consumer.NewConsumer(app.Config.Kafka.Consumer)
+	case AdapterKafkaProducer:
+		app.Producer = producer.NewProducer(app.Config.Kafka.Producer)
+	case AdapterTracing:
+		tracingProvider, err := tracing.NewProvider(app.Context, *app.Config.Tracing, app.Config.AppName)
+		if err != nil {
+			return err
+		}
 
-	dbInstance, err := db.NewDatabase(ctx, app.Config.Database)
+		app.Tracing = tracingProvider
+	case AdapterAuth:
+		// Verifier stays nil when the authentication is disabled
+		if !app.Config.Auth.Enabled {
+			return nil
+		}
+
+		verifier, err := auth.NewVerifier(*app.Config.Auth)
+		if err != nil {
+			return err
+		}
+
+		app.Verifier = verifier
+	}
+
+	return nil
+}
+
+func (app *App) setupDatabase(configuration *db.Configuration) error {
+	dbInstance, err := db.NewDatabase(app.Context, configuration)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	app.DB = dbInstance
 
-	return app, nil
+	return nil
 }
 
+// migrationDatabaseConfig returns the database configuration with the credentials of the migrations, when they are set
+func migrationDatabaseConfig(configuration *db.Configuration) *db.Configuration {
+	migration := *configuration
+	if configuration.Migration != nil && configuration.Migration.User != "" {
+		migration.User = configuration.Migration.User
+		migration.Password = configuration.Migration.Password
+	}
+
+	return &migration
+}
+
+// Close closes the adapters. The consumers must be stopped by then, so the messages they were producing are flushed.
 func (app *App) Close() {
+	if app.Producer != nil {
+		_ = app.Producer.Close()
+	}
+
 	if app.DB != nil {
 		_ = app.DB.Close()
 	}
 
+	if app.RedisClient != nil {
+		_ = app.RedisClient.Close()
+	}
+
+	// The app context is done by now, the buffered spans are exported within their own deadline
+	if app.Tracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		_ = app.Tracing.Shutdown(ctx)
+	}
+
 	app.Logger.Info("APP SUCCESSFULLY CLOSED")
 }
@@ -1,8 +1,10 @@
 package application
 
 import (
-	"github.com/dityuiri/go-adapter/client"
-	"github.com/dityuiri/go-adapter/kafka/producer"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/health"
+	"github.com/dityuiri/go-baseline/common/ratelimit"
 	"github.com/dityuiri/go-baseline/proxy"
 	"github.com/dityuiri/go-baseline/repository"
 	"github.com/dityuiri/go-baseline/service"
@@ -12,40 +14,74 @@ type Dependency struct {
 	HealthCheckService     service.IHealthCheckService
 	PlaceholderService     service.IPlaceholderService
 	PlaceholderFeedService service.IPlaceholderFeedService
+
+	// DeadLetterProducer receives the messages the consumers fail with. It and PlaceholderFeedService are nil
+	// unless the kafka producer is set up, for the consumers.
+	DeadLetterProducer repository.IDeadLetterProducer
+
+	// HealthRegistry is marked as shutting down once the app starts to stop
+	HealthRegistry *health.Registry
+
+	// RateLimiter is nil when the rate limiting is disabled
+	RateLimiter      ratelimit.ILimiter
+	IdempotencyCache repository.IIdempotencyCache
 }
 
 func SetupDependency(app *App) *Dependency {
 	// Repository and Proxy layer
-	healthCheckRepo := &repository.HealthCheckRepository{}
+	healthCheckRepo := &repository.HealthCheckRepository{
+		DB:           app.DB,
+		Redis:        app.Redis,
+		KafkaBrokers: app.Config.Kafka.Producer.Brokers,
+	}
 	//trxProducer := &repository.TransactionProducer{
-	//	Producer:    producer.NewProducer(app.Config.Kafka.Producer),
+	//	Producer:    app.Producer,
 	//	KafkaConfig: app.Config.Kafka,
 	//}
 
-	placeholderProducer := &repository.PlaceholderProducer{
-		Producer:    producer.NewProducer(app.Config.Kafka.Producer),
-		KafkaConfig: app.Config.Kafka,
-	}
-
 	placeholderRepo := &repository.PlaceholderRepository{
-		Logger: app.Logger,
-		DB:     app.DB,
+		DB:      app.DB,
+		Metrics: app.Metrics,
 	}
 
 	placeholderCache := &repository.PlaceholderCache{
-		Redis:  app.Redis,
-		Logger: app.Logger,
+		Redis:   app.Redis,
+		Metrics: app.Metrics,
+		Config:  app.LiveConfig,
 	}
 
 	alphaProxy := &proxy.AlphaProxy{
-		Logger:     app.Logger,
-		HTTPClient: client.NewClient(app.Context, app.Config.HTTPClient.ClientConfig),
+		Logger:              app.Logger,
+		HTTPClient:          app.HTTPClient,
+		ClientConfiguration: *app.Config.HTTPClient,
+		Metrics:             app.Metrics,
+	}
+
+	// The adapters which are not set up aren't checked
+	checks := map[string]health.CheckFunc{}
+
+	if app.Consumer != nil {
+		checks[common.HealthCheckKafka] = healthCheckRepo.PingKafka
+	}
+
+	if app.HTTPClient != nil {
+		checks[common.HealthCheckAlpha] = alphaProxy.Ping
+	}
+
+	if app.DB != nil {
+		checks[common.HealthCheckDatabase] = healthCheckRepo.PingDatabase
+	}
+
+	if app.Redis != nil {
+		checks[common.HealthCheckRedis] = healthCheckRepo.PingRedis
 	}
 
+	healthRegistry := setupHealthRegistry(app, checks)
+
 	// Service layer
 
 	healthCheckService := &service.HealthCheckService{
-		HealthCheckRepo: healthCheckRepo,
+		Registry: healthRegistry,
 	}
 
 	placeholderService := &service.PlaceholderService{
@@ -53,16 +89,72 @@ func SetupDependency(app *App) *Dependency {
 		PlaceholderRepository: placeholderRepo,
 		PlaceholderCache:      placeholderCache,
 		AlphaProxy:            alphaProxy,
+		Ownership: auth.Ownership{
+			BypassRoles: []string{common.RoleAdmin},
+		},
 	}
 
-	placeholderFeedService := &service.PlaceholderFeedService{
-		Logger:              app.Logger,
-		PlaceholderProducer: placeholderProducer,
+	dep := &Dependency{
+		HealthCheckService: healthCheckService,
+		PlaceholderService: placeholderService,
+		HealthRegistry:     healthRegistry,
+		RateLimiter:        setupRateLimiter(app),
+		IdempotencyCache: &repository.IdempotencyCache{
+			Redis:       app.Redis,
+			RedisClient: app.RedisClient,
+			Logger:      app.Logger,
+		},
+	}
+
+	// The producer is shared by the feeds and the dead letters of the consumers
+	if app.Producer != nil {
+		dep.PlaceholderFeedService = &service.PlaceholderFeedService{
+			Logger: app.Logger,
+			PlaceholderProducer: &repository.PlaceholderProducer{
+				Producer:    app.Producer,
+				KafkaConfig: app.Config.Kafka,
+				Metrics:     app.Metrics,
+			},
+		}
+
+		dep.DeadLetterProducer = &repository.DeadLetterProducer{
+			Producer:    app.Producer,
+			KafkaConfig: app.Config.Kafka,
+			Metrics:     app.Metrics,
+		}
 	}
 
-	return &Dependency{
-		HealthCheckService:     healthCheckService,
-		PlaceholderService:     placeholderService,
-		PlaceholderFeedService: placeholderFeedService,
+	return dep
+}
+
+// setupRateLimiter shares the limits between replicas through redis, or keeps them in memory for a single instance
+func setupRateLimiter(app *App) ratelimit.ILimiter {
+	if !app.Config.RateLimit.Enabled {
+		return nil
 	}
+
+	if app.Config.RateLimit.Backend == ratelimit.BackendRedis {
+		return &repository.RateLimitCache{
+			Redis:  app.RedisClient,
+			Logger: app.Logger,
+		}
+	}
+
+	return ratelimit.NewTokenBucket()
+}
+
+// setupHealthRegistry registers the checks with the timeout and the criticality configured for them
+func setupHealthRegistry(app *App, checks map[string]health.CheckFunc) *health.Registry {
+	registry := health.NewRegistry()
+
+	for name, check := range checks {
+		registry.Register(health.Check{
+			Name:     name,
+			Critical: app.Config.Health.IsCritical(name),
+			Timeout:  app.Config.Health.CheckTimeout(name),
+			Func:     check,
+		})
+	}
+
+	return registry
 }
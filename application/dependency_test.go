@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/client"
+	consumerMock "github.com/dityuiri/go-adapter/kafka/consumer/mock"
+	"github.com/dityuiri/go-adapter/kafka/producer"
+	producerMock "github.com/dityuiri/go-adapter/kafka/producer/mock"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/repository"
+	"github.com/dityuiri/go-baseline/service"
+)
+
+func TestSetupDependency_HealthChecks(t *testing.T) {
+	var (
+		newApp = func(t *testing.T) *App {
+			configuration := &config.Configuration{
+				Kafka:      &config.Kafka{Producer: &producer.Configuration{Brokers: []string{"localhost:9092"}}},
+				HTTPClient: &config.HttpClient{ClientConfig: &client.Configuration{}},
+				Health:     &config.Health{},
+				RateLimit:  &config.RateLimit{},
+			}
+
+			return &App{
+				Context:    context.Background(),
+				Config:     configuration,
+				Logger:     loggerMock.NewMockILogger(gomock.NewController(t)),
+				Metrics:    metrics.New(),
+				LiveConfig: config.NewLive(configuration),
+			}
+		}
+
+		checkNames = func(dep *Dependency) []string {
+			var names []string
+			for _, check := range dep.HealthRegistry.Checks() {
+				names = append(names, check.Name)
+			}
+
+			sort.Strings(names)
+			return names
+		}
+	)
+
+	t.Run("positive - consumer checks only kafka", func(t *testing.T) {
+		app := newApp(t)
+		app.Consumer = consumerMock.NewMockIConsumer(gomock.NewController(t))
+
+		assert.Equal(t, []string{"kafka"}, checkNames(SetupDependency(app)))
+	})
+
+	t.Run("positive - HTTP API checks the external services, not kafka", func(t *testing.T) {
+		app := newApp(t)
+		app.HTTPClient = client.NewClient(app.Context, app.Config.HTTPClient.ClientConfig)
+
+		assert.Equal(t, []string{"alpha"}, checkNames(SetupDependency(app)))
+	})
+}
+
+func TestSetupDependency_Producer(t *testing.T) {
+	var (
+		configuration = &config.Configuration{
+			Kafka:      &config.Kafka{Producer: &producer.Configuration{Brokers: []string{"localhost:9092"}}},
+			HTTPClient: &config.HttpClient{ClientConfig: &client.Configuration{}},
+			Health:     &config.Health{},
+			RateLimit:  &config.RateLimit{},
+		}
+
+		app = &App{
+			Context:    context.Background(),
+			Config:     configuration,
+			Logger:     loggerMock.NewMockILogger(gomock.NewController(t)),
+			Metrics:    metrics.New(),
+			LiveConfig: config.NewLive(configuration),
+		}
+	)
+
+	t.Run("positive - HTTP API produces nothing", func(t *testing.T) {
+		dep := SetupDependency(app)
+		assert.Nil(t, dep.PlaceholderFeedService)
+		assert.Nil(t, dep.DeadLetterProducer)
+	})
+
+	t.Run("positive - consumers share the producer of the app", func(t *testing.T) {
+		app.Producer = producerMock.NewMockIProducer(gomock.NewController(t))
+
+		dep := SetupDependency(app)
+		assert.Same(t, app.Producer, dep.DeadLetterProducer.(*repository.DeadLetterProducer).Producer)
+
+		feed := dep.PlaceholderFeedService.(*service.PlaceholderFeedService)
+		assert.Same(t, app.Producer, feed.PlaceholderProducer.(*repository.PlaceholderProducer).Producer)
+	})
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+// WatchConfiguration reloads the reloadable settings of the running configuration whenever the configuration file
+// changes, and applies the reloaded LOG_LEVEL. Every reload is logged with its changes, changes of the settings
+// needing a restart are rejected with a warning. It returns false when there is no configuration file to watch.
+func WatchConfiguration(ctx context.Context, live *config.Live, level *logging.Level, logger logger.ILogger) bool {
+	live.Subscribe(func(previous, current *config.Configuration) {
+		if previous.LogLevel == current.LogLevel {
+			return
+		}
+
+		if err := level.Set(current.LogLevel); err != nil {
+			logging.WithContext(ctx, logger).Error("failed to apply the reloaded log level", log.WithError(err))
+		}
+	})
+
+	return live.Watch(func(applied, rejected config.Changes, err error) {
+		logReload(ctx, logger, applied, rejected, err)
+	})
+}
+
+func logReload(ctx context.Context, logger logger.ILogger, applied, rejected config.Changes, err error) {
+	if err != nil {
+		logging.WithContext(ctx, logger).Error("configuration reload is rejected, the running configuration is kept", log.WithError(err))
+		return
+	}
+
+	if len(rejected) > 0 {
+		logging.WithContext(ctx, logger, "changes", rejected.String()).Warn("configuration changes need a restart, they are not applied")
+	}
+
+	if len(applied) > 0 {
+		logging.WithContext(ctx, logger, "changes", applied.String()).Info("configuration reloaded")
+	}
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/logger"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/mock"
+)
+
+const testConfiguration = `APP_NAME=go-baseline
+REDIS_HOST=localhost
+KAFKA_BROKERS=localhost:9092
+KAFKA_GROUP_ID=go-baseline
+ALPHA_URL=http://localhost:8700
+DB_HOST=localhost
+DB_USER=username
+DB_NAME=placeholder
+AUTH_ENABLED=false
+`
+
+func TestWatchConfiguration(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+
+		dir  = t.TempDir()
+		file = filepath.Join(dir, "test.env")
+		ctx  = context.Background()
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+	mockLogger.EXPECT().SetLevel(logger.Level.INFO)
+
+	t.Setenv("CONFIG_DIR", dir)
+	assert.Nil(t, os.WriteFile(file, []byte(testConfiguration+"LOG_LEVEL=INFO\n"), 0o600))
+
+	configuration, err := config.Load("test")
+	assert.Nil(t, err)
+
+	var (
+		live  = config.NewLive(configuration)
+		level = logging.NewLevel(mockLogger, configuration.LogLevel)
+	)
+
+	assert.True(t, WatchConfiguration(ctx, live, level, mockLogger))
+
+	mockLogger.EXPECT().SetLevel(logger.Level.WARN)
+	mockLogger.EXPECT().Info("configuration reloaded", mock.LogContaining(`LOG_LEVEL: \"INFO\" -> \"WARN\"`))
+	mockLogger.EXPECT().Warn("configuration changes need a restart, they are not applied", mock.LogContaining(`HTTP_PORT: \"8080\" -> \"8081\"`))
+
+	assert.Nil(t, os.WriteFile(file, []byte(testConfiguration+"LOG_LEVEL=WARN\nHTTP_PORT=8081\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		return level.String() == "WARN" && live.Load().LogLevel == "WARN"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 8080, live.Load().Const.HTTPPort)
+}
+
+func TestLogReload(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+		ctx        = context.Background()
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("negative - invalid configuration is rejected", func(t *testing.T) {
+		err := &config.ValidationError{Problems: []config.Problem{{Key: "SHORT_TIMEOUT", Message: "is required"}}}
+		mockLogger.EXPECT().Error("configuration reload is rejected, the running configuration is kept", mock.LogWith(err)).Times(1)
+
+		logReload(ctx, mockLogger, nil, nil, err)
+	})
+
+	t.Run("positive - nothing changed", func(t *testing.T) {
+		logReload(ctx, mockLogger, nil, nil, nil)
+	})
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common/health"
+	"github.com/dityuiri/go-baseline/common/retry"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+const startupBackoffJitter = 0.2
+
+// WaitForDependencies retries every health check of the registry until its dependency is available, keeping
+// the app unready meanwhile. It gives up after the configured maximum wait, returning the errors of the
+// critical dependencies still unavailable. Unavailable non critical dependencies are only logged.
+func WaitForDependencies(ctx context.Context, registry *health.Registry, cfg *config.Startup, logger logger.ILogger) error {
+	registry.BeginStartup()
+
+	var (
+		checks  = registry.Checks()
+		backoff = retry.Backoff{
+			Initial: cfg.InitialBackoff,
+			Max:     cfg.MaxBackoff,
+			Jitter:  startupBackoffJitter,
+		}
+
+		wg   sync.WaitGroup
+		errs = make([]error, len(checks))
+	)
+
+	for i, check := range checks {
+		wg.Add(1)
+
+		go func(i int, check health.Check) {
+			defer wg.Done()
+
+			err := retry.Do(ctx, backoff, cfg.MaxWait, check.Run, func(attempt int, err error, wait time.Duration) {
+				logger.Warn(fmt.Sprintf("waiting for %s (attempt %d), retrying in %s", check.Name, attempt, wait.Round(time.Millisecond)), log.WithError(err))
+			})
+
+			switch {
+			case err == nil:
+				logger.Info(fmt.Sprintf("%s is available", check.Name))
+			case check.Critical:
+				errs[i] = fmt.Errorf("%s is not available after %s: %w", check.Name, cfg.MaxWait, err)
+			default:
+				logger.Warn(fmt.Sprintf("%s is not available, starting degraded", check.Name), log.WithError(err))
+			}
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("dependencies are not available: %w", err)
+	}
+
+	registry.EndStartup()
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/health"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	var (
+		ctx = context.Background()
+		cfg = &config.Startup{
+			MaxWait:        50 * time.Millisecond,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+		}
+
+		up   = func(context.Context) error { return nil }
+		down = func(context.Context) error { return errors.New("connection refused") }
+	)
+
+	t.Run("positive - dependency becomes available", func(t *testing.T) {
+		mockLogger := loggerMock.NewMockILogger(gomock.NewController(t))
+
+		var (
+			registry = health.NewRegistry()
+			attempts int
+		)
+
+		registry.Register(health.Check{Name: "db", Critical: true, Func: func(context.Context) error {
+			if attempts++; attempts < 3 {
+				return errors.New("connection refused")
+			}
+
+			return nil
+		}})
+
+		mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).Times(2)
+		mockLogger.EXPECT().Info(gomock.Any()).Times(1)
+
+		err := WaitForDependencies(ctx, registry, cfg, mockLogger)
+		assert.Nil(t, err)
+		assert.False(t, registry.IsStarting())
+	})
+
+	t.Run("positive - non critical dependency stays unavailable", func(t *testing.T) {
+		mockLogger := loggerMock.NewMockILogger(gomock.NewController(t))
+
+		registry := health.NewRegistry()
+		registry.Register(health.Check{Name: "db", Critical: true, Func: up})
+		registry.Register(health.Check{Name: "alpha", Func: down})
+
+		mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).MinTimes(1)
+		mockLogger.EXPECT().Info(gomock.Any()).Times(1)
+
+		err := WaitForDependencies(ctx, registry, cfg, mockLogger)
+		assert.Nil(t, err)
+		assert.False(t, registry.IsStarting())
+	})
+
+	t.Run("negative - critical dependencies stay unavailable", func(t *testing.T) {
+		mockLogger := loggerMock.NewMockILogger(gomock.NewController(t))
+
+		registry := health.NewRegistry()
+		registry.Register(health.Check{Name: "db", Critical: true, Func: down})
+		registry.Register(health.Check{Name: "redis", Critical: true, Func: down})
+
+		mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).MinTimes(2)
+
+		err := WaitForDependencies(ctx, registry, cfg, mockLogger)
+		assert.ErrorContains(t, err, "db is not available after 50ms: connection refused")
+		assert.ErrorContains(t, err, "redis is not available after 50ms: connection refused")
+		assert.True(t, registry.IsStarting())
+	})
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// program is the name the commands are invoked with in the help texts
+const program = "go-baseline"
+
+// Exit codes shared by the commands
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+type (
+	// streams are where a command writes its output and its errors
+	streams struct {
+		out io.Writer
+		err io.Writer
+	}
+
+	// command is a node of the command tree. A command either runs or groups subcommands.
+	command struct {
+		name  string
+		args  string
+		short string
+		help  string
+
+		// setup defines the flags of the command and returns the function running it with the arguments left
+		setup func(flags *flag.FlagSet) runFunc
+
+		subcommands []*command
+	}
+
+	// runFunc runs a command and returns its exit code
+	runFunc func(ctx context.Context, s streams, args []string) int
+)
+
+// runCLI runs the command named by args, all when there is none, and returns the exit code
+func runCLI(ctx context.Context, args []string, s streams) int {
+	if len(args) == 0 {
+		args = []string{"all"}
+	}
+
+	return rootCommand().execute(ctx, s, program, args)
+}
+
+func rootCommand() *command {
+	return &command{
+		name: program,
+		help: "Runs the placeholder service, its kafka consumers and its operational tasks.",
+		subcommands: []*command{
+			serveCommand(),
+			consumeCommand(),
+			allCommand(),
+			migrateCommand(),
+			seedCommand(),
+			configCommand(),
+			dlqCommand(),
+			versionCommand(),
+			healthcheckCommand(),
+		},
+	}
+}
+
+func (c *command) execute(ctx context.Context, s streams, path string, args []string) int {
+	if len(c.subcommands) == 0 {
+		return c.run(ctx, s, path, args)
+	}
+
+	if len(args) == 0 {
+		c.printUsage(s.err, path)
+		return exitUsage
+	}
+
+	if isHelp(args[0]) {
+		c.printUsage(s.out, path)
+		return exitOK
+	}
+
+	for _, subcommand := range c.subcommands {
+		if subcommand.name == args[0] {
+			return subcommand.execute(ctx, s, path+" "+subcommand.name, args[1:])
+		}
+	}
+
+	_, _ = fmt.Fprintf(s.err, "unknown command %q\n\n", args[0])
+	c.printUsage(s.err, path)
+
+	return exitUsage
+}
+
+func (c *command) run(ctx context.Context, s streams, path string, args []string) int {
+	flags := flag.NewFlagSet(path, flag.ContinueOnError)
+	flags.SetOutput(s.err)
+	flags.Usage = func() {
+		c.printUsage(flags.Output(), path)
+		flags.PrintDefaults()
+	}
+
+	run := c.setup(flags)
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+
+		return exitUsage
+	}
+
+	if c.args == "" && flags.NArg() > 0 {
+		_, _ = fmt.Fprintf(s.err, "unexpected arguments %q\n\n", flags.Args())
+		flags.Usage()
+
+		return exitUsage
+	}
+
+	return run(ctx, s, flags.Args())
+}
+
+func (c *command) printUsage(out io.Writer, path string) {
+	usage := path
+	switch {
+	case len(c.subcommands) > 0:
+		usage += " <command>"
+	case c.args != "":
+		usage += " [flags] " + c.args
+	default:
+		usage += " [flags]"
+	}
+
+	_, _ = fmt.Fprintf(out, "usage: %s\n", usage)
+
+	if description := c.description(); description != "" {
+		_, _ = fmt.Fprintf(out, "\n%s\n", description)
+	}
+
+	if len(c.subcommands) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, "\ncommands:")
+	for _, subcommand := range c.subcommands {
+		_, _ = fmt.Fprintf(out, "  %-12s %s\n", subcommand.name, subcommand.short)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nRun '%s <command> -h' for the help of a command.\n", path)
+}
+
+func (c *command) description() string {
+	return strings.TrimSpace(strings.Join([]string{c.short, c.help}, "\n\n"))
+}
+
+func isHelp(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help" || arg == "help"
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCLI(t *testing.T) {
+	var ctx = context.Background()
+
+	t.Run("positive - help lists the commands", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"-h"}, streams{out: &out, err: &errOut}))
+		for _, name := range []string{"serve", "consume", "all", "migrate", "seed", "config", "version", "healthcheck"} {
+			assert.Contains(t, out.String(), "\n  "+name+" ")
+		}
+	})
+
+	t.Run("positive - help of a command", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"migrate", "up", "-h"}, streams{out: &out, err: &errOut}))
+		assert.Contains(t, errOut.String(), "usage: go-baseline migrate up [flags]")
+		assert.Contains(t, errOut.String(), "-steps")
+	})
+
+	t.Run("negative - unknown command", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"client"}, streams{out: &out, err: &errOut}))
+		assert.Contains(t, errOut.String(), `unknown command "client"`)
+		assert.Contains(t, errOut.String(), "usage: go-baseline <command>")
+	})
+
+	t.Run("negative - command group without a command", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"migrate"}, streams{out: &out, err: &errOut}))
+		assert.Contains(t, errOut.String(), "usage: go-baseline migrate <command>")
+	})
+
+	t.Run("negative - unknown flag", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"serve", "-verbose"}, streams{out: &out, err: &errOut}))
+		assert.Contains(t, errOut.String(), "flag provided but not defined: -verbose")
+	})
+
+	t.Run("negative - unexpected argument", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"version", "now"}, streams{out: &out, err: &errOut}))
+		assert.Contains(t, errOut.String(), `unexpected arguments ["now"]`)
+	})
+}
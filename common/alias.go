@@ -1,9 +1,13 @@
 package common
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Alias for function patch that can't be easily mocked
 var (
 	JsonMarshal   = json.Marshal
 	JsonUnmarshal = json.Unmarshal
+	TimeNow       = time.Now
 )
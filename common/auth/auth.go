@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+)
+
+type (
+	// Principal is the authenticated caller of a request
+	Principal struct {
+		Subject  string
+		Issuer   string
+		Audience []string
+		Roles    []string
+		Scopes   []string
+	}
+
+	contextKey struct{}
+)
+
+// NewContext returns a copy of ctx holding the principal
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal held by ctx, the bool is false when the request is not authenticated
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+	return principal, ok
+}
+
+// SubjectFromContext returns the subject of the principal held by ctx, or an empty string when there is none
+func SubjectFromContext(ctx context.Context) string {
+	principal, _ := FromContext(ctx)
+	return principal.Subject
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		principal := Principal{Subject: "user-1", Roles: []string{"admin"}}
+		ctx := NewContext(context.Background(), principal)
+
+		result, ok := FromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, principal, result)
+		assert.Equal(t, "user-1", SubjectFromContext(ctx))
+	})
+
+	t.Run("negative - not authenticated", func(t *testing.T) {
+		_, ok := FromContext(context.Background())
+		assert.False(t, ok)
+		assert.Empty(t, SubjectFromContext(context.Background()))
+	})
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type (
+	jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	// jwk holds the members of RSA and EC public JSON Web Keys (RFC 7517)
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+)
+
+// loadJWKS loads the signing keys of a local JWKS file. Keys of other types or usages are skipped.
+func (v *Verifier) loadJWKS(path string) error {
+	var keySet jwks
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading jwks: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &keySet); err != nil {
+		return fmt.Errorf("parsing jwks: %w", err)
+	}
+
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		switch key.Kty {
+		case "RSA":
+			rsaKey, err := key.rsaPublicKey()
+			if err != nil {
+				return err
+			}
+
+			v.addRSAKey(key.Kid, rsaKey)
+		case "EC":
+			ecKey, err := key.ecPublicKey()
+			if err != nil {
+				return err
+			}
+
+			if err = v.addECKey(key.Kid, ecKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("jwk %q has an invalid modulus: %w", k.Kid, err)
+	}
+
+	e, err := decodeBigInt(k.E)
+	if err != nil || !e.IsInt64() {
+		return nil, fmt.Errorf("jwk %q has an invalid exponent", k.Kid)
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecPublicKey() (*ecdsa.PublicKey, error) {
+	if k.Crv != "P-256" {
+		return nil, fmt.Errorf("jwk %q uses unsupported curve %q", k.Kid, k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("jwk %q has an invalid x coordinate: %w", k.Kid, err)
+	}
+
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("jwk %q has an invalid y coordinate: %w", k.Kid, err)
+	}
+
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	if !key.Curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("jwk %q point is not on the P-256 curve", k.Kid)
+	}
+
+	return key, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
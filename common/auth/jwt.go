@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+//go:generate mockgen -package=auth_mock -destination=../../mock/auth/jwt.go . IVerifier
+
+var (
+	ErrNoVerificationKey = errors.New("no jwt verification key is configured")
+	ErrUnknownKey        = errors.New("no verification key matches the token")
+)
+
+type (
+	IVerifier interface {
+		Verify(token string) (Principal, error)
+	}
+
+	// Verifier verifies HS256 tokens with a shared secret, and RS256/ES256 tokens with
+	// public keys loaded from a PEM file or a JWKS file
+	Verifier struct {
+		hmacSecret []byte
+		rsaKeys    []*rsa.PublicKey
+		ecKeys     []*ecdsa.PublicKey
+		keysByID   map[string]interface{}
+
+		parser *jwt.Parser
+	}
+
+	claims struct {
+		jwt.RegisteredClaims
+		Roles []string `json:"roles,omitempty"`
+		Scope string   `json:"scope,omitempty"`
+	}
+)
+
+func NewVerifier(cfg config.Auth) (*Verifier, error) {
+	verifier := &Verifier{
+		hmacSecret: []byte(cfg.HMACSecret),
+		keysByID:   make(map[string]interface{}),
+	}
+
+	if cfg.PublicKeyFile != "" {
+		if err := verifier.loadPEM(cfg.PublicKeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.JWKSFile != "" {
+		if err := verifier.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var methods []string
+	if len(verifier.hmacSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if len(verifier.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(verifier.ecKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodES256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, ErrNoVerificationKey
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Duration(cfg.Leeway) * time.Second),
+	}
+
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+
+	verifier.parser = jwt.NewParser(options...)
+
+	return verifier, nil
+}
+
+// Verify checks the token signature, exp, nbf, aud and iss, then returns the principal of the token
+func (v *Verifier) Verify(token string) (Principal, error) {
+	var tokenClaims claims
+
+	_, err := v.parser.ParseWithClaims(token, &tokenClaims, v.key)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	return Principal{
+		Subject:  tokenClaims.Subject,
+		Issuer:   tokenClaims.Issuer,
+		Audience: tokenClaims.Audience,
+		Roles:    tokenClaims.Roles,
+		Scopes:   strings.Fields(tokenClaims.Scope),
+	}, nil
+}
+
+// key picks the verification key by the token kid, or by the token algorithm when there is no kid
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		if key, ok := v.keysByID[kid]; ok {
+			return key, nil
+		}
+	}
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		keys := make([]jwt.VerificationKey, 0, len(v.rsaKeys))
+		for _, key := range v.rsaKeys {
+			keys = append(keys, key)
+		}
+
+		return jwt.VerificationKeySet{Keys: keys}, nil
+	case jwt.SigningMethodES256.Alg():
+		keys := make([]jwt.VerificationKey, 0, len(v.ecKeys))
+		for _, key := range v.ecKeys {
+			keys = append(keys, key)
+		}
+
+		return jwt.VerificationKeySet{Keys: keys}, nil
+	default:
+		return nil, ErrUnknownKey
+	}
+}
+
+func (v *Verifier) loadPEM(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading jwt public key: %w", err)
+	}
+
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		v.addRSAKey("", rsaKey)
+		return nil
+	}
+
+	ecKey, err := jwt.ParseECPublicKeyFromPEM(data)
+	if err != nil {
+		return fmt.Errorf("jwt public key is neither an RSA nor an ECDSA PEM key: %w", err)
+	}
+
+	return v.addECKey("", ecKey)
+}
+
+func (v *Verifier) addRSAKey(kid string, key *rsa.PublicKey) {
+	v.rsaKeys = append(v.rsaKeys, key)
+	if kid != "" {
+		v.keysByID[kid] = key
+	}
+}
+
+func (v *Verifier) addECKey(kid string, key *ecdsa.PublicKey) error {
+	// ES256 is only defined on P-256
+	if key.Curve != elliptic.P256() {
+		return fmt.Errorf("jwt ecdsa key %q is not on the P-256 curve", kid)
+	}
+
+	v.ecKeys = append(v.ecKeys, key)
+	if kid != "" {
+		v.keysByID[kid] = key
+	}
+
+	return nil
+}
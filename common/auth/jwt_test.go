@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	assert.Nil(t, err)
+
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "issuer",
+		"aud":   "baseline",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"roles": []string{"admin"},
+		"scope": "placeholder:read placeholder:write",
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("negative - no verification key", func(t *testing.T) {
+		_, err := NewVerifier(config.Auth{})
+		assert.Equal(t, ErrNoVerificationKey, err)
+	})
+
+	t.Run("negative - missing public key file", func(t *testing.T) {
+		_, err := NewVerifier(config.Auth{PublicKeyFile: filepath.Join(t.TempDir(), "missing.pem")})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("negative - invalid jwks file", func(t *testing.T) {
+		_, err := NewVerifier(config.Auth{JWKSFile: writeFile(t, "jwks.json", []byte("sausage"))})
+		assert.ErrorContains(t, err, "parsing jwks")
+	})
+}
+
+func TestVerifier_Verify_HS256(t *testing.T) {
+	verifier, err := NewVerifier(config.Auth{
+		HMACSecret: testSecret,
+		Audience:   "baseline",
+		Issuer:     "issuer",
+	})
+	assert.Nil(t, err)
+
+	t.Run("positive", func(t *testing.T) {
+		principal, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+		assert.Nil(t, err)
+		assert.Equal(t, Principal{
+			Subject:  "user-1",
+			Issuer:   "issuer",
+			Audience: []string{"baseline"},
+			Roles:    []string{"admin"},
+			Scopes:   []string{"placeholder:read", "placeholder:write"},
+		}, principal)
+	})
+
+	t.Run("negative - wrong secret", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("negative - expired", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("negative - missing exp", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "exp")
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+	})
+
+	t.Run("negative - not valid yet", func(t *testing.T) {
+		claims := validClaims()
+		claims["nbf"] = time.Now().Add(time.Minute).Unix()
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenNotValidYet)
+	})
+
+	t.Run("negative - wrong audience", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = "other"
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
+	t.Run("negative - wrong issuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "other"
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+	})
+
+	t.Run("negative - algorithm is not configured", func(t *testing.T) {
+		key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, key, "", validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("negative - malformed token", func(t *testing.T) {
+		_, err := verifier.Verify("sausage")
+		assert.ErrorIs(t, err, jwt.ErrTokenMalformed)
+	})
+}
+
+func TestVerifier_Verify_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+
+	verifier, err := NewVerifier(config.Auth{
+		PublicKeyFile: writeFile(t, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	})
+	assert.Nil(t, err)
+
+	t.Run("positive", func(t *testing.T) {
+		principal, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, key, "", validClaims()))
+		assert.Nil(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("negative - HS256 is not configured", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+}
+
+func TestVerifier_Verify_JWKS(t *testing.T) {
+	var (
+		ecKey, _    = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		otherKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		rsaKey, _   = rsa.GenerateKey(rand.Reader, 2048)
+
+		encode = func(n *big.Int) string {
+			return base64.RawURLEncoding.EncodeToString(n.Bytes())
+		}
+	)
+
+	keySet, err := json.Marshal(jwks{Keys: []jwk{
+		{Kty: "EC", Kid: "ec-1", Use: "sig", Crv: "P-256", X: encode(ecKey.X), Y: encode(ecKey.Y)},
+		{Kty: "EC", Kid: "ec-2", Use: "sig", Crv: "P-256", X: encode(otherKey.X), Y: encode(otherKey.Y)},
+		{Kty: "RSA", Kid: "rsa-1", N: encode(rsaKey.N), E: encode(big.NewInt(int64(rsaKey.E)))},
+		{Kty: "RSA", Kid: "enc-1", Use: "enc", N: "sausage", E: "sausage"},
+	}})
+	assert.Nil(t, err)
+
+	verifier, err := NewVerifier(config.Auth{JWKSFile: writeFile(t, "jwks.json", keySet)})
+	assert.Nil(t, err)
+
+	t.Run("positive - ES256 by kid", func(t *testing.T) {
+		principal, err := verifier.Verify(signToken(t, jwt.SigningMethodES256, otherKey, "ec-2", validClaims()))
+		assert.Nil(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("positive - ES256 without kid", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodES256, ecKey, "", validClaims()))
+		assert.Nil(t, err)
+	})
+
+	t.Run("positive - RS256 by kid", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims()))
+		assert.Nil(t, err)
+	})
+
+	t.Run("negative - kid of another key", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodES256, ecKey, "ec-2", validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("negative - unknown signing key", func(t *testing.T) {
+		unknownKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+		_, err := verifier.Verify(signToken(t, jwt.SigningMethodES256, unknownKey, "", validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("negative - key is not on the curve", func(t *testing.T) {
+		keySet, _ := json.Marshal(jwks{Keys: []jwk{
+			{Kty: "EC", Kid: "ec-1", Crv: "P-256", X: encode(big.NewInt(1)), Y: encode(big.NewInt(1))},
+		}})
+
+		_, err := NewVerifier(config.Auth{JWKSFile: writeFile(t, "jwks.json", keySet)})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+type (
+	// Requirement is met by a principal holding any of the roles or any of the scopes.
+	// An empty requirement is met by every authenticated principal.
+	Requirement struct {
+		Roles  []string
+		Scopes []string
+	}
+
+	// Policy holds the requirement of every route keyed by Route(method, pattern)
+	Policy map[string]Requirement
+
+	// Ownership lets principals modify the resources they created.
+	// Principals holding any of the BypassRoles may modify every resource.
+	Ownership struct {
+		BypassRoles []string
+	}
+)
+
+// Route builds the Policy key of the route with the given method and chi pattern
+func Route(method, pattern string) string {
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+
+	return strings.ToUpper(method) + " " + pattern
+}
+
+// HasRole reports whether the principal holds the role
+func (p Principal) HasRole(role string) bool {
+	return contains(p.Roles, role)
+}
+
+// HasScope reports whether the principal was granted the scope
+func (p Principal) HasScope(scope string) bool {
+	return contains(p.Scopes, scope)
+}
+
+// Allows reports whether the principal meets the requirement
+func (r Requirement) Allows(principal Principal) bool {
+	if len(r.Roles) == 0 && len(r.Scopes) == 0 {
+		return true
+	}
+
+	for _, role := range r.Roles {
+		if principal.HasRole(role) {
+			return true
+		}
+	}
+
+	for _, scope := range r.Scopes {
+		if principal.HasScope(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns common.ErrNotOwner when the principal of ctx is neither the owner nor holds a bypass role.
+// Requests without a principal, like the ones of an unauthenticated deployment, are not checked.
+func (o Ownership) Check(ctx context.Context, owner string) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if owner != "" && principal.Subject == owner {
+		return nil
+	}
+
+	for _, role := range o.BypassRoles {
+		if principal.HasRole(role) {
+			return nil
+		}
+	}
+
+	return common.ErrNotOwner
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
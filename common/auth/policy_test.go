@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+func TestRoute(t *testing.T) {
+	assert.Equal(t, "GET /v1/placeholder", Route("get", "/v1/placeholder/"))
+	assert.Equal(t, "DELETE /v1/placeholder/{placeholderID}", Route(http.MethodDelete, "/v1/placeholder/{placeholderID}"))
+	assert.Equal(t, "GET /", Route(http.MethodGet, "/"))
+}
+
+func TestRequirement_Allows(t *testing.T) {
+	var (
+		requirement = Requirement{Roles: []string{"admin"}, Scopes: []string{"placeholder:write"}}
+	)
+
+	t.Run("positive - any role", func(t *testing.T) {
+		assert.True(t, requirement.Allows(Principal{Roles: []string{"viewer", "admin"}}))
+	})
+
+	t.Run("positive - any scope", func(t *testing.T) {
+		assert.True(t, requirement.Allows(Principal{Scopes: []string{"placeholder:write"}}))
+	})
+
+	t.Run("positive - empty requirement", func(t *testing.T) {
+		assert.True(t, Requirement{}.Allows(Principal{}))
+	})
+
+	t.Run("negative - neither role nor scope", func(t *testing.T) {
+		assert.False(t, requirement.Allows(Principal{Roles: []string{"viewer"}, Scopes: []string{"placeholder:read"}}))
+	})
+}
+
+func TestOwnership_Check(t *testing.T) {
+	var (
+		ownership = Ownership{BypassRoles: []string{"admin"}}
+		ctx       = context.Background()
+	)
+
+	t.Run("positive - owner", func(t *testing.T) {
+		assert.Nil(t, ownership.Check(NewContext(ctx, Principal{Subject: "user-1"}), "user-1"))
+	})
+
+	t.Run("positive - bypass role", func(t *testing.T) {
+		assert.Nil(t, ownership.Check(NewContext(ctx, Principal{Subject: "user-2", Roles: []string{"admin"}}), "user-1"))
+	})
+
+	t.Run("positive - not authenticated", func(t *testing.T) {
+		assert.Nil(t, ownership.Check(ctx, "user-1"))
+	})
+
+	t.Run("negative - not the owner", func(t *testing.T) {
+		assert.Equal(t, common.ErrNotOwner, ownership.Check(NewContext(ctx, Principal{Subject: "user-2"}), "user-1"))
+	})
+
+	t.Run("negative - resource without owner", func(t *testing.T) {
+		assert.Equal(t, common.ErrNotOwner, ownership.Check(NewContext(ctx, Principal{}), ""))
+	})
+}
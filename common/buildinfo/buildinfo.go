@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit and BuildTime are set when building the binary, see the build target of the Makefile:
+//
+//	go build -ldflags "-X github.com/dityuiri/go-baseline/common/buildinfo.Commit=$(git rev-parse HEAD)"
+var (
+	Commit    = ""
+	BuildTime = ""
+)
+
+const unknown = "unknown"
+
+// Info describes the build of the running binary
+type Info struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build of the running binary. Without ldflags, the commit and the time
+// of the VCS revision embedded by the go command are used instead.
+func Get() Info {
+	info := Info{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+
+	if info.BuildTime == "" {
+		info.BuildTime = unknown
+	}
+
+	return info
+}
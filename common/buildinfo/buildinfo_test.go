@@ -0,0 +1,24 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("positive - set with ldflags", func(t *testing.T) {
+		Commit, BuildTime = "abc123", "2024-01-01T00:00:00Z"
+		defer func() { Commit, BuildTime = "", "" }()
+
+		assert.Equal(t, Info{Commit: "abc123", BuildTime: "2024-01-01T00:00:00Z", GoVersion: runtime.Version()}, Get())
+	})
+
+	t.Run("positive - unset", func(t *testing.T) {
+		info := Get()
+		assert.NotEmpty(t, info.Commit)
+		assert.NotEmpty(t, info.BuildTime)
+		assert.Equal(t, runtime.Version(), info.GoVersion)
+	})
+}
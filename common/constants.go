@@ -2,7 +2,31 @@ package common
 
 const (
 	// API Result Key
-	PlaceholderKey = "placeholder"
+	PlaceholderKey  = "placeholder"
+	PlaceholdersKey = "placeholders"
+
+	// Pagination
+	DefaultPage     = 1
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// Sorting
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+
+	// Roles and scopes granted by the bearer token
+	RoleAdmin             = "admin"
+	ScopePlaceholderRead  = "placeholder:read"
+	ScopePlaceholderWrite = "placeholder:write"
+
+	// Health checks, named as in HEALTH_CHECK_TIMEOUTS and HEALTH_NON_CRITICAL
+	HealthCheckDatabase = "db"
+	HealthCheckRedis    = "redis"
+	HealthCheckKafka    = "kafka"
+	HealthCheckAlpha    = "alpha"
+
+	// Logical topics, named as in CONSUMER_TOPICS and PRODUCER_TOPICS
+	TopicPlaceholder = "placeholder"
 
 	// Event name
 	EventPlaceholderRecorded = "PlaceholderRecorded"
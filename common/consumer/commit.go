@@ -0,0 +1,97 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	kafkaConsumer "github.com/dityuiri/go-adapter/kafka/consumer"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+// committer commits the offsets of the messages of a topic once they are done with, by batches of size messages
+// or interval after the first message of the batch. Only the goroutine consuming the topic uses it: the messages
+// are done with in the order they are fetched, so committing the last one of a partition commits the ones before.
+type committer struct {
+	consumer kafkaConsumer.IConsumer
+	topic    string
+	size     int
+	interval time.Duration
+
+	// pending holds the last message done with of every partition, since the last commit
+	pending map[int]*kafka.Message
+	count   int
+	due     time.Time
+}
+
+func newCommitter(consumer kafkaConsumer.IConsumer, topic string, size int, interval time.Duration) *committer {
+	if size < 1 {
+		size = 1
+	}
+
+	return &committer{
+		consumer: consumer,
+		topic:    topic,
+		size:     size,
+		interval: interval,
+		pending:  make(map[int]*kafka.Message),
+	}
+}
+
+// done marks the message as done with. It returns true once the batch is full and it should be committed.
+func (c *committer) done(msg *kafka.Message) bool {
+	if len(c.pending) == 0 && c.interval > 0 {
+		c.due = common.TimeNow().Add(c.interval)
+	}
+
+	c.pending[msg.Partition] = msg
+	c.count++
+
+	return c.count >= c.size
+}
+
+// dueAt returns the time the pending offsets are committed at, false when nothing is pending or they are
+// committed by batches only
+func (c *committer) dueAt() (time.Time, bool) {
+	return c.due, len(c.pending) > 0 && c.interval > 0
+}
+
+// commit commits the offset of every partition with pending messages. It commits even once ctx is done,
+// the messages done with would be handled again otherwise. The partitions failing to commit stay pending for
+// the next batch, or the next interval, rather than being committed again on every message.
+func (c *committer) commit() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), produceTimeout)
+	defer cancel()
+
+	partitions := make([]int, 0, len(c.pending))
+	for partition := range c.pending {
+		partitions = append(partitions, partition)
+	}
+
+	sort.Ints(partitions)
+
+	var errs []error
+	for _, partition := range partitions {
+		if err := c.consumer.Commit(ctx, c.topic, c.pending[partition]); err != nil {
+			errs = append(errs, fmt.Errorf("partition %d offset %d: %w", partition, c.pending[partition].Offset, err))
+			continue
+		}
+
+		delete(c.pending, partition)
+	}
+
+	c.count = 0
+	if len(c.pending) > 0 && c.interval > 0 {
+		c.due = common.TimeNow().Add(c.interval)
+	}
+
+	return errors.Join(errs...)
+}
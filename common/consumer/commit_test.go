@@ -0,0 +1,440 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/retry"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+// fakeConsumer serves the messages of a topic from memory as a consumer group does: from the committed offset
+// of every partition. Once crashed, the commits are lost as they are when the process is killed.
+type fakeConsumer struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	next      int
+	start     map[int]int64
+	committed map[int]int64
+	commits   int
+	attempts  int
+	commitErr error
+	crashed   bool
+	closed    bool
+
+	// drained is closed once every message is fetched
+	drained chan struct{}
+}
+
+func newFakeConsumer(messages []kafka.Message, committed map[int]int64) *fakeConsumer {
+	f := &fakeConsumer{
+		messages:  messages,
+		start:     make(map[int]int64, len(committed)),
+		committed: make(map[int]int64, len(committed)),
+		drained:   make(chan struct{}),
+	}
+
+	for partition, offset := range committed {
+		f.start[partition] = offset
+		f.committed[partition] = offset
+	}
+
+	return f
+}
+
+func (f *fakeConsumer) Fetch(ctx context.Context, _ string) (*kafka.Message, error) {
+	f.mu.Lock()
+	for f.next < len(f.messages) {
+		msg := f.messages[f.next]
+		f.next++
+
+		if msg.Offset >= f.start[msg.Partition] {
+			f.mu.Unlock()
+			return &msg, nil
+		}
+	}
+
+	if f.next == len(f.messages) {
+		close(f.drained)
+		f.next++
+	}
+	f.mu.Unlock()
+
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (*fakeConsumer) Consume(context.Context, string) (*kafka.Message, error) {
+	return nil, errors.New("the runtime must fetch the messages")
+}
+
+func (f *fakeConsumer) Commit(_ context.Context, _ string, msg *kafka.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts++
+
+	switch {
+	case f.closed:
+		return errors.New("consumer closed")
+	case f.commitErr != nil:
+		return f.commitErr
+	case f.crashed:
+		return nil
+	}
+
+	if msg.Offset+1 > f.committed[msg.Partition] {
+		f.committed[msg.Partition] = msg.Offset + 1
+	}
+
+	f.commits++
+
+	return nil
+}
+
+func (f *fakeConsumer) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+
+	return nil
+}
+
+func (f *fakeConsumer) crash() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.crashed = true
+}
+
+// failCommits makes the commits fail with err, or succeed again when err is nil
+func (f *fakeConsumer) failCommits(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.commitErr = err
+}
+
+func (f *fakeConsumer) commitAttempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.attempts
+}
+
+func (f *fakeConsumer) committedOffsets() map[int]int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	committed := make(map[int]int64, len(f.committed))
+	for partition, offset := range f.committed {
+		committed[partition] = offset
+	}
+
+	return committed
+}
+
+// messagesOf returns count messages of each partition, interleaved, with the value {partition}-{offset}
+func messagesOf(partitions, count int) []kafka.Message {
+	var messages []kafka.Message
+	for offset := 0; offset < count; offset++ {
+		for partition := 0; partition < partitions; partition++ {
+			messages = append(messages, kafka.Message{
+				Partition: partition,
+				Offset:    int64(offset),
+				Value:     []byte(fmt.Sprintf("%d-%d", partition, offset)),
+			})
+		}
+	}
+
+	return messages
+}
+
+// handledValues records the values of the messages handled
+type handledValues struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (h *handledValues) add(msg kafka.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.values = append(h.values, string(msg.Value.([]byte)))
+}
+
+func (h *handledValues) get() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]string(nil), h.values...)
+}
+
+func newFakeRuntime(t *testing.T, fake *fakeConsumer) (*Runtime, *loggerMock.MockILogger) {
+	mockLogger := loggerMock.NewMockILogger(gomock.NewController(t))
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return &Runtime{
+		Consumer: fake,
+		Logger:   mockLogger,
+		Backoff:  retry.Backoff{Initial: time.Millisecond, Max: time.Millisecond},
+		Topics:   map[string]string{"placeholder": "placeholder-record"},
+	}, mockLogger
+}
+
+func TestRuntime_commit(t *testing.T) {
+
+	t.Run("positive - no message is skipped on a crash", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			messages    = messagesOf(2, 5)
+			first       = newFakeConsumer(messages, nil)
+			handled     handledValues
+		)
+
+		runtime, _ := newFakeRuntime(t, first)
+		runtime.CommitBatchSize = 3
+
+		// The process is killed while handling the 7th message, the offsets of the 6 before are committed
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			if string(msg.Value.([]byte)) == "0-3" {
+				first.crash()
+				cancel()
+
+				return true, nil
+			}
+
+			handled.add(msg)
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		wg.Wait()
+
+		assert.Equal(t, []string{"0-0", "1-0", "0-1", "1-1", "0-2", "1-2"}, handled.get())
+		assert.Equal(t, map[int]int64{0: 3, 1: 3}, first.committedOffsets())
+
+		var (
+			restartCtx, restartCancel = context.WithCancel(context.Background())
+			restarted                 = newFakeConsumer(messages, first.committedOffsets())
+			handledAgain              handledValues
+		)
+
+		runtime, _ = newFakeRuntime(t, restarted)
+		runtime.CommitBatchSize = 3
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handledAgain.add(msg)
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(restartCtx, &wg))
+		<-restarted.drained
+		restartCancel()
+		wg.Wait()
+
+		assert.Equal(t, []string{"0-3", "1-3", "0-4", "1-4"}, handledAgain.get())
+		assert.Equal(t, map[int]int64{0: 5, 1: 5}, restarted.committedOffsets())
+	})
+
+	t.Run("positive - in-flight messages are committed on shutdown before the consumer is closed", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			fake        = newFakeConsumer(messagesOf(2, 2), nil)
+		)
+
+		runtime, _ := newFakeRuntime(t, fake)
+		runtime.CommitBatchSize = 100
+		runtime.CommitInterval = time.Hour
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			if string(msg.Value.([]byte)) == "1-1" {
+				cancel()
+			}
+
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		wg.Wait()
+
+		assert.True(t, fake.closed)
+		assert.Equal(t, map[int]int64{0: 2, 1: 2}, fake.committedOffsets())
+	})
+
+	t.Run("positive - offsets are committed by batches", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			fake        = newFakeConsumer(messagesOf(2, 3), nil)
+		)
+
+		runtime, _ := newFakeRuntime(t, fake)
+		runtime.CommitBatchSize = 2
+		runtime.Register("placeholder", func(kafka.Message) (bool, error) { return true, nil })
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		<-fake.drained
+
+		assert.Equal(t, map[int]int64{0: 3, 1: 3}, fake.committedOffsets())
+		assert.Equal(t, 6, fake.commits)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("positive - offsets are committed every interval", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			fake        = newFakeConsumer(messagesOf(2, 3), nil)
+		)
+
+		runtime, _ := newFakeRuntime(t, fake)
+		runtime.CommitBatchSize = 100
+		runtime.CommitInterval = 10 * time.Millisecond
+		runtime.Register("placeholder", func(kafka.Message) (bool, error) { return true, nil })
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		<-fake.drained
+
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(map[int]int64{0: 3, 1: 3}, fake.committedOffsets())
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("negative - failed commit is tried again after the interval rather than in a loop", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			fake        = newFakeConsumer(messagesOf(2, 3), nil)
+		)
+
+		runtime, mockLogger := newFakeRuntime(t, fake)
+		runtime.CommitBatchSize = 100
+		runtime.CommitInterval = 20 * time.Millisecond
+		runtime.Register("placeholder", func(kafka.Message) (bool, error) { return true, nil })
+
+		mockLogger.EXPECT().Error("failed to commit the offsets", gomock.Any()).MinTimes(1)
+		fake.failCommits(errors.New("coordinator unavailable"))
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		<-fake.drained
+		time.Sleep(200 * time.Millisecond)
+
+		// About one attempt of each of the 2 partitions every interval, not one every fetch
+		assert.LessOrEqual(t, fake.commitAttempts(), 2*(200/20+5))
+		assert.Empty(t, fake.committedOffsets())
+
+		fake.failCommits(nil)
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(map[int]int64{0: 3, 1: 3}, fake.committedOffsets())
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("negative - failed commit does not fill the next batch", func(t *testing.T) {
+		fake := newFakeConsumer(nil, nil)
+		c := newCommitter(fake, "placeholder-record", 2, 0)
+
+		fake.failCommits(errors.New("coordinator unavailable"))
+		assert.False(t, c.done(&kafka.Message{Partition: 0, Offset: 0}))
+		assert.True(t, c.done(&kafka.Message{Partition: 1, Offset: 0}))
+		assert.EqualError(t, c.commit(), "partition 0 offset 0: coordinator unavailable\npartition 1 offset 0: coordinator unavailable")
+
+		fake.failCommits(nil)
+		assert.False(t, c.done(&kafka.Message{Partition: 0, Offset: 1}))
+		assert.True(t, c.done(&kafka.Message{Partition: 0, Offset: 2}))
+		assert.Nil(t, c.commit())
+		assert.Equal(t, map[int]int64{0: 3, 1: 1}, fake.committedOffsets())
+	})
+
+	t.Run("positive - message which can't be handed on is not committed until it is", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			messages    = messagesOf(1, 3)
+			fake        = newFakeConsumer(messages, nil)
+			handled     handledValues
+			produceErr  = errors.New("broker unavailable")
+			attempts    = make(chan struct{}, 10)
+		)
+
+		runtime, mockLogger := newFakeRuntime(t, fake)
+		mockDeadLetters := repositoryMock.NewMockIDeadLetterProducer(gomock.NewController(t))
+		runtime.DeadLetters = mockDeadLetters
+
+		mockDeadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).
+			DoAndReturn(func(context.Context, string, model.DeadLetter) error {
+				attempts <- struct{}{}
+				return produceErr
+			}).MinTimes(2)
+		mockLogger.EXPECT().Error("failed to hand the message on, handling it again", gomock.Any()).MinTimes(2)
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handled.add(msg)
+			if string(msg.Value.([]byte)) == "0-1" {
+				return true, errors.New("invalid message")
+			}
+
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		<-attempts
+		<-attempts
+		cancel()
+		wg.Wait()
+
+		assert.Equal(t, []string{"0-0", "0-1", "0-1"}, handled.get()[:3])
+		assert.Equal(t, map[int]int64{0: 1}, fake.committedOffsets())
+
+		var (
+			restartCtx, restartCancel = context.WithCancel(context.Background())
+			restarted                 = newFakeConsumer(messages, fake.committedOffsets())
+			handledAgain              handledValues
+		)
+
+		runtime, mockLogger = newFakeRuntime(t, restarted)
+		mockDeadLetters = repositoryMock.NewMockIDeadLetterProducer(gomock.NewController(t))
+		runtime.DeadLetters = mockDeadLetters
+
+		mockDeadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).Return(nil)
+		mockLogger.EXPECT().Warn("message sent to the dead letter topic", gomock.Any())
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handledAgain.add(msg)
+			if string(msg.Value.([]byte)) == "0-1" {
+				return true, errors.New("invalid message")
+			}
+
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(restartCtx, &wg))
+		<-restarted.drained
+		restartCancel()
+		wg.Wait()
+
+		assert.Equal(t, []string{"0-1", "0-2"}, handledAgain.get())
+		assert.Equal(t, map[int]int64{0: 3}, restarted.committedOffsets())
+	})
+}
@@ -0,0 +1,294 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	kafkaConsumer "github.com/dityuiri/go-adapter/kafka/consumer"
+	"github.com/dityuiri/go-adapter/kafka/producer"
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/retry"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+// produceTimeout bounds sending a message to a retry topic or the dead letter topic, which is done even once ctx is done
+const produceTimeout = 10 * time.Second
+
+type (
+	// Handler handles a message. When the handling fails, the bool result tells whether the message is done with:
+	// false means the handling can be retried later, true means it can't, like for a malformed message.
+	// The bool is ignored when the handling succeeds.
+	Handler func(kafka.Message) (bool, error)
+
+	// DeadLetterProducer publishes the messages the handlers failed with to the dead letter topic of their logical topic
+	DeadLetterProducer interface {
+		ProduceDeadLetter(ctx context.Context, name string, letter model.DeadLetter) error
+	}
+
+	// Runtime consumes the topic of every registered handler in its own goroutine. A goroutine which panics,
+	// or fails to read, is restarted after a backoff growing from Backoff.Initial up to Backoff.Max. A message which
+	// can't be handed on, like when the dead letter topic is unavailable, is handled again after the same backoff.
+	//
+	// A message whose handler fails in a way that can be retried is sent by Producer to the retry topics
+	// of RetryTiers one after the other, each consumed in its own goroutine as well. A message failing on every
+	// retry topic, or in a way that can't be retried, is sent to DeadLetters.
+	//
+	// The messages are consumed at least once: the offset of a message is committed once it is handled, or sent
+	// to a retry or the dead letter topic, by batches of CommitBatchSize messages or every CommitInterval.
+	Runtime struct {
+		Consumer        kafkaConsumer.IConsumer
+		Producer        producer.IProducer
+		DeadLetters     DeadLetterProducer
+		Logger          logger.ILogger
+		Metrics         *metrics.Metrics
+		Backoff         retry.Backoff
+		CommitBatchSize int
+		CommitInterval  time.Duration
+
+		// Topics maps the logical topic names the handlers are registered with to the kafka topics
+		Topics map[string]string
+
+		// RetryTiers maps the logical topic names to the delays of their retry topics, see retryTopic
+		RetryTiers map[string][]time.Duration
+
+		handlers map[string]Handler
+	}
+
+	// worker consumes the kafka topic of the logical topic name with the handler. The topic is the retry topic
+	// of the tier when tier is above 0.
+	worker struct {
+		name    string
+		topic   string
+		tier    int
+		handler Handler
+	}
+)
+
+// Register consumes the topic of the logical name with the handler, replacing the handler registered before
+func (r *Runtime) Register(name string, handler Handler) {
+	if r.handlers == nil {
+		r.handlers = make(map[string]Handler)
+	}
+
+	r.handlers[name] = handler
+}
+
+// Start consumes the topic of every registered handler until ctx is done. wg is done once every goroutine
+// has stopped, after committing the messages it was handling, and the consumer is closed.
+// Start fails, consuming nothing, when a handler has no topic.
+func (r *Runtime) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	workers, err := r.workers(ctx)
+	if err != nil {
+		return err
+	}
+
+	var running sync.WaitGroup
+	for _, w := range workers {
+		running.Add(1)
+
+		go func(w worker) {
+			defer running.Done()
+
+			w.handler = r.Metrics.InstrumentHandler(w.topic, w.handler)
+			r.supervise(ctx, w)
+		}(w)
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		running.Wait()
+		_ = r.Consumer.Close()
+		r.Logger.Info("kafka consumers stopped")
+	}()
+
+	return nil
+}
+
+// workers returns the worker of every handler. Topics without a handler are only logged.
+func (r *Runtime) workers(ctx context.Context) ([]worker, error) {
+	var (
+		workers = make([]worker, 0, len(r.handlers))
+		errs    []error
+	)
+
+	for _, name := range sortedKeys(r.handlers) {
+		topic := r.Topics[name]
+		if topic == "" {
+			errs = append(errs, fmt.Errorf("%s: %w", name, common.ErrConsumerTopicNotConfigured))
+			continue
+		}
+
+		workers = append(workers, worker{name: name, topic: topic, handler: r.handlers[name]})
+		for i, delay := range r.RetryTiers[name] {
+			workers = append(workers, worker{name: name, topic: retryTopic(topic, delay), tier: i + 1, handler: r.handlers[name]})
+		}
+	}
+
+	for _, name := range sortedKeys(r.Topics) {
+		if _, found := r.handlers[name]; !found {
+			logging.WithContext(ctx, r.Logger, "topic", r.Topics[name]).Warn(fmt.Sprintf("no handler is registered for %s, it is not consumed", name))
+		}
+	}
+
+	return workers, errors.Join(errs...)
+}
+
+// supervise consumes the topic until ctx is done, restarting the consumption after a backoff when it stops
+// on an error. The backoff starts over once a message is handled.
+func (r *Runtime) supervise(ctx context.Context, w worker) {
+	logger := logging.WithContext(ctx, r.Logger, "topic", w.topic)
+	logger.Info("kafka consumer started")
+
+	for restart := 1; ; restart++ {
+		handled, err := r.consume(ctx, w)
+		if err == nil || ctx.Err() != nil {
+			logger.Info("kafka consumer stopped")
+			return
+		}
+
+		if handled > 0 {
+			restart = 1
+		}
+
+		wait := r.Backoff.Delay(restart)
+		logging.WithContext(ctx, r.Logger, "topic", w.topic, "restart", restart, "wait", wait.Round(time.Millisecond)).
+			Error("kafka consumer stopped on an error, restarting it", log.WithError(err))
+
+		if !sleep(ctx, wait) {
+			logger.Info("kafka consumer stopped")
+			return
+		}
+	}
+}
+
+// consume handles the messages of the topic until ctx is done, returning nil, or until reading a message fails.
+// It returns the number of messages handled. The offset of a message is committed once the message is done with,
+// so a message is handled again after a crash rather than skipped. The messages done with are committed on return.
+func (r *Runtime) consume(ctx context.Context, w worker) (handled int, err error) {
+	c := newCommitter(r.Consumer, w.topic, r.CommitBatchSize, r.CommitInterval)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v\n%s", common.ErrHandlerPanicked, recovered, debug.Stack())
+		}
+
+		r.commit(ctx, w, c)
+	}()
+
+	for {
+		msg, err := r.fetch(ctx, w, c)
+		switch {
+		case ctx.Err() != nil || errors.Is(err, io.EOF):
+			return handled, nil
+		case errors.Is(err, context.DeadlineExceeded):
+			// the pending offsets are due
+			r.commit(ctx, w, c)
+			continue
+		case err != nil:
+			return handled, err
+		case msg == nil:
+			// no message, no error. skip
+			continue
+		}
+
+		// A message of a retry topic may wait for its time, the messages done with are committed first
+		if w.tier > 0 {
+			r.commit(ctx, w, c)
+		}
+
+		// A message without a value is skipped, but committed
+		if msg.Value != nil {
+			if !r.deliver(ctx, w, *msg) {
+				return handled, nil
+			}
+
+			handled++
+		}
+
+		if c.done(msg) {
+			r.commit(ctx, w, c)
+		}
+	}
+}
+
+// fetch returns the next message of the topic, without committing it. It gives up once the pending offsets
+// are due, so they are committed while no message comes.
+func (r *Runtime) fetch(ctx context.Context, w worker, c *committer) (*kafka.Message, error) {
+	due, pending := c.dueAt()
+	if !pending {
+		return r.Consumer.Fetch(ctx, w.topic)
+	}
+
+	fetchCtx, cancel := context.WithDeadline(ctx, due)
+	defer cancel()
+
+	return r.Consumer.Fetch(fetchCtx, w.topic)
+}
+
+// deliver handles the message until it is done with, handling it again after a backoff while it can neither be
+// handled nor sent to a retry or the dead letter topic. It returns false when ctx is done first, the message isn't
+// committed then and it is handled again once the app restarts.
+func (r *Runtime) deliver(ctx context.Context, w worker, msg kafka.Message) bool {
+	for attempt := 1; ; attempt++ {
+		err := r.handle(ctx, w, msg)
+		if err == nil {
+			return true
+		}
+
+		wait := r.Backoff.Delay(attempt)
+		logging.WithContext(ctx, r.Logger, "topic", w.topic, "partition", msg.Partition, "offset", msg.Offset,
+			"attempt", attempt, "wait", wait.Round(time.Millisecond)).
+			Error("failed to hand the message on, handling it again", log.WithError(err))
+
+		if !sleep(ctx, wait) {
+			return false
+		}
+	}
+}
+
+// commit commits the offsets of the messages done with, logging the failure. The offsets failing to commit
+// are committed with the next batch, or once the interval elapses again.
+func (r *Runtime) commit(ctx context.Context, w worker, c *committer) {
+	if err := c.commit(); err != nil {
+		logging.WithContext(ctx, r.Logger, "topic", w.topic).Error("failed to commit the offsets", log.WithError(err))
+	}
+}
+
+// sleep waits for the duration, returning false when ctx is done first
+func sleep(ctx context.Context, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,213 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	consumerMock "github.com/dityuiri/go-adapter/kafka/consumer/mock"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/retry"
+	"github.com/dityuiri/go-baseline/mock"
+)
+
+// fetchResult is what a Fetch call of the mock consumer returns
+type fetchResult struct {
+	msg *kafka.Message
+	err error
+}
+
+// fetchInOrder returns the results one by one, then blocks until ctx is done
+func fetchInOrder(results ...fetchResult) func(ctx context.Context, topic string) (*kafka.Message, error) {
+	var mu sync.Mutex
+
+	return func(ctx context.Context, _ string) (*kafka.Message, error) {
+		mu.Lock()
+		if len(results) > 0 {
+			result := results[0]
+			results = results[1:]
+			mu.Unlock()
+
+			return result.msg, result.err
+		}
+		mu.Unlock()
+
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+}
+
+// newRuntime returns a runtime consuming the placeholder topic, with its own mocks as every test sets up
+// the messages of the consumer
+func newRuntime(t *testing.T) (*Runtime, *consumerMock.MockIConsumer, *loggerMock.MockILogger) {
+	var (
+		mockCtrl     = gomock.NewController(t)
+		mockLogger   = loggerMock.NewMockILogger(mockCtrl)
+		mockConsumer = consumerMock.NewMockIConsumer(mockCtrl)
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockConsumer.EXPECT().Commit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return &Runtime{
+		Consumer: mockConsumer,
+		Logger:   mockLogger,
+		Backoff:  retry.Backoff{Initial: time.Millisecond, Max: time.Millisecond},
+		Topics:   map[string]string{"placeholder": "placeholder-record"},
+	}, mockConsumer, mockLogger
+}
+
+func TestRuntime_Start(t *testing.T) {
+
+	t.Run("positive - messages are handled until the context is done", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			handled     = make(chan kafka.Message, 2)
+		)
+
+		runtime, mockConsumer, _ := newRuntime(t)
+
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record").DoAndReturn(fetchInOrder(
+			fetchResult{msg: &kafka.Message{Offset: 1, Value: []byte("1")}},
+			fetchResult{msg: &kafka.Message{Offset: 2}},
+			fetchResult{msg: &kafka.Message{Offset: 3, Value: []byte("3")}},
+		)).MinTimes(4)
+		mockConsumer.EXPECT().Close().Return(nil).Times(1)
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handled <- msg
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		assert.Equal(t, int64(1), (<-handled).Offset)
+		assert.Equal(t, int64(3), (<-handled).Offset)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("positive - message of a panicking handler is dropped without a dead letter topic", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			handled     = make(chan kafka.Message, 1)
+		)
+
+		runtime, mockConsumer, mockLogger := newRuntime(t)
+
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record").DoAndReturn(fetchInOrder(
+			fetchResult{msg: &kafka.Message{Offset: 1, Value: []byte("panic")}},
+			fetchResult{msg: &kafka.Message{Offset: 2, Value: []byte("ok")}},
+		)).MinTimes(3)
+		mockConsumer.EXPECT().Close().Return(nil).Times(1)
+		mockLogger.EXPECT().Error("message handling failed, the message is dropped",
+			mock.LogWith(common.ErrHandlerPanicked, "topic=placeholder-record", "offset=1")).Times(1)
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			if string(msg.Value.([]byte)) == "panic" {
+				var m map[string]int
+				m["boom"]++
+			}
+
+			handled <- msg
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		assert.Equal(t, int64(2), (<-handled).Offset)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("positive - failing read is retried after a growing backoff", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			handled     = make(chan kafka.Message, 1)
+			readErr     = errors.New("broker unavailable")
+		)
+
+		runtime, mockConsumer, mockLogger := newRuntime(t)
+
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record").DoAndReturn(fetchInOrder(
+			fetchResult{err: readErr},
+			fetchResult{err: readErr},
+			fetchResult{msg: &kafka.Message{Offset: 1, Value: []byte("ok")}},
+		)).MinTimes(4)
+		mockConsumer.EXPECT().Close().Return(nil).Times(1)
+		gomock.InOrder(
+			mockLogger.EXPECT().Error(gomock.Any(), mock.LogWith(readErr, "restart=1")),
+			mockLogger.EXPECT().Error(gomock.Any(), mock.LogWith(readErr, "restart=2")),
+		)
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handled <- msg
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		assert.Equal(t, int64(1), (<-handled).Offset)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("positive - retry topics are consumed with the handler of their topic", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			handled     = make(chan kafka.Message, 2)
+			retryAt     = []byte(time.Now().UTC().Format(time.RFC3339Nano))
+		)
+
+		runtime, mockConsumer, _ := newRuntime(t)
+		runtime.RetryTiers = map[string][]time.Duration{"placeholder": {5 * time.Second, time.Minute}}
+
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record").DoAndReturn(fetchInOrder()).MinTimes(1)
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record.retry.5s").DoAndReturn(fetchInOrder(
+			fetchResult{msg: &kafka.Message{Offset: 1, Value: []byte("1"),
+				Headers: kafka.Header{HeaderRetrySourceTopic: []byte("placeholder-record"), HeaderRetryAt: retryAt}}},
+		)).MinTimes(2)
+		mockConsumer.EXPECT().Fetch(gomock.Any(), "placeholder-record.retry.1m").DoAndReturn(fetchInOrder(
+			fetchResult{msg: &kafka.Message{Offset: 2, Value: []byte("2"),
+				Headers: kafka.Header{HeaderRetrySourceTopic: []byte("placeholder-record"), HeaderRetryAt: retryAt}}},
+		)).MinTimes(2)
+		mockConsumer.EXPECT().Close().Return(nil).Times(1)
+
+		runtime.Register("placeholder", func(msg kafka.Message) (bool, error) {
+			handled <- msg
+			return true, nil
+		})
+
+		assert.Nil(t, runtime.Start(ctx, &wg))
+		assert.ElementsMatch(t, []int64{1, 2}, []int64{(<-handled).Offset, (<-handled).Offset})
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("negative - handler without a topic", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		runtime, _, mockLogger := newRuntime(t)
+
+		mockLogger.EXPECT().Warn("no handler is registered for placeholder, it is not consumed", mock.LogContaining("topic=placeholder-record"))
+
+		runtime.Register("audit", func(kafka.Message) (bool, error) { return true, nil })
+
+		err := runtime.Start(context.Background(), &wg)
+		assert.ErrorIs(t, err, common.ErrConsumerTopicNotConfigured)
+		assert.Contains(t, err.Error(), "audit")
+	})
+}
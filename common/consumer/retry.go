@@ -0,0 +1,211 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+// Headers of a message of a retry topic, telling where it was consumed first and when it is handled again.
+// The headers of the original message are kept along.
+const (
+	HeaderRetrySourceTopic     = "retry_source_topic"
+	HeaderRetrySourcePartition = "retry_source_partition"
+	HeaderRetrySourceOffset    = "retry_source_offset"
+	HeaderRetryError           = "retry_error"
+	HeaderRetryFailures        = "retry_failures"
+	HeaderRetryAt              = "retry_at"
+)
+
+// handle hands the message to the handler. A message whose handling fails in a way that can be retried goes to
+// the next retry topic of its logical topic, and to the dead letter topic once it can't be retried or it failed
+// on the last retry topic. A message of a retry topic is handed once its scheduled time has passed.
+// handle returns an error when the message is not done with, it failed and couldn't be sent to any topic.
+func (r *Runtime) handle(ctx context.Context, w worker, msg kafka.Message) error {
+	letter, retryAt, err := failedMessage(w, msg)
+	if err != nil {
+		letter.Error, letter.FailedAt = err.Error(), common.TimeNow()
+		return r.deadLetter(ctx, w, letter, err)
+	}
+
+	// A message still waiting on shutdown is scheduled again, as it is, so its offset can be committed
+	if wait := retryAt.Sub(common.TimeNow()); wait > 0 && !sleep(ctx, wait) {
+		return r.retry(w.topic, letter, retryAt)
+	}
+
+	done, err := call(w.handler, msg)
+	if err == nil {
+		return nil
+	}
+
+	letter.Error = err.Error()
+	letter.Failures++
+	letter.FailedAt = common.TimeNow()
+
+	tiers := r.RetryTiers[w.name]
+	if done || w.tier >= len(tiers) {
+		return r.deadLetter(ctx, w, letter, err)
+	}
+
+	delay := tiers[w.tier]
+	topic := retryTopic(r.Topics[w.name], delay)
+	logger := logging.WithContext(ctx, r.Logger, retryFields(letter, topic)...)
+
+	if retryErr := r.retry(topic, letter, letter.FailedAt.Add(delay)); retryErr != nil {
+		logger.Error("failed to send the message to the retry topic, sending it to the dead letter topic", log.WithError(retryErr))
+		return r.deadLetter(ctx, w, letter, err)
+	}
+
+	logger.Warn("message handling failed, it is retried on the retry topic", log.WithError(err))
+
+	return nil
+}
+
+// call hands the message to the handler. A panic of the handler is a failure which can't be retried,
+// the message would be handled again and again otherwise.
+func call(handler Handler, msg kafka.Message) (done bool, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			done, err = true, fmt.Errorf("%w: %v\n%s", common.ErrHandlerPanicked, recovered, debug.Stack())
+		}
+	}()
+
+	return handler(msg)
+}
+
+// retry sends the message to the retry topic, to be handled at retryAt. It is sent even when ctx is done,
+// the message would be lost otherwise.
+func (r *Runtime) retry(topic string, letter model.DeadLetter, retryAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), produceTimeout)
+	defer cancel()
+
+	err := r.Producer.Produce(ctx, topic, retryMessage(letter, retryAt))
+	r.Metrics.ObserveProduce(topic, 1, err)
+
+	return err
+}
+
+// deadLetter sends the original message to the dead letter topic of the worker. It is sent even when ctx is done,
+// the message would not be done with otherwise. Without a dead letter topic, the message is dropped.
+// cause is the error the message failed with, as written in letter.Error.
+func (r *Runtime) deadLetter(ctx context.Context, w worker, letter model.DeadLetter, cause error) error {
+	logger := logging.WithContext(ctx, r.Logger, "topic", letter.Topic, "partition", letter.Partition, "offset", letter.Offset,
+		"failures", letter.Failures)
+	if r.DeadLetters == nil {
+		logger.Error("message handling failed, the message is dropped", log.WithError(cause))
+		return nil
+	}
+
+	dlqCtx, cancel := context.WithTimeout(context.Background(), produceTimeout)
+	defer cancel()
+
+	if err := r.DeadLetters.ProduceDeadLetter(dlqCtx, w.name, letter); err != nil {
+		return fmt.Errorf("failed to send the message to the dead letter topic: %w", errors.Join(err, cause))
+	}
+
+	logger.Warn("message sent to the dead letter topic", log.WithError(cause))
+
+	return nil
+}
+
+// retryTopic names the retry topic of the kafka topic with the delay, like placeholder-record.retry.5s
+func retryTopic(topic string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", topic, formatDelay(delay))
+}
+
+// formatDelay writes the delay in its largest whole unit, 1m rather than 1m0s
+func formatDelay(delay time.Duration) string {
+	switch {
+	case delay%time.Hour == 0:
+		return fmt.Sprintf("%dh", delay/time.Hour)
+	case delay%time.Minute == 0:
+		return fmt.Sprintf("%dm", delay/time.Minute)
+	case delay%time.Second == 0:
+		return fmt.Sprintf("%ds", delay/time.Second)
+	default:
+		return delay.String()
+	}
+}
+
+// failedMessage returns the message as it is sent on when its handling fails. A message of a retry topic carries
+// the topic, partition and offset it was consumed from first, its failures and the time it is handled at.
+func failedMessage(w worker, msg kafka.Message) (letter model.DeadLetter, retryAt time.Time, err error) {
+	letter = model.DeadLetter{
+		Topic:     w.topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Headers:   msg.Headers,
+	}
+	letter.Value, _ = msg.Value.([]byte)
+
+	if w.tier == 0 {
+		return letter, retryAt, nil
+	}
+
+	if _, found := msg.Headers[HeaderRetrySourceTopic]; !found {
+		return letter, retryAt, fmt.Errorf("%w: no %s header", common.ErrMalformedRetryMessage, HeaderRetrySourceTopic)
+	}
+
+	letter.Headers = make(map[string][]byte, len(msg.Headers))
+	for key, value := range msg.Headers {
+		switch key {
+		case HeaderRetrySourceTopic:
+			letter.Topic = string(value)
+		case HeaderRetrySourcePartition:
+			letter.Partition, err = strconv.Atoi(string(value))
+		case HeaderRetrySourceOffset:
+			letter.Offset, err = strconv.ParseInt(string(value), 10, 64)
+		case HeaderRetryFailures:
+			letter.Failures, err = strconv.Atoi(string(value))
+		case HeaderRetryAt:
+			retryAt, err = time.Parse(time.RFC3339Nano, string(value))
+		case HeaderRetryError:
+			letter.Error = string(value)
+		default:
+			letter.Headers[key] = value
+		}
+
+		if err != nil {
+			return letter, retryAt, fmt.Errorf("%w: header %s: %v", common.ErrMalformedRetryMessage, key, err)
+		}
+	}
+
+	return letter, retryAt, nil
+}
+
+// retryMessage returns the original message with the retry headers
+func retryMessage(letter model.DeadLetter, retryAt time.Time) *kafka.Message {
+	headers := make(kafka.Header, len(letter.Headers)+6)
+	for key, value := range letter.Headers {
+		headers[key] = value
+	}
+
+	headers[HeaderRetrySourceTopic] = []byte(letter.Topic)
+	headers[HeaderRetrySourcePartition] = []byte(strconv.Itoa(letter.Partition))
+	headers[HeaderRetrySourceOffset] = []byte(strconv.FormatInt(letter.Offset, 10))
+	headers[HeaderRetryError] = []byte(letter.Error)
+	headers[HeaderRetryFailures] = []byte(strconv.Itoa(letter.Failures))
+	headers[HeaderRetryAt] = []byte(retryAt.UTC().Format(time.RFC3339Nano))
+
+	return &kafka.Message{
+		Key:     letter.Key,
+		Value:   letter.Value,
+		Headers: headers,
+	}
+}
+
+func retryFields(letter model.DeadLetter, retryTopic string) []interface{} {
+	return []interface{}{"topic", letter.Topic, "partition", letter.Partition, "offset", letter.Offset,
+		"failures", letter.Failures, "retry_topic", retryTopic}
+}
@@ -0,0 +1,326 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	producerMock "github.com/dityuiri/go-adapter/kafka/producer/mock"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/mock"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+// retryMocks are the mocks of a runtime retrying the placeholder topic on two retry topics
+type retryMocks struct {
+	producer    *producerMock.MockIProducer
+	deadLetters *repositoryMock.MockIDeadLetterProducer
+	logger      *loggerMock.MockILogger
+}
+
+func newRetryRuntime(t *testing.T) (*Runtime, retryMocks) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mocks    = retryMocks{
+			producer:    producerMock.NewMockIProducer(mockCtrl),
+			deadLetters: repositoryMock.NewMockIDeadLetterProducer(mockCtrl),
+			logger:      loggerMock.NewMockILogger(mockCtrl),
+		}
+	)
+
+	mocks.logger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	return &Runtime{
+		Producer:    mocks.producer,
+		DeadLetters: mocks.deadLetters,
+		Logger:      mocks.logger,
+		Topics:      map[string]string{"placeholder": "placeholder-record"},
+		RetryTiers:  map[string][]time.Duration{"placeholder": {5 * time.Second, time.Minute}},
+	}, mocks
+}
+
+func TestRuntime_handle(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		now       = time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC)
+		handleErr = errors.New("alpha unavailable")
+
+		main  = worker{name: "placeholder", topic: "placeholder-record"}
+		first = worker{name: "placeholder", topic: "placeholder-record.retry.5s", tier: 1}
+		last  = worker{name: "placeholder", topic: "placeholder-record.retry.1m", tier: 2}
+
+		original = kafka.Message{
+			Partition: 1,
+			Offset:    7,
+			Key:       []byte("key"),
+			Value:     []byte(`{"id":"1"}`),
+			Headers:   kafka.Header{"request_id": []byte("request-1")},
+		}
+
+		// retried is the original message on the retry topic of the tier, scheduled at retryAt
+		retried = func(failures string, retryAt time.Time) kafka.Message {
+			return kafka.Message{
+				Offset: 3,
+				Key:    []byte("key"),
+				Value:  []byte(`{"id":"1"}`),
+				Headers: kafka.Header{
+					"request_id":               []byte("request-1"),
+					HeaderRetrySourceTopic:     []byte("placeholder-record"),
+					HeaderRetrySourcePartition: []byte("1"),
+					HeaderRetrySourceOffset:    []byte("7"),
+					HeaderRetryError:           []byte("alpha unavailable"),
+					HeaderRetryFailures:        []byte(failures),
+					HeaderRetryAt:              []byte(retryAt.Format(time.RFC3339Nano)),
+				},
+			}
+		}
+
+		// failing counts the calls of a handler failing with handleErr
+		failing = func(done bool, calls *int) Handler {
+			return func(kafka.Message) (bool, error) {
+				*calls++
+				return done, handleErr
+			}
+		}
+	)
+
+	defer func(timeNow func() time.Time) { common.TimeNow = timeNow }(common.TimeNow)
+	common.TimeNow = func() time.Time { return now }
+
+	t.Run("positive - handled message", func(t *testing.T) {
+		runtime, _ := newRetryRuntime(t)
+
+		calls := 0
+		main.handler = func(kafka.Message) (bool, error) { calls++; return false, nil }
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("positive - retryable failure goes to the first retry topic", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		mocks.producer.EXPECT().Produce(gomock.Any(), "placeholder-record.retry.5s", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Len(t, messages, 1)
+
+				expected := retried("1", now.Add(5*time.Second))
+				assert.Equal(t, expected.Key, messages[0].Key)
+				assert.Equal(t, expected.Value, messages[0].Value)
+				assert.Equal(t, expected.Headers, messages[0].Headers)
+				return nil
+			})
+		mocks.logger.EXPECT().Warn("message handling failed, it is retried on the retry topic",
+			mock.LogWith(handleErr, "retry_topic=placeholder-record.retry.5s", "failures=1"))
+
+		calls := 0
+		main.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("positive - retryable failure goes to the next retry topic", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		mocks.producer.EXPECT().Produce(gomock.Any(), "placeholder-record.retry.1m", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Equal(t, retried("2", now.Add(time.Minute)).Headers, messages[0].Headers)
+				return nil
+			})
+		mocks.logger.EXPECT().Warn(gomock.Any(), mock.LogWith(handleErr, "retry_topic=placeholder-record.retry.1m", "failures=2"))
+
+		calls := 0
+		first.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, first, retried("1", now)))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("positive - failure on the last retry topic goes to the dead letter topic", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", model.DeadLetter{
+			Topic:     "placeholder-record",
+			Partition: 1,
+			Offset:    7,
+			Key:       []byte("key"),
+			Value:     []byte(`{"id":"1"}`),
+			Headers:   map[string][]byte{"request_id": []byte("request-1")},
+			Error:     "alpha unavailable",
+			Failures:  3,
+			FailedAt:  now,
+		}).Return(nil)
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", mock.LogContaining("failures=3"))
+
+		calls := 0
+		last.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, last, retried("2", now)))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("positive - failure which can't be retried goes to the dead letter topic right away", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, letter model.DeadLetter) error {
+				assert.Equal(t, "placeholder-record", letter.Topic)
+				assert.Equal(t, int64(7), letter.Offset)
+				assert.Equal(t, 1, letter.Failures)
+				return nil
+			})
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", gomock.Any())
+
+		calls := 0
+		main.handler = failing(true, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("positive - topic without retry topics goes to the dead letter topic", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+		runtime.RetryTiers = nil
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).Return(nil)
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", mock.LogContaining("failures=1"))
+
+		calls := 0
+		main.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+	})
+
+	t.Run("positive - retried message waits for its scheduled time", func(t *testing.T) {
+		runtime, _ := newRetryRuntime(t)
+		common.TimeNow = time.Now
+		defer func() { common.TimeNow = func() time.Time { return now } }()
+
+		var handledAt time.Time
+		first.handler = func(kafka.Message) (bool, error) { handledAt = time.Now(); return true, nil }
+
+		retryAt := time.Now().Add(50 * time.Millisecond)
+		assert.Nil(t, runtime.handle(ctx, first, retried("1", retryAt)))
+		assert.False(t, handledAt.Before(retryAt))
+	})
+
+	t.Run("positive - waiting message is scheduled again on shutdown", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		retryAt := now.Add(time.Minute)
+		mocks.producer.EXPECT().Produce(gomock.Any(), "placeholder-record.retry.5s", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Equal(t, retried("1", retryAt).Headers, messages[0].Headers)
+				return nil
+			})
+
+		calls := 0
+		first.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(cancelled, first, retried("1", retryAt)))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("negative - retry topic unavailable goes to the dead letter topic", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+		produceErr := errors.New("broker unavailable")
+
+		mocks.producer.EXPECT().Produce(gomock.Any(), "placeholder-record.retry.5s", gomock.Any()).Return(produceErr)
+		mocks.logger.EXPECT().Error("failed to send the message to the retry topic, sending it to the dead letter topic",
+			mock.LogWith(produceErr, "retry_topic=placeholder-record.retry.5s"))
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).Return(nil)
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", gomock.Any())
+
+		calls := 0
+		main.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+	})
+
+	t.Run("negative - dead letter topic unavailable", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+		produceErr := errors.New("broker unavailable")
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).Return(produceErr)
+
+		calls := 0
+		main.handler = failing(true, &calls)
+
+		err := runtime.handle(ctx, main, original)
+		assert.ErrorIs(t, err, produceErr)
+		assert.ErrorContains(t, err, "alpha unavailable")
+	})
+
+	t.Run("negative - waiting message can't be scheduled again on shutdown", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		produceErr := errors.New("broker unavailable")
+		mocks.producer.EXPECT().Produce(gomock.Any(), "placeholder-record.retry.5s", gomock.Any()).Return(produceErr)
+
+		calls := 0
+		first.handler = failing(false, &calls)
+
+		assert.ErrorIs(t, runtime.handle(cancelled, first, retried("1", now.Add(time.Minute))), produceErr)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("positive - panicking handler goes to the dead letter topic right away", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, letter model.DeadLetter) error {
+				assert.Contains(t, letter.Error, common.ErrHandlerPanicked.Error())
+				assert.Equal(t, 1, letter.Failures)
+				return nil
+			})
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", mock.LogWith(common.ErrHandlerPanicked))
+
+		main.handler = func(kafka.Message) (bool, error) {
+			var m map[string]int
+			m["boom"]++
+			return false, nil
+		}
+
+		assert.Nil(t, runtime.handle(ctx, main, original))
+	})
+
+	t.Run("negative - malformed retry message goes to the dead letter topic unhandled", func(t *testing.T) {
+		runtime, mocks := newRetryRuntime(t)
+
+		malformed := retried("1", now)
+		malformed.Headers[HeaderRetryFailures] = []byte("one")
+
+		mocks.deadLetters.EXPECT().ProduceDeadLetter(gomock.Any(), "placeholder", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, letter model.DeadLetter) error {
+				assert.Contains(t, letter.Error, common.ErrMalformedRetryMessage.Error())
+				return nil
+			})
+		mocks.logger.EXPECT().Warn("message sent to the dead letter topic", gomock.Any())
+
+		calls := 0
+		first.handler = failing(false, &calls)
+
+		assert.Nil(t, runtime.handle(ctx, first, malformed))
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestRetryTopic(t *testing.T) {
+	assert.Equal(t, "placeholder-record.retry.5s", retryTopic("placeholder-record", 5*time.Second))
+	assert.Equal(t, "placeholder-record.retry.1m", retryTopic("placeholder-record", time.Minute))
+	assert.Equal(t, "placeholder-record.retry.90s", retryTopic("placeholder-record", 90*time.Second))
+	assert.Equal(t, "placeholder-record.retry.2h", retryTopic("placeholder-record", 2*time.Hour))
+	assert.Equal(t, "placeholder-record.retry.500ms", retryTopic("placeholder-record", 500*time.Millisecond))
+}
@@ -6,7 +6,13 @@ var (
 	// Parameter Validation Errors
 	ErrInvalidUUIDPlaceholderID = errors.New("invalid uuid #{placeholderID}")
 	ErrInvalidRequestBody       = errors.New("invalid request body")
+	ErrUnsupportedMediaType     = errors.New("unsupported media type")
+	ErrValidationFailed         = errors.New("request validation failed")
 	ErrMissingPlaceholderID     = errors.New("missing #{placeholderID}")
+	ErrInvalidPagination        = errors.New("invalid #{page} or #{page_size}")
+	ErrInvalidAmountRange       = errors.New("invalid #{min_amount} or #{max_amount}")
+	ErrInvalidCreatedAtRange    = errors.New("invalid #{created_from} or #{created_to}")
+	ErrInvalidSortParameter     = errors.New("invalid #{sort_by} or #{sort_order}")
 
 	// Proxy Errors
 	ErrAlphaProxyBadRequest     = errors.New("bad request from alpha")
@@ -15,4 +21,42 @@ var (
 
 	// Repository Errors
 	ErrPlaceholderNotFound = errors.New("placeholder not found")
+	ErrVersionMismatch     = errors.New("placeholder has been modified since the #{If-Match} version")
+
+	// Health Check Errors
+	ErrRedisNotConnected = errors.New("redis is not connected")
+	ErrKafkaUnreachable  = errors.New("no kafka broker is reachable")
+	ErrAlphaUnhealthy    = errors.New("alpha is unhealthy")
+
+	// Auth Errors
+	ErrUnauthorized = errors.New("missing or invalid bearer token")
+	ErrForbidden    = errors.New("insufficient role or scope")
+	ErrNotOwner     = errors.New("only the creator of the resource may modify it")
+
+	// Request Errors
+	ErrRequestTimeout = errors.New("request timeout")
+	ErrRateLimited    = errors.New("too many requests")
+
+	// Conditional Request Errors
+	ErrMissingIfMatch = errors.New("missing #{If-Match}, get the placeholder first to learn its ETag")
+
+	// Idempotency Errors
+	ErrInvalidIdempotencyKey = errors.New("invalid #{Idempotency-Key}")
+	ErrIdempotencyKeyReused  = errors.New("#{Idempotency-Key} was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("a request with the same #{Idempotency-Key} is in progress")
+
+	// Admin Errors
+	ErrInvalidLogLevel = errors.New("invalid #{level}, use one of DEBUG, INFO, WARN, ERROR or PANIC")
+
+	// Consumer Errors
+	ErrConsumerTopicNotConfigured = errors.New("the handler has no topic in CONSUMER_TOPICS")
+	ErrHandlerPanicked            = errors.New("message handler panicked")
+	ErrDeadLetterTopicNotFound    = errors.New("the topic has no dead letter topic {name}_dlq in PRODUCER_TOPICS")
+	ErrNotDeadLetter              = errors.New("the message has no dead letter headers")
+	ErrMalformedRetryMessage      = errors.New("the message of the retry topic has malformed retry headers")
+
+	// Migration Errors
+	ErrDirtyMigration   = errors.New("the database is dirty, a migration failed half way and must be fixed by hand")
+	ErrUnknownMigration = errors.New("the database version has no migration file")
+	ErrInvalidMigration = errors.New("invalid migration file name, use {version}_{description}.{up|down}.sql")
 )
@@ -0,0 +1,292 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+const (
+	// Status of the app and of its components
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusDegraded     = "degraded"
+	StatusShuttingDown = "shutting_down"
+	StatusStarting     = "starting"
+
+	defaultTimeout = time.Second
+)
+
+type (
+	// CheckFunc returns an error when the component is not able to serve the app
+	CheckFunc func(ctx context.Context) error
+
+	// Check probes one component. The app is not ready while a Critical check fails,
+	// other failing checks only degrade it.
+	Check struct {
+		Name     string
+		Critical bool
+		Timeout  time.Duration
+		Func     CheckFunc
+	}
+
+	// Report is the JSON answer of the health endpoints
+	Report struct {
+		Status     string                     `json:"status"`
+		Components map[string]ComponentStatus `json:"components,omitempty"`
+	}
+
+	ComponentStatus struct {
+		Status      string     `json:"status"`
+		Critical    bool       `json:"critical"`
+		LatencyMs   float64    `json:"latency_ms"`
+		CheckedAt   *time.Time `json:"checked_at,omitempty"`
+		LastError   string     `json:"last_error,omitempty"`
+		LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	}
+
+	// Registry runs the registered checks and remembers their last outcome
+	Registry struct {
+		mu           sync.RWMutex
+		checks       []Check
+		components   map[string]ComponentStatus
+		checkedAt    time.Time
+		starting     int32
+		shuttingDown int32
+
+		// probing lets one caller of Status probe the components at a time
+		probing sync.Mutex
+	}
+)
+
+func NewRegistry() *Registry {
+	return &Registry{
+		components: make(map[string]ComponentStatus),
+	}
+}
+
+// Register adds the check, a check without a timeout times out after a second
+func (r *Registry) Register(check Check) {
+	if check.Timeout <= 0 {
+		check.Timeout = defaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, check)
+	r.components[check.Name] = ComponentStatus{Status: StatusUp, Critical: check.Critical}
+}
+
+// Checks returns the registered checks
+func (r *Registry) Checks() []Check {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Check(nil), r.checks...)
+}
+
+// BeginStartup keeps the app unready until EndStartup, while it waits for its dependencies
+func (r *Registry) BeginStartup() {
+	atomic.StoreInt32(&r.starting, 1)
+}
+
+func (r *Registry) EndStartup() {
+	atomic.StoreInt32(&r.starting, 0)
+}
+
+func (r *Registry) IsStarting() bool {
+	return atomic.LoadInt32(&r.starting) == 1
+}
+
+// Startup reports whether the app is done waiting for its dependencies, nothing is probed
+func (r *Registry) Startup() Report {
+	if r.IsStarting() {
+		return Report{Status: StatusStarting}
+	}
+
+	return Report{Status: StatusUp}
+}
+
+// ShutDown makes the app unready, so it stops receiving traffic before its servers are closed
+func (r *Registry) ShutDown() {
+	atomic.StoreInt32(&r.shuttingDown, 1)
+}
+
+func (r *Registry) IsShuttingDown() bool {
+	return atomic.LoadInt32(&r.shuttingDown) == 1
+}
+
+// Liveness reports the process is alive along with the last known state of the components.
+// Nothing is probed, a failing dependency must not get the process restarted.
+func (r *Registry) Liveness() Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := Report{
+		Status:     StatusUp,
+		Components: make(map[string]ComponentStatus, len(r.components)),
+	}
+
+	for name, component := range r.components {
+		report.Components[name] = component
+	}
+
+	return report
+}
+
+// Readiness runs every check concurrently, each bound by its own timeout
+func (r *Registry) Readiness(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]Check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]ComponentStatus, len(checks))
+	)
+
+	for i, check := range checks {
+		wg.Add(1)
+
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, check)
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	r.mu.Lock()
+	r.checkedAt = common.TimeNow()
+	r.mu.Unlock()
+
+	report := Report{
+		Status:     r.status(checks, results),
+		Components: make(map[string]ComponentStatus, len(checks)),
+	}
+
+	for i, check := range checks {
+		report.Components[check.Name] = results[i]
+	}
+
+	return report
+}
+
+// Status reports the readiness without the components, from their last outcome. The components are probed
+// again once that is older than maxAge, by one caller while the others wait for its outcome, so the status
+// can be served publicly without letting the callers hammer the dependencies.
+func (r *Registry) Status(ctx context.Context, maxAge time.Duration) Report {
+	r.probing.Lock()
+	defer r.probing.Unlock()
+
+	r.mu.RLock()
+	stale := common.TimeNow().Sub(r.checkedAt) >= maxAge
+	r.mu.RUnlock()
+
+	if stale {
+		return Report{Status: r.Readiness(ctx).Status}
+	}
+
+	r.mu.RLock()
+	var (
+		checks  = append([]Check(nil), r.checks...)
+		results = make([]ComponentStatus, len(checks))
+	)
+
+	for i, check := range checks {
+		results[i] = r.components[check.Name]
+	}
+	r.mu.RUnlock()
+
+	return Report{Status: r.status(checks, results)}
+}
+
+// status aggregates the outcome of the checks: down when a critical one failed, degraded when another one did
+func (r *Registry) status(checks []Check, results []ComponentStatus) string {
+	switch {
+	case r.IsShuttingDown():
+		return StatusShuttingDown
+	case r.IsStarting():
+		return StatusStarting
+	}
+
+	status := StatusUp
+	for i, check := range checks {
+		if results[i].Status == StatusUp {
+			continue
+		}
+
+		if check.Critical {
+			return StatusDown
+		}
+
+		status = StatusDegraded
+	}
+
+	return status
+}
+
+// IsReady reports whether the app should receive traffic with the given readiness
+func (report Report) IsReady() bool {
+	return report.Status == StatusUp || report.Status == StatusDegraded
+}
+
+// Run probes the component once, bound by the check timeout. Checks ignoring the context still time out.
+func (check Check) Run(ctx context.Context) error {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- check.Func(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run probes the component and records the outcome
+func (r *Registry) run(ctx context.Context, check Check) ComponentStatus {
+	var (
+		start = common.TimeNow()
+		err   = check.Run(ctx)
+	)
+
+	var (
+		checkedAt = common.TimeNow()
+		latency   = checkedAt.Sub(start)
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	component := r.components[check.Name]
+	component.Status = StatusUp
+	component.Critical = check.Critical
+	component.LatencyMs = float64(latency.Microseconds()) / 1000
+	component.CheckedAt = &checkedAt
+
+	if err != nil {
+		component.Status = StatusDown
+		component.LastError = err.Error()
+		component.LastErrorAt = &checkedAt
+	}
+
+	r.components[check.Name] = component
+
+	return component
+}
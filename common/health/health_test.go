@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+func TestRegistry_Readiness(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		up   = func(context.Context) error { return nil }
+		down = func(context.Context) error { return errors.New("connection refused") }
+	)
+
+	t.Run("positive - every check is up", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(Check{Name: "db", Critical: true, Func: up})
+		registry.Register(Check{Name: "alpha", Func: up})
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusUp, report.Status)
+		assert.True(t, report.IsReady())
+		assert.Equal(t, StatusUp, report.Components["db"].Status)
+		assert.True(t, report.Components["db"].Critical)
+		assert.NotNil(t, report.Components["alpha"].CheckedAt)
+	})
+
+	t.Run("positive - non critical check down degrades the app", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(Check{Name: "db", Critical: true, Func: up})
+		registry.Register(Check{Name: "alpha", Func: down})
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusDegraded, report.Status)
+		assert.True(t, report.IsReady())
+		assert.Equal(t, StatusDown, report.Components["alpha"].Status)
+		assert.Equal(t, "connection refused", report.Components["alpha"].LastError)
+	})
+
+	t.Run("negative - critical check down", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(Check{Name: "db", Critical: true, Func: down})
+		registry.Register(Check{Name: "alpha", Func: down})
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusDown, report.Status)
+		assert.False(t, report.IsReady())
+	})
+
+	t.Run("negative - check times out", func(t *testing.T) {
+		var (
+			registry = NewRegistry()
+			block    = make(chan struct{})
+		)
+
+		defer close(block)
+
+		// The check ignores its context, the registry stops waiting for it anyway
+		registry.Register(Check{Name: "kafka", Critical: true, Timeout: 10 * time.Millisecond, Func: func(context.Context) error {
+			<-block
+			return nil
+		}})
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["kafka"].LastError)
+	})
+
+	t.Run("negative - shutting down", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(Check{Name: "db", Critical: true, Func: up})
+		registry.ShutDown()
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusShuttingDown, report.Status)
+		assert.False(t, report.IsReady())
+	})
+
+	t.Run("negative - starting", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register(Check{Name: "db", Critical: true, Func: up})
+		registry.BeginStartup()
+
+		report := registry.Readiness(ctx)
+		assert.Equal(t, StatusStarting, report.Status)
+		assert.False(t, report.IsReady())
+
+		registry.EndStartup()
+
+		report = registry.Readiness(ctx)
+		assert.Equal(t, StatusUp, report.Status)
+	})
+}
+
+func TestRegistry_Status(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		probes int32
+		err    = errors.New("dial tcp 10.0.0.5:5432: connection refused")
+
+		registry = NewRegistry()
+	)
+
+	common.TimeNow = func() time.Time { return now }
+	defer func() { common.TimeNow = time.Now }()
+
+	registry.Register(Check{Name: "db", Critical: true, Func: func(context.Context) error {
+		atomic.AddInt32(&probes, 1)
+		return err
+	}})
+
+	t.Run("negative - probed once while fresh, without the components", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				report := registry.Status(ctx, time.Second)
+				assert.Equal(t, StatusDown, report.Status)
+				assert.Empty(t, report.Components)
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&probes))
+	})
+
+	t.Run("positive - probed again once stale", func(t *testing.T) {
+		err = nil
+		assert.Equal(t, StatusDown, registry.Status(ctx, time.Second).Status)
+
+		now = now.Add(time.Second)
+		assert.Equal(t, StatusUp, registry.Status(ctx, time.Second).Status)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&probes))
+	})
+
+	t.Run("negative - shutting down without probing", func(t *testing.T) {
+		registry.ShutDown()
+		assert.Equal(t, StatusShuttingDown, registry.Status(ctx, time.Second).Status)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&probes))
+	})
+}
+
+func TestRegistry_Startup(t *testing.T) {
+	registry := NewRegistry()
+	assert.Equal(t, StatusUp, registry.Startup().Status)
+
+	registry.BeginStartup()
+	assert.Equal(t, StatusStarting, registry.Startup().Status)
+	assert.False(t, registry.Startup().IsReady())
+
+	registry.EndStartup()
+	assert.True(t, registry.Startup().IsReady())
+}
+
+func TestRegistry_Liveness(t *testing.T) {
+	var (
+		registry = NewRegistry()
+		failing  = true
+	)
+
+	registry.Register(Check{Name: "redis", Critical: true, Func: func(context.Context) error {
+		if failing {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	}})
+
+	report := registry.Liveness()
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Components["redis"].Status)
+	assert.Nil(t, report.Components["redis"].CheckedAt)
+
+	registry.Readiness(context.Background())
+	failing = false
+	registry.Readiness(context.Background())
+
+	// The component recovered, its last error is still reported
+	report = registry.Liveness()
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Components["redis"].Status)
+	assert.Equal(t, "connection refused", report.Components["redis"].LastError)
+	assert.NotNil(t, report.Components["redis"].LastErrorAt)
+}
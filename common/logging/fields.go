@@ -0,0 +1,152 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/requestid"
+)
+
+// Keys of the fields every log is enriched with
+const (
+	FieldRequestID     = "request_id"
+	FieldTraceID       = "trace_id"
+	FieldSpanID        = "span_id"
+	FieldPrincipal     = "principal"
+	FieldRoute         = "route"
+	FieldPlaceholderID = "placeholder_id"
+	FieldErrorChain    = "error_chain"
+)
+
+type contextKey struct{}
+
+// fields are the key/value pairs of a log, in the order they were added
+type fields []interface{}
+
+// NewContext returns a copy of ctx carrying keyvals, a list of key/value pairs like "placeholder_id", id.
+// Every log written with ctx includes them, after the fields ctx already carries. A key ctx already
+// carries is replaced, so it is logged once.
+func NewContext(ctx context.Context, keyvals ...interface{}) context.Context {
+	if len(keyvals) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, fieldsFromContext(ctx).with(keyvals...))
+}
+
+// fieldsFromContext returns the fields carried by ctx with NewContext
+func fieldsFromContext(ctx context.Context) fields {
+	f, _ := ctx.Value(contextKey{}).(fields)
+	return f
+}
+
+// contextFields collects the request ID, trace, principal and route held by ctx,
+// followed by the fields carried with NewContext
+func contextFields(ctx context.Context) fields {
+	var f fields
+
+	if requestID := requestid.FromContext(ctx); requestID != "" {
+		f = append(f, FieldRequestID, requestID)
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		f = append(f, FieldTraceID, spanContext.TraceID().String(), FieldSpanID, spanContext.SpanID().String())
+	}
+
+	if subject := auth.SubjectFromContext(ctx); subject != "" {
+		f = append(f, FieldPrincipal, subject)
+	}
+
+	if routeCtx := chi.RouteContext(ctx); routeCtx != nil {
+		if route := routeCtx.RoutePattern(); route != "" {
+			f = append(f, FieldRoute, route)
+		}
+	}
+
+	return f.with(fieldsFromContext(ctx)...)
+}
+
+// with returns a copy of f followed by keyvals. A key f already holds takes the value of keyvals in place,
+// and a key without value is kept with an empty value.
+func (f fields) with(keyvals ...interface{}) fields {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "")
+	}
+
+	extended := make(fields, 0, len(f)+len(keyvals))
+	extended = append(extended, f...)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		if j := extended.index(keyvals[i]); j >= 0 {
+			extended[j+1] = keyvals[i+1]
+			continue
+		}
+
+		extended = append(extended, keyvals[i], keyvals[i+1])
+	}
+
+	return extended
+}
+
+// index returns the position of key in f, -1 when f doesn't hold it
+func (f fields) index(key interface{}) int {
+	for i := 0; i+1 < len(f); i += 2 {
+		if f[i] == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// String renders the fields as space separated key=value pairs. Values holding spaces,
+// quotes or equal signs are quoted.
+func (f fields) String() string {
+	pairs := make([]string, 0, len(f)/2)
+	for i := 0; i+1 < len(f); i += 2 {
+		pairs = append(pairs, fmt.Sprint(f[i])+"="+formatValue(f[i+1]))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+func formatValue(value interface{}) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// errorChain lists the types of err and of every error it wraps, outermost first,
+// so the root cause of the error can be told apart from its message.
+func errorChain(err error) string {
+	var types []string
+
+	var walk func(err error)
+	walk = func(err error) {
+		for err != nil {
+			types = append(types, fmt.Sprintf("%T", err))
+
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joined.Unwrap() {
+					walk(e)
+				}
+				return
+			}
+
+			err = errors.Unwrap(err)
+		}
+	}
+	walk(err)
+
+	return strings.Join(types, " > ")
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dityuiri/go-adapter/logger"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+// Levels are the log levels, from the most verbose
+var Levels = []string{"DEBUG", "INFO", "WARN", "ERROR", "PANIC"}
+
+// Level is the level of a logger, which can be changed while the app is running.
+// The logger can't tell its level, so it is kept here.
+type Level struct {
+	mu     sync.RWMutex
+	logger logger.ILogger
+	name   string
+}
+
+// NewLevel sets the level of l to name, or to DEBUG when name is not one of Levels
+func NewLevel(l logger.ILogger, name string) *Level {
+	level := &Level{logger: l}
+	if err := level.Set(name); err != nil {
+		_ = level.Set(Levels[0])
+	}
+
+	return level
+}
+
+// String returns the current level
+func (lv *Level) String() string {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+
+	return lv.name
+}
+
+// Set changes the level of the logger, name is case insensitive.
+// It returns common.ErrInvalidLogLevel when name is not one of Levels.
+func (lv *Level) Set(name string) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !isLevel(name) {
+		return common.ErrInvalidLogLevel
+	}
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	lv.logger.SetLevel(logger.StringToLevel(name))
+	lv.name = name
+
+	return nil
+}
+
+func isLevel(name string) bool {
+	for _, level := range Levels {
+		if level == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/logger"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+func TestLevel(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - initial level", func(t *testing.T) {
+		mockLogger.EXPECT().SetLevel(logger.Level.WARN)
+		assert.Equal(t, "WARN", NewLevel(mockLogger, " warn ").String())
+	})
+
+	t.Run("positive - unknown initial level falls back to debug", func(t *testing.T) {
+		mockLogger.EXPECT().SetLevel(logger.Level.DEBUG)
+		assert.Equal(t, "DEBUG", NewLevel(mockLogger, "").String())
+	})
+
+	t.Run("positive - set", func(t *testing.T) {
+		mockLogger.EXPECT().SetLevel(logger.Level.DEBUG)
+		level := NewLevel(mockLogger, "DEBUG")
+
+		mockLogger.EXPECT().SetLevel(logger.Level.ERROR)
+		assert.Nil(t, level.Set("error"))
+		assert.Equal(t, "ERROR", level.String())
+	})
+
+	t.Run("negative - unknown level is rejected", func(t *testing.T) {
+		mockLogger.EXPECT().SetLevel(logger.Level.INFO)
+		level := NewLevel(mockLogger, "INFO")
+
+		assert.ErrorIs(t, level.Set("verbose"), common.ErrInvalidLogLevel)
+		assert.Equal(t, "INFO", level.String())
+	})
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+)
+
+// contextLogger adds the fields of a context to the data of every log, and the chain of its error
+type contextLogger struct {
+	logger.ILogger
+	fields fields
+}
+
+// WithContext returns a logger that includes the request ID, trace, principal and route held by ctx,
+// the fields added to ctx with NewContext and keyvals, a list of key/value pairs, in every log.
+func WithContext(ctx context.Context, l logger.ILogger, keyvals ...interface{}) logger.ILogger {
+	if l == nil {
+		return l
+	}
+
+	return &contextLogger{ILogger: l, fields: contextFields(ctx).with(keyvals...)}
+}
+
+func (cl *contextLogger) Debug(message string, options ...log.Option) {
+	cl.ILogger.Debug(message, cl.compose(options)...)
+}
+
+func (cl *contextLogger) Info(message string, options ...log.Option) {
+	cl.ILogger.Info(message, cl.compose(options)...)
+}
+
+func (cl *contextLogger) Warn(message string, options ...log.Option) {
+	cl.ILogger.Warn(message, cl.compose(options)...)
+}
+
+func (cl *contextLogger) Error(message string, options ...log.Option) {
+	cl.ILogger.Error(message, cl.compose(options)...)
+}
+
+func (cl *contextLogger) Panic(message string, options ...log.Option) {
+	cl.ILogger.Panic(message, cl.compose(options)...)
+}
+
+// compose prepends the fields to the log data and appends the error chain. The logger only keeps
+// a single data, so the options are resolved here. One more stack frame is skipped so the caller info
+// points to the caller of contextLogger instead of contextLogger itself.
+func (cl *contextLogger) compose(options []log.Option) []log.Option {
+	resolved := &log.Options{}
+	for _, option := range options {
+		option(resolved)
+	}
+
+	data := cl.fields.String()
+	if d := resolved.GetData(); d != nil && *d != "" {
+		data = strings.TrimSpace(data + " " + *d)
+	}
+
+	if err := resolved.GetError(); err != nil && *err != nil {
+		data = strings.TrimSpace(data + " " + fields{FieldErrorChain, errorChain(*err)}.String())
+	}
+
+	skip := 1
+	if s := resolved.GetSkip(); s != nil {
+		skip += *s
+	} else if s := cl.ILogger.GetSkip(); s != nil {
+		skip += *s
+	}
+
+	composed := []log.Option{log.WithSkip(skip)}
+	if data != "" {
+		composed = append(composed, log.WithData("%s", data))
+	}
+
+	if err := resolved.GetError(); err != nil {
+		composed = append(composed, log.WithError(*err))
+	}
+
+	return composed
+}
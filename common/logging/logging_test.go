@@ -0,0 +1,193 @@
+package logging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-adapter/logger/log"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/requestid"
+)
+
+func resolve(options []log.Option) *log.Options {
+	resolved := &log.Options{}
+	for _, option := range options {
+		option(resolved)
+	}
+
+	return resolved
+}
+
+func TestWithContext(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+
+		ctx = requestid.NewContext(context.Background(), "request-1")
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - request id is added to the data", func(t *testing.T) {
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Error("error inserting placeholder", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "request_id=request-1 id=1 error_chain=*errors.errorString", *resolved.GetData())
+			assert.Equal(t, 1, *resolved.GetSkip())
+			assert.EqualError(t, *resolved.GetError(), "error")
+		})
+
+		WithContext(ctx, mockLogger).Error("error inserting placeholder", log.WithData("id=%d", 1), log.WithError(errors.New("error")))
+	})
+
+	t.Run("positive - skip of the logger is kept", func(t *testing.T) {
+		skip := 2
+
+		mockLogger.EXPECT().GetSkip().Return(&skip)
+		mockLogger.EXPECT().Info("placeholder not found", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "request_id=request-1", *resolved.GetData())
+			assert.Equal(t, 3, *resolved.GetSkip())
+			assert.Nil(t, resolved.GetError())
+		})
+
+		WithContext(ctx, mockLogger).Info("placeholder not found")
+	})
+
+	t.Run("positive - every level", func(t *testing.T) {
+		mockLogger.EXPECT().GetSkip().Return(nil).Times(3)
+		mockLogger.EXPECT().Debug("debug", gomock.Any())
+		mockLogger.EXPECT().Warn("warn", gomock.Any())
+		mockLogger.EXPECT().Panic("panic", gomock.Any())
+
+		l := WithContext(ctx, mockLogger)
+		l.Debug("debug")
+		l.Warn("warn")
+		l.Panic("panic")
+	})
+
+	t.Run("positive - fields of the context and the call", func(t *testing.T) {
+		fieldsCtx := NewContext(auth.NewContext(ctx, auth.Principal{Subject: "user-1"}), FieldPlaceholderID, "placeholder-1")
+
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Info("placeholder found", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "request_id=request-1 principal=user-1 placeholder_id=placeholder-1 limit=20 name=\"a placeholder\"", *resolved.GetData())
+		})
+
+		WithContext(fieldsCtx, mockLogger, "limit", 20, "name", "a placeholder").Info("placeholder found")
+	})
+
+	t.Run("positive - trace of the context", func(t *testing.T) {
+		spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		})
+
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Info("traced", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "trace_id=01000000000000000000000000000000 span_id=0200000000000000", *resolved.GetData())
+		})
+
+		WithContext(trace.ContextWithSpanContext(context.Background(), spanContext), mockLogger).Info("traced")
+	})
+
+	t.Run("positive - route of the context", func(t *testing.T) {
+		routeCtx := chi.NewRouteContext()
+		routeCtx.RoutePatterns = []string{"/v1/placeholder/{id}"}
+
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Info("routed", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "route=/v1/placeholder/{id}", *resolved.GetData())
+		})
+
+		WithContext(context.WithValue(context.Background(), chi.RouteCtxKey, routeCtx), mockLogger).Info("routed")
+	})
+
+	t.Run("positive - route of the context replaced by the call", func(t *testing.T) {
+		routeCtx := chi.NewRouteContext()
+		routeCtx.RoutePatterns = []string{"/v1/*"}
+
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Info("denied", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "route=\"PUT /v1/placeholder/{id}\"", *resolved.GetData())
+		})
+
+		routedCtx := context.WithValue(context.Background(), chi.RouteCtxKey, routeCtx)
+		WithContext(routedCtx, mockLogger, FieldRoute, "PUT /v1/placeholder/{id}").Info("denied")
+	})
+
+	t.Run("positive - error chain is recorded", func(t *testing.T) {
+		err := fmt.Errorf("error getting placeholder: %w", sql.ErrNoRows)
+
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Error("error getting placeholder", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Equal(t, "error_chain=\"*fmt.wrapError > *errors.errorString\"", *resolved.GetData())
+			assert.Equal(t, err, *resolved.GetError())
+		})
+
+		WithContext(context.Background(), mockLogger).Error("error getting placeholder", log.WithError(err))
+	})
+
+	t.Run("positive - no field", func(t *testing.T) {
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Info("nothing to add", gomock.Any()).Do(func(_ string, options ...log.Option) {
+			resolved := resolve(options)
+			assert.Nil(t, resolved.GetData())
+			assert.Equal(t, 1, *resolved.GetSkip())
+		})
+
+		WithContext(context.Background(), mockLogger).Info("nothing to add")
+	})
+
+	t.Run("positive - nil logger", func(t *testing.T) {
+		assert.Nil(t, WithContext(ctx, nil))
+	})
+}
+
+func TestNewContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "topic", "placeholder")
+
+	t.Run("positive - fields are added after the existing ones", func(t *testing.T) {
+		assert.Equal(t, "topic=placeholder offset=1", fieldsFromContext(NewContext(ctx, "offset", 1)).String())
+		assert.Equal(t, "topic=placeholder", fieldsFromContext(ctx).String())
+	})
+
+	t.Run("positive - key without value", func(t *testing.T) {
+		assert.Equal(t, "topic=placeholder key=\"\"", fieldsFromContext(NewContext(ctx, "key")).String())
+	})
+
+	t.Run("positive - existing key is replaced", func(t *testing.T) {
+		replaced := NewContext(NewContext(ctx, "offset", 1), "topic", "placeholder.retry.5s")
+		assert.Equal(t, "topic=placeholder.retry.5s offset=1", fieldsFromContext(replaced).String())
+	})
+
+	t.Run("positive - no field", func(t *testing.T) {
+		assert.Equal(t, ctx, NewContext(ctx))
+	})
+}
+
+func TestErrorChain(t *testing.T) {
+	var (
+		root    = errors.New("root")
+		wrapped = fmt.Errorf("wrapped: %w", root)
+	)
+
+	assert.Equal(t, "*errors.errorString", errorChain(root))
+	assert.Equal(t, "*fmt.wrapError > *errors.errorString", errorChain(wrapped))
+	assert.Equal(t, "*errors.joinError > *fmt.wrapError > *errors.errorString > *errors.errorString", errorChain(errors.Join(wrapped, root)))
+}
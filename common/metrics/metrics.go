@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/dityuiri/go-adapter/kafka"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+const (
+	// Cache results
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+
+	// ResultSuccess and ResultError label the outcome of a produced message
+	ResultSuccess = "success"
+	ResultError   = "error"
+
+	// StatusError labels the proxy calls failing without a response
+	StatusError = "error"
+
+	// RouteUnmatched labels the requests no route matched, so unknown paths don't create new series
+	RouteUnmatched = "unmatched"
+)
+
+// Metrics holds the collectors of the app, registered on their own registry.
+// Every method is a no-op on a nil Metrics, components built without it aren't instrumented.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	cacheRequests       *prometheus.CounterVec
+	queryDuration       *prometheus.HistogramVec
+	proxyDuration       *prometheus.HistogramVec
+	kafkaProduced       *prometheus.CounterVec
+	kafkaConsumed       *prometheus.CounterVec
+	kafkaHandlerErrors  *prometheus.CounterVec
+	kafkaProcessing     *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests served, by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to serve HTTP requests, by method, route pattern and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Cache lookups, by cache and result (hit, miss or error).",
+		}, []string{"cache", "result"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_query_duration_seconds",
+			Help:    "Time taken by the repository queries, by repository and query.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"repository", "query"}),
+		proxyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "proxy_request_duration_seconds",
+			Help:    "Time taken by the calls to external services, by proxy, endpoint and response status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"proxy", "endpoint", "status"}),
+		kafkaProduced: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kafka_messages_produced_total",
+			Help: "Kafka messages produced, by topic and result.",
+		}, []string{"topic", "result"}),
+		kafkaConsumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kafka_messages_consumed_total",
+			Help: "Kafka messages consumed, by topic.",
+		}, []string{"topic"}),
+		kafkaHandlerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kafka_handler_errors_total",
+			Help: "Kafka messages whose handler returned an error, by topic.",
+		}, []string{"topic"}),
+		kafkaProcessing: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "kafka_message_processing_duration_seconds",
+			Help:    "Time taken by the handlers to process the Kafka messages, by topic.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"topic"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.cacheRequests,
+		m.queryDuration,
+		m.proxyDuration,
+		m.kafkaProduced,
+		m.kafkaConsumed,
+		m.kafkaHandlerErrors,
+		m.kafkaProcessing,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a request served by the route pattern, an empty pattern when no route matched
+func (m *Metrics) ObserveHTTPRequest(method string, route string, status int, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	if route == "" {
+		route = RouteUnmatched
+	}
+
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	m.httpRequests.With(labels).Inc()
+	m.httpRequestDuration.With(labels).Observe(since(start))
+}
+
+// ObserveCacheLookup records a lookup of the cache, a redis.Nil error being a miss
+func (m *Metrics) ObserveCacheLookup(cache string, err error) {
+	if m == nil {
+		return
+	}
+
+	result := CacheHit
+	switch {
+	case err == redis.Nil:
+		result = CacheMiss
+	case err != nil:
+		result = CacheError
+	}
+
+	m.cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveQuery records the duration of a repository query, meant to be deferred when the query starts
+func (m *Metrics) ObserveQuery(repository string, query string, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.queryDuration.WithLabelValues(repository, query).Observe(since(start))
+}
+
+// ObserveProxyCall records a call to an external service, err is the error of the call without a response
+func (m *Metrics) ObserveProxyCall(proxy string, endpoint string, resp *http.Response, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	status := StatusError
+	if err == nil && resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.proxyDuration.WithLabelValues(proxy, endpoint, status).Observe(since(start))
+}
+
+// ObserveProduce records the messages produced to the topic
+func (m *Metrics) ObserveProduce(topic string, messages int, err error) {
+	if m == nil {
+		return
+	}
+
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+	}
+
+	m.kafkaProduced.WithLabelValues(topic, result).Add(float64(messages))
+}
+
+// InstrumentHandler counts the messages consumed from the topic, the errors of the handler and its processing time
+func (m *Metrics) InstrumentHandler(topic string, handler func(kafka.Message) (bool, error)) func(kafka.Message) (bool, error) {
+	if m == nil {
+		return handler
+	}
+
+	return func(msg kafka.Message) (bool, error) {
+		start := common.TimeNow()
+		m.kafkaConsumed.WithLabelValues(topic).Inc()
+
+		done, err := handler(msg)
+		if err != nil {
+			m.kafkaHandlerErrors.WithLabelValues(topic).Inc()
+		}
+
+		m.kafkaProcessing.WithLabelValues(topic).Observe(since(start))
+
+		return done, err
+	}
+}
+
+func since(start time.Time) float64 {
+	return common.TimeNow().Sub(start).Seconds()
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	return recorder.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	var (
+		m     = New()
+		start = time.Now()
+	)
+
+	m.ObserveHTTPRequest(http.MethodGet, "/v1/placeholder/{placeholderID}/", http.StatusOK, start)
+	m.ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, start)
+
+	m.ObserveCacheLookup("placeholder", nil)
+	m.ObserveCacheLookup("placeholder", redis.Nil)
+	m.ObserveCacheLookup("placeholder", errors.New("connection refused"))
+
+	m.ObserveQuery("placeholder", "get_single_placeholder", start)
+
+	m.ObserveProxyCall("alpha", "/ping", &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, start)
+	m.ObserveProxyCall("alpha", "/ping", nil, errors.New("timeout"), start)
+
+	m.ObserveProduce("placeholder", 2, nil)
+	m.ObserveProduce("placeholder", 1, errors.New("error"))
+
+	handler := m.InstrumentHandler("placeholder", func(kafka.Message) (bool, error) {
+		return true, errors.New("error")
+	})
+	_, _ = handler(kafka.Message{})
+
+	body := scrape(t, m)
+	for _, line := range []string{
+		`http_requests_total{method="GET",route="/v1/placeholder/{placeholderID}/",status="200"} 1`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/v1/placeholder/{placeholderID}/",status="200"} 1`,
+		`cache_requests_total{cache="placeholder",result="hit"} 1`,
+		`cache_requests_total{cache="placeholder",result="miss"} 1`,
+		`cache_requests_total{cache="placeholder",result="error"} 1`,
+		`repository_query_duration_seconds_count{query="get_single_placeholder",repository="placeholder"} 1`,
+		`proxy_request_duration_seconds_count{endpoint="/ping",proxy="alpha",status="503"} 1`,
+		`proxy_request_duration_seconds_count{endpoint="/ping",proxy="alpha",status="error"} 1`,
+		`kafka_messages_produced_total{result="success",topic="placeholder"} 2`,
+		`kafka_messages_produced_total{result="error",topic="placeholder"} 1`,
+		`kafka_messages_consumed_total{topic="placeholder"} 1`,
+		`kafka_handler_errors_total{topic="placeholder"} 1`,
+		`kafka_message_processing_duration_seconds_count{topic="placeholder"} 1`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var (
+		m       *Metrics
+		handler = func(kafka.Message) (bool, error) { return true, nil }
+	)
+
+	assert.NotPanics(t, func() {
+		m.ObserveHTTPRequest(http.MethodGet, "/ping", http.StatusOK, time.Now())
+		m.ObserveCacheLookup("placeholder", nil)
+		m.ObserveQuery("placeholder", "get_single_placeholder", time.Now())
+		m.ObserveProxyCall("alpha", "/ping", nil, nil, time.Now())
+		m.ObserveProduce("placeholder", 1, nil)
+
+		done, err := m.InstrumentHandler("placeholder", handler)(kafka.Message{})
+		assert.True(t, done)
+		assert.Nil(t, err)
+	})
+}
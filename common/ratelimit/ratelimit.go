@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+//go:generate mockgen -package=ratelimit_mock -destination=../../mock/ratelimit/ratelimit.go . ILimiter
+
+const (
+	// Backends of the limiter
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+
+	// Client identities a limit is counted by
+	KeyByPrincipal = "principal"
+	KeyByIP        = "ip"
+)
+
+type (
+	// ILimiter counts the requests of the key against the rule
+	ILimiter interface {
+		Allow(ctx context.Context, key string, rule config.RateLimitRule) (Result, error)
+	}
+
+	Result struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+
+		// RetryAfter is the wait before the next request is allowed, only set when the request is not allowed
+		RetryAfter time.Duration
+
+		// Reset is the wait before the full limit is available again
+		Reset time.Duration
+	}
+)
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+const (
+	sweepInterval = time.Minute
+)
+
+type (
+	// TokenBucket is a process-local limiter. Every key has a bucket of rule.Requests tokens,
+	// refilled at rule.Requests per rule.Window. Use it when the service runs as a single instance.
+	TokenBucket struct {
+		mu        sync.Mutex
+		buckets   map[string]*bucket
+		lastSweep time.Time
+	}
+
+	bucket struct {
+		tokens  float64
+		updated time.Time
+		full    time.Time
+	}
+)
+
+func NewTokenBucket() *TokenBucket {
+	return &TokenBucket{
+		buckets:   make(map[string]*bucket),
+		lastSweep: common.TimeNow(),
+	}
+}
+
+func (tb *TokenBucket) Allow(_ context.Context, key string, rule config.RateLimitRule) (Result, error) {
+	var (
+		now      = common.TimeNow()
+		capacity = float64(rule.Requests)
+		rate     = capacity / rule.Window.Seconds()
+		result   = Result{Limit: rule.Requests}
+	)
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.sweep(now)
+
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		tb.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / rate)
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep drops the buckets that are full again, they are the same as the ones not created yet
+func (tb *TokenBucket) sweep(now time.Time) {
+	if now.Sub(tb.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range tb.buckets {
+		if !now.Before(b.full) {
+			delete(tb.buckets, key)
+		}
+	}
+
+	tb.lastSweep = now
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+func TestTokenBucket_Allow(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		now  = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		rule = config.RateLimitRule{Requests: 2, Window: 10 * time.Second}
+	)
+
+	common.TimeNow = func() time.Time { return now }
+	defer func() { common.TimeNow = time.Now }()
+
+	limiter := NewTokenBucket()
+
+	t.Run("positive - bucket starts full", func(t *testing.T) {
+		result, err := limiter.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, 1, result.Remaining)
+		assert.Equal(t, 5*time.Second, result.Reset)
+
+		result, _ = limiter.Allow(ctx, "client-1", rule)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+	})
+
+	t.Run("negative - bucket is empty", func(t *testing.T) {
+		result, err := limiter.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 5*time.Second, result.RetryAfter)
+		assert.Equal(t, 10*time.Second, result.Reset)
+	})
+
+	t.Run("positive - keys have their own bucket", func(t *testing.T) {
+		result, _ := limiter.Allow(ctx, "client-2", rule)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("positive - bucket is refilled", func(t *testing.T) {
+		now = now.Add(5 * time.Second)
+
+		result, _ := limiter.Allow(ctx, "client-1", rule)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+	})
+
+	t.Run("positive - full buckets are swept", func(t *testing.T) {
+		now = now.Add(sweepInterval)
+
+		_, _ = limiter.Allow(ctx, "client-3", rule)
+		assert.Len(t, limiter.buckets, 1)
+	})
+}
@@ -0,0 +1,52 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header carries the request ID on HTTP requests and responses
+	Header = "X-Request-ID"
+
+	// MessageHeader carries the request ID on Kafka messages
+	MessageHeader = "request_id"
+
+	// maxLength bounds the request ID accepted from clients
+	maxLength = 128
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx holding the request ID
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+// FromContext returns the request ID held by ctx, or an empty string when there is none
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(contextKey{}).(string)
+	return requestID
+}
+
+// New generates a request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether a request ID received from outside can be used as is.
+// It has to be printable ASCII without spaces so it can't forge log lines or headers.
+func Valid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
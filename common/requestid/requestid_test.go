@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		ctx := NewContext(context.Background(), "request-1")
+		assert.Equal(t, "request-1", FromContext(ctx))
+	})
+
+	t.Run("negative - no request id", func(t *testing.T) {
+		assert.Equal(t, "", FromContext(context.Background()))
+	})
+}
+
+func TestNew(t *testing.T) {
+	requestID := New()
+	assert.True(t, Valid(requestID))
+	assert.NotEqual(t, requestID, New())
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("7f9c2a1e-1b7a-4a43-9a8f-2c9d7d6f1e2b"))
+	assert.True(t, Valid("host/abc-000001"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("with space"))
+	assert.False(t, Valid("line\nbreak"))
+	assert.False(t, Valid("ünicode"))
+	assert.False(t, Valid(strings.Repeat("a", maxLength+1)))
+}
@@ -0,0 +1,101 @@
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/dityuiri/go-baseline/common"
+)
+
+const (
+	defaultInitial    = 500 * time.Millisecond
+	defaultMax        = 10 * time.Second
+	defaultMultiplier = 2
+)
+
+type (
+	// Backoff grows the wait between attempts exponentially, from Initial by Multiplier up to Max.
+	// Jitter randomizes every wait by up to that fraction of it, so replicas don't retry in lockstep.
+	Backoff struct {
+		Initial    time.Duration
+		Max        time.Duration
+		Multiplier float64
+		Jitter     float64
+	}
+
+	// Func is retried until it returns nil
+	Func func(ctx context.Context) error
+
+	// NotifyFunc is told about every failed attempt, and the wait before the next one
+	NotifyFunc func(attempt int, err error, wait time.Duration)
+)
+
+// Delay returns the wait after the given failed attempt, counted from 1
+func (b Backoff) Delay(attempt int) time.Duration {
+	var (
+		initial    = b.Initial
+		max        = b.Max
+		multiplier = b.Multiplier
+	)
+
+	if initial <= 0 {
+		initial = defaultInitial
+	}
+
+	if max <= 0 {
+		max = defaultMax
+	}
+
+	if multiplier < 1 {
+		multiplier = defaultMultiplier
+	}
+
+	delay := math.Min(float64(initial)*math.Pow(multiplier, float64(attempt-1)), float64(max))
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// Do calls fn until it succeeds, waiting the backoff delay between attempts. It gives up once maxWait
+// has passed, or ctx is done, and returns the error of the last attempt. A maxWait of 0 waits forever.
+func Do(ctx context.Context, backoff Backoff, maxWait time.Duration, fn Func, notify NotifyFunc) error {
+	var deadline time.Time
+	if maxWait > 0 {
+		deadline = common.TimeNow().Add(maxWait)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := backoff.Delay(attempt)
+		if !deadline.IsZero() {
+			left := deadline.Sub(common.TimeNow())
+			if left <= 0 {
+				return err
+			}
+
+			if wait > left {
+				wait = left
+			}
+		}
+
+		if notify != nil {
+			notify(attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	t.Run("grows exponentially up to the max", func(t *testing.T) {
+		backoff := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+		assert.Equal(t, 100*time.Millisecond, backoff.Delay(1))
+		assert.Equal(t, 200*time.Millisecond, backoff.Delay(2))
+		assert.Equal(t, 800*time.Millisecond, backoff.Delay(4))
+		assert.Equal(t, time.Second, backoff.Delay(5))
+		assert.Equal(t, time.Second, backoff.Delay(50))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, defaultInitial, Backoff{}.Delay(1))
+		assert.Equal(t, defaultMax, Backoff{}.Delay(100))
+	})
+
+	t.Run("jitter stays within its fraction", func(t *testing.T) {
+		backoff := Backoff{Initial: time.Second, Max: time.Second, Jitter: 0.2}
+
+		for i := 0; i < 100; i++ {
+			delay := backoff.Delay(1)
+			assert.GreaterOrEqual(t, delay, 800*time.Millisecond)
+			assert.LessOrEqual(t, delay, 1200*time.Millisecond)
+		}
+	})
+}
+
+func TestDo(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		backoff = Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	)
+
+	t.Run("positive - succeeds after failed attempts", func(t *testing.T) {
+		var (
+			calls    int
+			notified []int
+		)
+
+		err := Do(ctx, backoff, time.Second, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("connection refused")
+			}
+
+			return nil
+		}, func(attempt int, err error, wait time.Duration) {
+			notified = append(notified, attempt)
+			assert.EqualError(t, err, "connection refused")
+			assert.Equal(t, time.Millisecond, wait)
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []int{1, 2}, notified)
+	})
+
+	t.Run("negative - gives up after max wait", func(t *testing.T) {
+		err := Do(ctx, backoff, 20*time.Millisecond, func(context.Context) error {
+			return errors.New("connection refused")
+		}, nil)
+
+		assert.EqualError(t, err, "connection refused")
+	})
+
+	t.Run("negative - context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := Do(ctx, Backoff{Initial: time.Hour}, 0, func(context.Context) error {
+			return errors.New("connection refused")
+		}, nil)
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"github.com/dityuiri/go-adapter/kafka"
+)
+
+// InjectHTTP writes the trace context of ctx to the headers of an outbound request
+func InjectHTTP(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTP returns a copy of ctx holding the trace context of an inbound request
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// InjectMessage writes the trace context of ctx to the headers of a Kafka message
+func InjectMessage(ctx context.Context, msg *kafka.Message) {
+	if msg.Headers == nil {
+		msg.Headers = map[string][]byte{}
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, messageCarrier(msg.Headers))
+}
+
+// ExtractMessage returns a copy of ctx holding the trace context of a consumed Kafka message
+func ExtractMessage(ctx context.Context, msg kafka.Message) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, messageCarrier(msg.Headers))
+}
+
+// KafkaMessage are the span attributes locating a consumed Kafka message
+func KafkaMessage(msg kafka.Message) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingKafkaDestinationPartition(msg.Partition),
+		semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+	}
+}
+
+// messageCarrier adapts the Kafka message headers to the propagators
+type messageCarrier map[string][]byte
+
+func (c messageCarrier) Get(key string) string {
+	return string(c[key])
+}
+
+func (c messageCarrier) Set(key string, value string) {
+	c[key] = []byte(value)
+}
+
+func (c messageCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,169 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+const (
+	// Exporters of the spans
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	instrumentationName = "github.com/dityuiri/go-baseline"
+)
+
+// Provider exports the spans of the app. Without an exporter nothing is recorded,
+// the trace context is still propagated so the traces of other services stay connected.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProvider installs the global tracer provider and the W3C trace context propagator
+func NewProvider(ctx context.Context, cfg config.Tracing, appName string) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return &Provider{}, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRate))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(appName))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return &Provider{provider: provider}, nil
+}
+
+// Shutdown exports the spans still buffered
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil || p.provider == nil {
+		return nil
+	}
+
+	return p.provider.Shutdown(ctx)
+}
+
+// Start starts a span, child of the span held by ctx if any
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartServer starts the span of an inbound request, child of the remote span extracted into ctx if any
+func StartServer(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startKind(ctx, name, trace.SpanKindServer, attributes...)
+}
+
+// StartClient starts the span of an outbound request
+func StartClient(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startKind(ctx, name, trace.SpanKindClient, attributes...)
+}
+
+// StartProducer starts the span of a produced message
+func StartProducer(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startKind(ctx, name, trace.SpanKindProducer, attributes...)
+}
+
+// StartConsumer starts the span of a consumed message, child of the producer span extracted into ctx if any
+func StartConsumer(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startKind(ctx, name, trace.SpanKindConsumer, attributes...)
+}
+
+func startKind(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// RecordError marks the span as failed with the error, a nil error is ignored
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// PlaceholderID is the span attribute of the placeholder a span works on
+func PlaceholderID(placeholderID string) attribute.KeyValue {
+	return attribute.String("placeholder.id", placeholderID)
+}
+
+// DBStatement is the span attribute of the query run by a repository span
+func DBStatement(query string) attribute.KeyValue {
+	return semconv.DBStatement(query)
+}
+
+// MessagingDestination is the span attribute of the topic a message is produced to or consumed from
+func MessagingDestination(topic string) attribute.KeyValue {
+	return semconv.MessagingDestinationName(topic)
+}
+
+// HTTPRequest are the span attributes of the method and the route of an HTTP request
+func HTTPRequest(method string, route string) []attribute.KeyValue {
+	return []attribute.KeyValue{semconv.HTTPMethod(method), semconv.HTTPRoute(route)}
+}
+
+// RecordResponse records the status of an HTTP response on the span, a server error or err failing the span
+func RecordResponse(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		RecordError(span, err)
+		return
+	}
+
+	RecordStatus(span, resp.StatusCode)
+}
+
+// RecordStatus records the HTTP status code on the span, a server error failing the span
+func RecordStatus(span trace.Span, statusCode int) {
+	span.SetAttributes(semconv.HTTPStatusCode(statusCode))
+
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
+
+// SetRoute names the server span after the method and the route pattern which served the request
+func SetRoute(span trace.Span, method string, route string) {
+	span.SetName(method + " " + route)
+	span.SetAttributes(HTTPRequest(method, route)...)
+}
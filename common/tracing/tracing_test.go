@@ -0,0 +1,121 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-adapter/kafka"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+// record installs a tracer provider recording the ended spans
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	return recorder
+}
+
+func TestNewProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("positive - no exporter", func(t *testing.T) {
+		provider, err := NewProvider(ctx, config.Tracing{}, "go-baseline")
+		assert.Nil(t, err)
+		assert.Nil(t, provider.Shutdown(ctx))
+	})
+
+	t.Run("positive - stdout exporter", func(t *testing.T) {
+		provider, err := NewProvider(ctx, config.Tracing{Exporter: ExporterStdout, SampleRate: 1}, "go-baseline")
+		assert.Nil(t, err)
+		assert.Nil(t, provider.Shutdown(ctx))
+	})
+
+	t.Run("negative - unknown exporter", func(t *testing.T) {
+		_, err := NewProvider(ctx, config.Tracing{Exporter: "zipkin"}, "go-baseline")
+		assert.EqualError(t, err, `unknown tracing exporter "zipkin"`)
+	})
+}
+
+func TestStart(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := StartServer(context.Background(), "GET /v1/placeholder/{placeholderID}")
+	_, child := Start(ctx, "PlaceholderService.GetPlaceholder", PlaceholderID("1"))
+	RecordError(child, errors.New("error"))
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "PlaceholderService.GetPlaceholder", spans[0].Name())
+	assert.Equal(t, parent.SpanContext(), spans[0].Parent())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, trace.SpanKindServer, spans[1].SpanKind())
+	assert.False(t, spans[1].Parent().IsValid())
+}
+
+func TestRecordStatus(t *testing.T) {
+	recorder := record(t)
+
+	_, ok := Start(context.Background(), "ok")
+	RecordStatus(ok, http.StatusNotFound)
+	ok.End()
+
+	_, failed := Start(context.Background(), "failed")
+	RecordStatus(failed, http.StatusBadGateway)
+	failed.End()
+
+	spans := recorder.Ended()
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestPropagation(t *testing.T) {
+	record(t)
+
+	ctx, span := StartProducer(context.Background(), "PlaceholderProducer.ProducePlaceholderRecord")
+	defer span.End()
+
+	t.Run("http headers", func(t *testing.T) {
+		header := http.Header{}
+		InjectHTTP(ctx, header)
+		assert.NotEmpty(t, header.Get("traceparent"))
+
+		extracted := trace.SpanContextFromContext(ExtractHTTP(context.Background(), header))
+		assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+		assert.True(t, extracted.IsRemote())
+	})
+
+	t.Run("kafka message headers", func(t *testing.T) {
+		msg := &kafka.Message{}
+		InjectMessage(ctx, msg)
+		assert.NotEmpty(t, msg.Headers["traceparent"])
+
+		extracted := trace.SpanContextFromContext(ExtractMessage(context.Background(), *msg))
+		assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+	})
+
+	t.Run("message without trace context", func(t *testing.T) {
+		extracted := trace.SpanContextFromContext(ExtractMessage(context.Background(), kafka.Message{}))
+		assert.False(t, extracted.IsValid())
+	})
+}
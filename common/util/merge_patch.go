@@ -0,0 +1,47 @@
+package util
+
+import "encoding/json"
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) document to the original JSON document
+func MergePatch(original []byte, patch []byte) ([]byte, error) {
+	var (
+		originalDoc interface{}
+		patchDoc    interface{}
+	)
+
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, err
+	}
+
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(mergePatch(originalDoc, patchDoc))
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		// Anything other than an object replaces the target entirely
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
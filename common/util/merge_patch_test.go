@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	t.Run("positive - replace and keep members", func(t *testing.T) {
+		res, err := MergePatch([]byte(`{"name":"Aoi","amount":10000}`), []byte(`{"amount":25000}`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"name":"Aoi","amount":25000}`, string(res))
+	})
+
+	t.Run("positive - null removes member", func(t *testing.T) {
+		res, err := MergePatch([]byte(`{"name":"Aoi","amount":10000}`), []byte(`{"name":null}`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"amount":10000}`, string(res))
+	})
+
+	t.Run("positive - nested objects are merged", func(t *testing.T) {
+		res, err := MergePatch([]byte(`{"a":{"b":1,"c":2}}`), []byte(`{"a":{"c":null,"d":3}}`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"a":{"b":1,"d":3}}`, string(res))
+	})
+
+	t.Run("positive - non object patch replaces target", func(t *testing.T) {
+		res, err := MergePatch([]byte(`{"name":"Aoi"}`), []byte(`["Minase"]`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `["Minase"]`, string(res))
+	})
+
+	t.Run("negative - invalid patch", func(t *testing.T) {
+		_, err := MergePatch([]byte(`{}`), []byte(`potato`))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("negative - invalid original", func(t *testing.T) {
+		_, err := MergePatch([]byte(`potato`), []byte(`{}`))
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,254 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Rules are declared on struct fields with the `validate` tag, separated by comma.
+// Rule parameter is given after "=", e.g. `validate:"required,max=255"`.
+// Field name reported on failure follows the `json` tag of the field.
+const (
+	tagName = "validate"
+	jsonTag = "json"
+)
+
+type (
+	// FieldError describes a single field that broke one of its rules
+	FieldError struct {
+		Field   string
+		Rule    string
+		Param   string
+		Message string
+	}
+
+	// ValidationErrors holds every failing field of the validated struct
+	ValidationErrors []FieldError
+
+	// RuleFunc reports whether the field value satisfies the rule with the given parameter
+	RuleFunc func(value reflect.Value, param string) bool
+
+	// MessageFunc builds a human-readable message for a failing field
+	MessageFunc func(field string, param string) string
+
+	rule struct {
+		check   RuleFunc
+		message MessageFunc
+	}
+)
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]rule{
+		"required": {check: required, message: func(field, _ string) string {
+			return fmt.Sprintf("%s is required", field)
+		}},
+		"notblank": {check: notBlank, message: func(field, _ string) string {
+			return fmt.Sprintf("%s must not be blank", field)
+		}},
+		"min": {check: minimum, message: func(field, param string) string {
+			return fmt.Sprintf("%s must be at least %s", field, param)
+		}},
+		"max": {check: maximum, message: func(field, param string) string {
+			return fmt.Sprintf("%s must be at most %s", field, param)
+		}},
+		"oneof": {check: oneOf, message: func(field, param string) string {
+			return fmt.Sprintf("%s must be one of [%s]", field, strings.Join(strings.Fields(param), ", "))
+		}},
+		"uuid": {check: isUUID, message: func(field, _ string) string {
+			return fmt.Sprintf("%s must be a valid uuid", field)
+		}},
+	}
+)
+
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, 0, len(ve))
+	for _, fe := range ve {
+		messages = append(messages, fe.Message)
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// RegisterRule adds or replaces a rule that can be used in the `validate` tag.
+// Register custom rules during initialization, before any validation happens.
+func RegisterRule(name string, check RuleFunc, message MessageFunc) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	rules[name] = rule{check: check, message: message}
+}
+
+// Validate checks the struct (or pointer to struct) against the rules in its `validate` tags.
+// It returns ValidationErrors listing every failing field, or nil when the struct is valid.
+func Validate(data interface{}) error {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fmt.Errorf("validator: nil %s", value.Type())
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validator: unsupported type %s", value.Type())
+	}
+
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	var errs ValidationErrors
+	validateStruct(value, "", &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func validateStruct(value reflect.Value, prefix string, errs *ValidationErrors) {
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			structField = structType.Field(i)
+			field       = value.Field(i)
+			name        = prefix + fieldName(structField)
+		)
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag := structField.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		if tag != "" {
+			for _, ruleDefinition := range strings.Split(tag, ",") {
+				ruleName, param, _ := strings.Cut(strings.TrimSpace(ruleDefinition), "=")
+
+				r, ok := rules[ruleName]
+				if !ok {
+					panic(fmt.Sprintf("validator: unknown rule %q on %s.%s", ruleName, structType.Name(), structField.Name))
+				}
+
+				// Only "required" cares about empty optional fields
+				if ruleName != "required" && isNilPointer(field) {
+					continue
+				}
+
+				if !r.check(indirect(field), param) {
+					*errs = append(*errs, FieldError{
+						Field:   name,
+						Rule:    ruleName,
+						Param:   param,
+						Message: r.message(name, param),
+					})
+
+					// Further rules on the same field would only repeat the failure
+					break
+				}
+			}
+		}
+
+		nested := indirect(field)
+		if nested.Kind() == reflect.Struct && nested.Type() != reflect.TypeOf(time.Time{}) {
+			validateStruct(nested, name+".", errs)
+		}
+	}
+}
+
+func fieldName(structField reflect.StructField) string {
+	name, _, _ := strings.Cut(structField.Tag.Get(jsonTag), ",")
+	if name == "" || name == "-" {
+		return structField.Name
+	}
+
+	return name
+}
+
+func isNilPointer(value reflect.Value) bool {
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	return value
+}
+
+func required(value reflect.Value, _ string) bool {
+	return value.IsValid() && !value.IsZero()
+}
+
+func notBlank(value reflect.Value, _ string) bool {
+	if value.Kind() != reflect.String {
+		return true
+	}
+
+	return strings.TrimSpace(value.String()) != ""
+}
+
+func minimum(value reflect.Value, param string) bool {
+	return compare(value, param, func(actual, limit float64) bool { return actual >= limit })
+}
+
+func maximum(value reflect.Value, param string) bool {
+	return compare(value, param, func(actual, limit float64) bool { return actual <= limit })
+}
+
+// compare checks numbers by value, and strings, slices and maps by their length
+func compare(value reflect.Value, param string, fn func(actual, limit float64) bool) bool {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validator: invalid parameter %q", param))
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fn(float64(value.Int()), limit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fn(float64(value.Uint()), limit)
+	case reflect.Float32, reflect.Float64:
+		return fn(value.Float(), limit)
+	case reflect.String:
+		return fn(float64(utf8.RuneCountInString(value.String())), limit)
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return fn(float64(value.Len()), limit)
+	default:
+		return true
+	}
+}
+
+func oneOf(value reflect.Value, param string) bool {
+	actual := fmt.Sprint(value.Interface())
+	for _, option := range strings.Fields(param) {
+		if actual == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isUUID(value reflect.Value, _ string) bool {
+	if value.Kind() != reflect.String {
+		return false
+	}
+
+	_, err := uuid.Parse(value.String())
+	return err == nil
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testNested struct {
+		Code string `json:"code" validate:"required,uuid"`
+	}
+
+	testRequest struct {
+		Name     string      `json:"name" validate:"required,notblank,max=5"`
+		Amount   int         `json:"amount" validate:"min=0,max=100"`
+		Status   string      `json:"status" validate:"oneof=active inactive"`
+		Tags     []string    `json:"tags" validate:"max=2"`
+		Optional *int        `json:"optional,omitempty" validate:"min=1"`
+		Nested   testNested  `json:"nested"`
+		Pointer  *testNested `json:"pointer"`
+		Ignored  string      `json:"-" validate:"-"`
+	}
+)
+
+func validRequest() testRequest {
+	return testRequest{
+		Name:   "Aoi",
+		Amount: 10,
+		Status: "active",
+		Tags:   []string{"a"},
+		Nested: testNested{Code: "0b3c0a42-7b7c-4ad2-9d5b-1b6ac0a3f8f4"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		request := validRequest()
+		assert.Nil(t, Validate(request))
+		assert.Nil(t, Validate(&request))
+	})
+
+	t.Run("negative - every failing field is reported", func(t *testing.T) {
+		var (
+			optional = 0
+			request  = testRequest{
+				Name:     "Minase Aoi",
+				Amount:   -1,
+				Status:   "deleted",
+				Tags:     []string{"a", "b", "c"},
+				Optional: &optional,
+				Pointer:  &testNested{Code: "potato"},
+			}
+		)
+
+		err := Validate(request)
+
+		var validationErrors ValidationErrors
+		assert.ErrorAs(t, err, &validationErrors)
+		assert.Equal(t, ValidationErrors{
+			{Field: "name", Rule: "max", Param: "5", Message: "name must be at most 5"},
+			{Field: "amount", Rule: "min", Param: "0", Message: "amount must be at least 0"},
+			{Field: "status", Rule: "oneof", Param: "active inactive", Message: "status must be one of [active, inactive]"},
+			{Field: "tags", Rule: "max", Param: "2", Message: "tags must be at most 2"},
+			{Field: "optional", Rule: "min", Param: "1", Message: "optional must be at least 1"},
+			{Field: "nested.code", Rule: "required", Message: "nested.code is required"},
+			{Field: "pointer.code", Rule: "uuid", Message: "pointer.code must be a valid uuid"},
+		}, validationErrors)
+	})
+
+	t.Run("negative - blank string", func(t *testing.T) {
+		request := validRequest()
+		request.Name = "   "
+
+		err := Validate(request)
+		assert.EqualError(t, err, "validation failed: name must not be blank")
+	})
+
+	t.Run("negative - nil pointer", func(t *testing.T) {
+		var request *testRequest
+		assert.NotNil(t, Validate(request))
+	})
+
+	t.Run("negative - not a struct", func(t *testing.T) {
+		assert.NotNil(t, Validate("potato"))
+	})
+
+	t.Run("negative - unknown rule", func(t *testing.T) {
+		type unknown struct {
+			Name string `validate:"potato"`
+		}
+
+		assert.Panics(t, func() {
+			_ = Validate(unknown{})
+		})
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("lowercase", func(value reflect.Value, _ string) bool {
+		return value.String() == strings.ToLower(value.String())
+	}, func(field, _ string) string {
+		return field + " must be lowercase"
+	})
+
+	type custom struct {
+		Name string `json:"name" validate:"lowercase"`
+	}
+
+	assert.Nil(t, Validate(custom{Name: "aoi"}))
+	assert.EqualError(t, Validate(custom{Name: "Aoi"}), "validation failed: name must be lowercase")
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -14,25 +19,127 @@ import (
 
 type (
 	Configuration struct {
-		AppName    string
-		Const      *Constants
-		Kafka      *Kafka
-		Redis      *redis.Config
-		Database   *db.Configuration
-		HTTPClient *HttpClient
+		AppName     string
+		Environment string
+		LogLevel    string
+		Const       *Constants
+		Kafka       *Kafka
+		Redis       *redis.Config
+		Database    *db.Configuration
+		Passwords   *Passwords
+		HTTPClient  *HttpClient
+		Auth        *Auth
+		RateLimit   *RateLimit
+		Idempotency *Idempotency
+		Health      *Health
+		Startup     *Startup
+		Tracing     *Tracing
+		Cache       *Cache
+		Features    map[string]bool
+
+		// values are the values of the keys the configuration is built from, see Diff
+		values map[string]string
 	}
 
+	// Kafka configures the producer and the consumers. ConsumerTopics and ProducerTopics map the logical topic
+	// names used by the app to the kafka topics. A consumer which panics or fails to read restarts after a backoff
+	// growing from RestartInitialBackoff up to RestartMaxBackoff. RetryTiers maps the logical topic names to the
+	// delays of their retry topics, a message whose handling fails is retried once on each before the dead letter topic.
+	// The offsets of the messages done with are committed by batches of CommitBatchSize, or every CommitInterval.
 	Kafka struct {
-		Consumer       *consumer.Configuration
-		Producer       *producer.Configuration
-		ConsumerTopics map[string]string
-		ProducerTopics map[string]string
+		Consumer              *consumer.Configuration
+		Producer              *producer.Configuration
+		ConsumerTopics        map[string]string
+		ProducerTopics        map[string]string
+		RetryTiers            map[string][]time.Duration
+		RestartInitialBackoff time.Duration
+		RestartMaxBackoff     time.Duration
+		CommitBatchSize       int
+		CommitInterval        time.Duration
+	}
+
+	// Passwords are the passwords of the adapters. They are left out of Database and Redis, whose fields can't be
+	// a Secret, and set only in the configurations built for the adapters by DatabaseConfig and RedisConfig.
+	Passwords struct {
+		Database          Secret
+		DatabaseMigration Secret
+		Redis             Secret
 	}
 
 	Constants struct {
-		GRPCPort     int
-		HTTPPort     int
-		ShortTimeout int
+		GRPCPort      int
+		HTTPPort      int
+		AdminPort     int
+		ShortTimeout  int
+		RouteTimeouts map[string]int
+	}
+
+	// Auth configures the JWT bearer authentication. HMACSecret verifies HS256 tokens,
+	// keys from PublicKeyFile (PEM) and JWKSFile verify RS256 and ES256 tokens.
+	Auth struct {
+		Enabled       bool
+		HMACSecret    Secret
+		PublicKeyFile string
+		JWKSFile      string
+		Audience      string
+		Issuer        string
+		Leeway        int
+	}
+
+	// RateLimit limits the requests of each client per route. Clients are identified by the first
+	// available of KeyBy (principal, ip), routes without a limit in Routes use Default.
+	RateLimit struct {
+		Enabled bool
+		Backend string
+		KeyBy   []string
+		Default RateLimitRule
+		Routes  map[string]RateLimitRule
+	}
+
+	// RateLimitRule allows Requests per Window
+	RateLimitRule struct {
+		Requests int
+		Window   time.Duration
+	}
+
+	// Idempotency configures the Idempotency-Key support. Responses are replayed for TTL, an in progress
+	// request holds its key for LockTTL at most, and repeated requests wait up to Wait for it to complete.
+	Idempotency struct {
+		TTL     time.Duration
+		LockTTL time.Duration
+		Wait    time.Duration
+	}
+
+	// Health configures the readiness checks. A check times out after its Timeouts entry, or Timeout,
+	// and NonCritical checks only degrade the readiness. ShutdownDelay keeps serving, while unready,
+	// before the servers are closed so the load balancer stops routing to the app first.
+	Health struct {
+		Timeout       time.Duration
+		Timeouts      map[string]time.Duration
+		NonCritical   []string
+		ShutdownDelay time.Duration
+	}
+
+	// Startup configures how long the app waits for its dependencies before giving up. The wait between
+	// attempts grows exponentially from InitialBackoff up to MaxBackoff, a MaxWait of 0 waits forever.
+	Startup struct {
+		MaxWait        time.Duration
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+	}
+
+	// Tracing configures the export of the spans: none, stdout, or otlp to the collector at Endpoint (host:port).
+	// SampleRate is the fraction of the traces started by the app which are sampled, from 0 to 1.
+	Tracing struct {
+		Exporter   string
+		Endpoint   string
+		Insecure   bool
+		SampleRate float64
+	}
+
+	// Cache configures the expiration of the cached entities. PlaceholderTTL of 0 keeps the REDIS_EXPIRATION.
+	Cache struct {
+		PlaceholderTTL time.Duration
 	}
 
 	HttpClient struct {
@@ -45,33 +152,146 @@ type (
 	}
 )
 
-func LoadConfiguration() *Configuration {
-	// Initialize viper
+const (
+	// DefaultEnvironment is used when ENVIRONMENT is not set
+	DefaultEnvironment = "local"
+
+	// defaultDir holds the configuration files when CONFIG_DIR is not set
+	defaultDir = "config"
+)
+
+// fileTypes are the formats of the configuration files, a file is looked for in this order
+var fileTypes = []string{"yaml", "yml", "toml", "env"}
+
+// LoadConfiguration loads the configuration of the ENVIRONMENT, local by default. See Load.
+func LoadConfiguration() (*Configuration, error) {
+	return Load(Environment())
+}
+
+// Environment returns the ENVIRONMENT the app runs in
+func Environment() string {
+	if environment := strings.TrimSpace(os.Getenv("ENVIRONMENT")); environment != "" {
+		return environment
+	}
+
+	return DefaultEnvironment
+}
+
+// FindFile returns the configuration file of the environment, {CONFIG_DIR}/{environment}.{yaml|yml|toml|env},
+// and false when there is none
+func FindFile(environment string) (string, bool) {
+	dir := os.Getenv("CONFIG_DIR")
+	if dir == "" {
+		dir = defaultDir
+	}
+
+	for _, fileType := range fileTypes {
+		file := filepath.Join(dir, fmt.Sprintf("%s.%s", environment, fileType))
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, true
+		}
+	}
+
+	return "", false
+}
+
+// Load loads the configuration of the environment in layers: the typed defaults, then the configuration file of
+// the environment, if any, then the environment variables, which take precedence. The files use the names of the
+// environment variables as their keys. Every invalid or missing key is reported in one *ValidationError.
+func Load(environment string) (*Configuration, error) {
+	// The adapters read their configuration from the global viper, so it is loaded there
+	viper.Reset()
+	setDefaults()
 	viper.AutomaticEnv()
-	return &Configuration{
-		AppName:    viper.GetString("APP_NAME"),
-		Const:      loadConstants(),
-		Redis:      redis.NewConfig(),
-		Kafka:      loadKafkaConfig(),
-		Database:   loadDatabaseConfig(),
-		HTTPClient: loadHTTPClientConfig(),
+
+	if file, found := FindFile(environment); found {
+		viper.SetConfigFile(file)
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("reading configuration file %s: %w", file, err)
+		}
 	}
+
+	return build(environment)
+}
+
+// build builds the configuration of the environment from the keys read by viper, and validates it
+func build(environment string) (*Configuration, error) {
+	e := &ValidationError{}
+	loadSecrets(e)
+	checkKeys(e)
+
+	configuration := &Configuration{
+		AppName:     viper.GetString("APP_NAME"),
+		Environment: environment,
+		LogLevel:    viper.GetString("LOG_LEVEL"),
+		Const:       loadConstants(),
+		Redis:       loadRedisConfig(),
+		Kafka:       loadKafkaConfig(),
+		Database:    loadDatabaseConfig(),
+		Passwords:   loadPasswords(),
+		HTTPClient:  loadHTTPClientConfig(),
+		Auth:        loadAuthConfig(),
+		RateLimit:   loadRateLimitConfig(),
+		Idempotency: loadIdempotencyConfig(),
+		Health:      loadHealthConfig(),
+		Startup:     loadStartupConfig(),
+		Tracing:     loadTracingConfig(),
+		Cache:       loadCacheConfig(),
+		Features:    loadFeatureFlags(),
+		values:      values(),
+	}
+
+	configuration.validate(e)
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+
+	return configuration, nil
 }
 
 func loadConstants() *Constants {
+	var (
+		routeTimeouts       = strings.Split(strings.TrimSpace(viper.GetString("ROUTE_TIMEOUTS")), ";")
+		mappedRouteTimeouts = map[string]int{}
+	)
+
+	for _, routeTimeout := range routeTimeouts {
+		t := strings.Split(strings.TrimSpace(routeTimeout), ":")
+		if len(t) != 2 {
+			continue
+		}
+
+		if timeout, err := strconv.Atoi(t[1]); err == nil {
+			mappedRouteTimeouts[t[0]] = timeout
+		}
+	}
+
 	return &Constants{
-		GRPCPort:     viper.GetInt("GRPC_PORT"),
-		HTTPPort:     viper.GetInt("HTTP_PORT"),
-		ShortTimeout: viper.GetInt("SHORT_TIMEOUT"),
+		GRPCPort:      viper.GetInt("GRPC_PORT"),
+		HTTPPort:      viper.GetInt("HTTP_PORT"),
+		AdminPort:     viper.GetInt("ADMIN_PORT"),
+		ShortTimeout:  viper.GetInt("SHORT_TIMEOUT"),
+		RouteTimeouts: mappedRouteTimeouts,
 	}
 }
 
+// RouteTimeout returns the timeout configured for the given route name, falling back to SHORT_TIMEOUT
+func (c *Constants) RouteTimeout(route string) time.Duration {
+	if timeout, ok := c.RouteTimeouts[route]; ok && timeout > 0 {
+		return time.Duration(timeout) * time.Second
+	}
+
+	return time.Duration(c.ShortTimeout) * time.Second
+}
+
 func loadKafkaConfig() *Kafka {
 	var (
 		consumerTopics       = strings.Split(strings.TrimSpace(viper.GetString("CONSUMER_TOPICS")), ";")
 		producerTopics       = strings.Split(strings.TrimSpace(viper.GetString("PRODUCER_TOPICS")), ";")
 		mappedConsumerTopics = map[string]string{}
 		mappedProducerTopics = map[string]string{}
+		retryTiers           = strings.Split(strings.TrimSpace(viper.GetString("CONSUMER_RETRY_TIERS")), ";")
+		mappedRetryTiers     = map[string][]time.Duration{}
 	)
 
 	for _, topic := range consumerTopics {
@@ -88,6 +308,19 @@ func loadKafkaConfig() *Kafka {
 		}
 	}
 
+	for _, tiers := range retryTiers {
+		t := strings.Split(strings.TrimSpace(tiers), ":")
+		if len(t) != 2 {
+			continue
+		}
+
+		for _, delay := range strings.Split(t[1], ",") {
+			if d, err := time.ParseDuration(strings.TrimSpace(delay)); err == nil {
+				mappedRetryTiers[t[0]] = append(mappedRetryTiers[t[0]], d)
+			}
+		}
+	}
+
 	return &Kafka{
 		Consumer: &consumer.Configuration{
 			Brokers:     strings.Split(viper.GetString("KAFKA_BROKERS"), ","),
@@ -96,7 +329,12 @@ func loadKafkaConfig() *Kafka {
 			MaxBytes:    10e6,
 			StartOffset: consumer.LastOffset,
 		},
-		ConsumerTopics: mappedConsumerTopics,
+		ConsumerTopics:        mappedConsumerTopics,
+		RetryTiers:            mappedRetryTiers,
+		RestartInitialBackoff: viper.GetDuration("CONSUMER_RESTART_INITIAL_BACKOFF"),
+		RestartMaxBackoff:     viper.GetDuration("CONSUMER_RESTART_MAX_BACKOFF"),
+		CommitBatchSize:       viper.GetInt("CONSUMER_COMMIT_BATCH_SIZE"),
+		CommitInterval:        viper.GetDuration("CONSUMER_COMMIT_INTERVAL"),
 		Producer: &producer.Configuration{
 			Brokers:      strings.Split(viper.GetString("KAFKA_BROKERS"), ","),
 			Async:        false,
@@ -106,8 +344,53 @@ func loadKafkaConfig() *Kafka {
 	}
 }
 
+// loadDatabaseConfig loads the database configuration without its passwords, see DatabaseConfig
 func loadDatabaseConfig() *db.Configuration {
-	return db.NewConfig()
+	configuration := db.NewConfig()
+	configuration.Password = ""
+	if configuration.Migration != nil {
+		configuration.Migration.Password = ""
+	}
+
+	return configuration
+}
+
+// loadRedisConfig loads the redis configuration without its password, see RedisConfig
+func loadRedisConfig() *redis.Config {
+	configuration := redis.NewConfig()
+	configuration.Password = ""
+
+	return configuration
+}
+
+func loadPasswords() *Passwords {
+	return &Passwords{
+		Database:          Secret(viper.GetString("DB_PASSWORD")),
+		DatabaseMigration: Secret(viper.GetString("DB_MIGRATION_PASSWORD")),
+		Redis:             Secret(viper.GetString("REDIS_PASSWORD")),
+	}
+}
+
+// DatabaseConfig returns the configuration of the database adapter, with its passwords. Don't keep or print it.
+func (c *Configuration) DatabaseConfig() *db.Configuration {
+	configuration := *c.Database
+	configuration.Password = c.Passwords.Database.Value()
+
+	if c.Database.Migration != nil {
+		migration := *c.Database.Migration
+		migration.Password = c.Passwords.DatabaseMigration.Value()
+		configuration.Migration = &migration
+	}
+
+	return &configuration
+}
+
+// RedisConfig returns the configuration of the redis adapter, with its password. Don't keep or print it.
+func (c *Configuration) RedisConfig() *redis.Config {
+	configuration := *c.Redis
+	configuration.Password = c.Passwords.Redis.Value()
+
+	return &configuration
 }
 
 func loadHTTPClientConfig() *HttpClient {
@@ -118,3 +401,181 @@ func loadHTTPClientConfig() *HttpClient {
 		},
 	}
 }
+
+func loadAuthConfig() *Auth {
+	return &Auth{
+		Enabled:       viper.GetBool("AUTH_ENABLED"),
+		HMACSecret:    Secret(viper.GetString("JWT_HMAC_SECRET")),
+		PublicKeyFile: viper.GetString("JWT_PUBLIC_KEY_FILE"),
+		JWKSFile:      viper.GetString("JWT_JWKS_FILE"),
+		Audience:      viper.GetString("JWT_AUDIENCE"),
+		Issuer:        viper.GetString("JWT_ISSUER"),
+		Leeway:        viper.GetInt("JWT_LEEWAY"),
+	}
+}
+
+func loadRateLimitConfig() *RateLimit {
+	var (
+		routeLimits       = strings.Split(strings.TrimSpace(viper.GetString("RATE_LIMITS")), ";")
+		mappedRouteLimits = map[string]RateLimitRule{}
+		keyBy             []string
+	)
+
+	for _, routeLimit := range routeLimits {
+		l := strings.Split(strings.TrimSpace(routeLimit), ":")
+		if len(l) != 2 {
+			continue
+		}
+
+		if rule, ok := parseRateLimitRule(l[1]); ok {
+			mappedRouteLimits[l[0]] = rule
+		}
+	}
+
+	for _, key := range strings.Split(viper.GetString("RATE_LIMIT_KEY_BY"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keyBy = append(keyBy, key)
+		}
+	}
+
+	defaultRule, _ := parseRateLimitRule(viper.GetString("RATE_LIMIT_DEFAULT"))
+
+	return &RateLimit{
+		Enabled: viper.GetBool("RATE_LIMIT_ENABLED"),
+		Backend: viper.GetString("RATE_LIMIT_BACKEND"),
+		KeyBy:   keyBy,
+		Default: defaultRule,
+		Routes:  mappedRouteLimits,
+	}
+}
+
+// parseRateLimitRule parses rules written as {requests}/{window}, like 100/1m
+func parseRateLimitRule(s string) (RateLimitRule, bool) {
+	requests, window, found := strings.Cut(strings.TrimSpace(s), "/")
+	if !found {
+		return RateLimitRule{}, false
+	}
+
+	rule := RateLimitRule{}
+	rule.Requests, _ = strconv.Atoi(requests)
+	rule.Window, _ = time.ParseDuration(window)
+
+	return rule, rule.Requests > 0 && rule.Window > 0
+}
+
+// RouteLimit returns the rule configured for the given route name, falling back to RATE_LIMIT_DEFAULT
+func (r *RateLimit) RouteLimit(route string) RateLimitRule {
+	if rule, ok := r.Routes[route]; ok {
+		return rule
+	}
+
+	return r.Default
+}
+
+func loadIdempotencyConfig() *Idempotency {
+	return &Idempotency{
+		TTL:     viper.GetDuration("IDEMPOTENCY_TTL"),
+		LockTTL: viper.GetDuration("IDEMPOTENCY_LOCK_TTL"),
+		Wait:    viper.GetDuration("IDEMPOTENCY_WAIT"),
+	}
+}
+
+func loadHealthConfig() *Health {
+	var (
+		checkTimeouts       = strings.Split(strings.TrimSpace(viper.GetString("HEALTH_CHECK_TIMEOUTS")), ";")
+		mappedCheckTimeouts = map[string]time.Duration{}
+		nonCritical         []string
+	)
+
+	for _, checkTimeout := range checkTimeouts {
+		t := strings.Split(strings.TrimSpace(checkTimeout), ":")
+		if len(t) != 2 {
+			continue
+		}
+
+		if timeout, err := time.ParseDuration(t[1]); err == nil {
+			mappedCheckTimeouts[t[0]] = timeout
+		}
+	}
+
+	for _, check := range strings.Split(viper.GetString("HEALTH_NON_CRITICAL"), ",") {
+		if check = strings.TrimSpace(check); check != "" {
+			nonCritical = append(nonCritical, check)
+		}
+	}
+
+	return &Health{
+		Timeout:       viper.GetDuration("HEALTH_CHECK_TIMEOUT"),
+		Timeouts:      mappedCheckTimeouts,
+		NonCritical:   nonCritical,
+		ShutdownDelay: viper.GetDuration("HEALTH_SHUTDOWN_DELAY"),
+	}
+}
+
+// CheckTimeout returns the timeout configured for the given check, falling back to HEALTH_CHECK_TIMEOUT
+func (h *Health) CheckTimeout(check string) time.Duration {
+	if timeout, ok := h.Timeouts[check]; ok && timeout > 0 {
+		return timeout
+	}
+
+	return h.Timeout
+}
+
+// IsCritical reports whether the app is unready while the given check fails
+func (h *Health) IsCritical(check string) bool {
+	for _, nonCritical := range h.NonCritical {
+		if nonCritical == check {
+			return false
+		}
+	}
+
+	return true
+}
+
+func loadStartupConfig() *Startup {
+	return &Startup{
+		MaxWait:        viper.GetDuration("STARTUP_MAX_WAIT"),
+		InitialBackoff: viper.GetDuration("STARTUP_INITIAL_BACKOFF"),
+		MaxBackoff:     viper.GetDuration("STARTUP_MAX_BACKOFF"),
+	}
+}
+
+func loadTracingConfig() *Tracing {
+	return &Tracing{
+		Exporter:   viper.GetString("TRACING_EXPORTER"),
+		Endpoint:   viper.GetString("TRACING_OTLP_ENDPOINT"),
+		Insecure:   viper.GetBool("TRACING_OTLP_INSECURE"),
+		SampleRate: viper.GetFloat64("TRACING_SAMPLE_RATE"),
+	}
+}
+
+func loadCacheConfig() *Cache {
+	return &Cache{
+		PlaceholderTTL: viper.GetDuration("PLACEHOLDER_CACHE_TTL"),
+	}
+}
+
+func loadFeatureFlags() map[string]bool {
+	var (
+		flags       = strings.Split(strings.TrimSpace(viper.GetString("FEATURE_FLAGS")), ";")
+		mappedFlags = map[string]bool{}
+	)
+
+	for _, flag := range flags {
+		f := strings.Split(strings.TrimSpace(flag), ":")
+		if len(f) != 2 {
+			continue
+		}
+
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(f[1])); err == nil {
+			mappedFlags[strings.TrimSpace(f[0])] = enabled
+		}
+	}
+
+	return mappedFlags
+}
+
+// FeatureEnabled reports whether the feature flag is enabled in FEATURE_FLAGS, flags which are not listed are disabled
+func (c *Configuration) FeatureEnabled(flag string) bool {
+	return c.Features[flag]
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// requiredKeys holds a value for each required key, in the env format
+const requiredKeys = `APP_NAME=go-baseline
+REDIS_HOST=localhost
+KAFKA_BROKERS=localhost:9092
+KAFKA_GROUP_ID=go-baseline
+ALPHA_URL=http://localhost:8700
+DB_HOST=localhost
+DB_USER=username
+DB_NAME=placeholder
+JWT_HMAC_SECRET=secret
+`
+
+func writeConfigFile(t *testing.T, name, content string) {
+	dir := t.TempDir()
+	t.Setenv("CONFIG_DIR", dir)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func problemKeys(err error) []string {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+
+	return keys
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("positive - env file with defaults", func(t *testing.T) {
+		writeConfigFile(t, "test.env", requiredKeys+"ROUTE_TIMEOUTS=\"placeholder_get:5\"\nCONSUMER_TOPICS=\"placeholder:placeholder-record\"\n"+
+			"CONSUMER_RETRY_TIERS=\"placeholder:5s, 1m\"\n")
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "go-baseline", configuration.AppName)
+		assert.Equal(t, "test", configuration.Environment)
+		assert.Equal(t, "INFO", configuration.LogLevel)
+		assert.Equal(t, 8080, configuration.Const.HTTPPort)
+		assert.Equal(t, 5*time.Second, configuration.Const.RouteTimeout("placeholder_get"))
+		assert.Equal(t, 24*time.Hour, configuration.Idempotency.TTL)
+		assert.Equal(t, 5432, configuration.Database.Port)
+		assert.Equal(t, []string{"localhost:9092"}, configuration.Kafka.Consumer.Brokers)
+		assert.Equal(t, []time.Duration{5 * time.Second, time.Minute}, configuration.Kafka.RetryTiers["placeholder"])
+	})
+
+	t.Run("positive - yaml file", func(t *testing.T) {
+		writeConfigFile(t, "test.yaml", `
+APP_NAME: go-baseline
+REDIS_HOST: redis
+KAFKA_BROKERS: kafka:9092
+KAFKA_GROUP_ID: go-baseline
+ALPHA_URL: http://alpha:8700
+DB_HOST: postgres
+DB_USER: username
+DB_NAME: placeholder
+AUTH_ENABLED: false
+HTTP_PORT: 8081
+HEALTH_CHECK_TIMEOUT: 2s
+`)
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, 8081, configuration.Const.HTTPPort)
+		assert.Equal(t, "redis", configuration.Redis.Host)
+		assert.False(t, configuration.Auth.Enabled)
+		assert.Equal(t, 2*time.Second, configuration.Health.Timeout)
+	})
+
+	t.Run("positive - toml file", func(t *testing.T) {
+		writeConfigFile(t, "test.toml", `
+APP_NAME = "go-baseline"
+REDIS_HOST = "redis"
+KAFKA_BROKERS = "kafka:9092"
+KAFKA_GROUP_ID = "go-baseline"
+ALPHA_URL = "http://alpha:8700"
+DB_HOST = "postgres"
+DB_USER = "username"
+DB_NAME = "placeholder"
+JWT_HMAC_SECRET = "secret"
+TRACING_SAMPLE_RATE = 0.5
+`)
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, 0.5, configuration.Tracing.SampleRate)
+	})
+
+	t.Run("positive - environment variables take precedence", func(t *testing.T) {
+		writeConfigFile(t, "test.env", requiredKeys+"HTTP_PORT=8081\n")
+		t.Setenv("HTTP_PORT", "8082")
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, 8082, configuration.Const.HTTPPort)
+	})
+
+	t.Run("positive - no file reads the environment variables only", func(t *testing.T) {
+		t.Setenv("CONFIG_DIR", t.TempDir())
+		for _, env := range []string{"APP_NAME=go-baseline", "REDIS_HOST=redis", "KAFKA_BROKERS=kafka:9092", "KAFKA_GROUP_ID=go-baseline",
+			"ALPHA_URL=http://alpha:8700", "DB_HOST=postgres", "DB_USER=username", "DB_NAME=placeholder", "AUTH_ENABLED=false"} {
+			key, value, _ := strings.Cut(env, "=")
+			t.Setenv(key, value)
+		}
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "redis", configuration.Redis.Host)
+	})
+
+	t.Run("negative - every problem is reported", func(t *testing.T) {
+		writeConfigFile(t, "test.env", `APP_NAME=go-baseline
+HTTP_PORT=http
+IDEMPOTENCY_TTL=10
+RATE_LIMITS="placeholder_create:20"
+CONSUMER_RETRY_TIERS="placeholder:5s,soon"
+TRACING_EXPORTER=jaeger
+`)
+
+		_, err := Load("test")
+		assert.ElementsMatch(t, []string{
+			"REDIS_HOST", "KAFKA_BROKERS", "KAFKA_GROUP_ID", "ALPHA_URL", "DB_HOST", "DB_USER", "DB_NAME",
+			"HTTP_PORT", "IDEMPOTENCY_TTL", "RATE_LIMITS", "CONSUMER_RETRY_TIERS", "TRACING_EXPORTER", "AUTH_ENABLED",
+		}, problemKeys(err))
+		assert.Contains(t, err.Error(), `HTTP_PORT must be an integer, got "http"`)
+	})
+
+	t.Run("negative - malformed file", func(t *testing.T) {
+		writeConfigFile(t, "test.yaml", "APP_NAME: [go-baseline\n")
+
+		_, err := Load("test")
+		assert.NotNil(t, err)
+		assert.Nil(t, problemKeys(err))
+	})
+}
+
+func TestFindFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CONFIG_DIR", dir)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "test.env"), []byte(requiredKeys), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "test.yaml"), []byte("APP_NAME: go-baseline\n"), 0o600))
+
+	file, found := FindFile("test")
+	assert.True(t, found)
+	assert.Equal(t, filepath.Join(dir, "test.yaml"), file)
+
+	_, found = FindFile("staging")
+	assert.False(t, found)
+}
+
+func TestEnvironment(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	assert.Equal(t, DefaultEnvironment, Environment())
+
+	t.Setenv("ENVIRONMENT", "production")
+	assert.Equal(t, "production", Environment())
+}
@@ -0,0 +1,286 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// keyKind is the type a configuration key is parsed as
+type keyKind int
+
+const (
+	stringKey keyKind = iota
+	intKey
+	boolKey
+	floatKey
+	durationKey
+)
+
+// key describes a configuration key. A key without a value takes its default, a required key must have a value,
+// and check validates the format of the values which are parsed further, like lists of name:value entries.
+// A reloadable key is applied while the app runs when the configuration file changes, see Configuration.reload.
+// A secret key can be mounted as a file, see loadSecrets, and its value is redacted wherever it is shown.
+type key struct {
+	name       string
+	kind       keyKind
+	required   bool
+	reloadable bool
+	secret     bool
+	def        interface{}
+	check      func(value string) error
+}
+
+// keys are every configuration key read by the app and its adapters
+var keys = []key{
+	// COMMON
+	{name: "APP_NAME", required: true},
+	{name: "LOG_LEVEL", reloadable: true, def: "INFO"},
+	{name: "SECRETS_DIR"},
+	{name: "FEATURE_FLAGS", reloadable: true, check: entries(checkBool)},
+
+	// REDIS
+	{name: "REDIS_HOST", required: true},
+	{name: "REDIS_PORT", kind: intKey, def: 6379},
+	{name: "REDIS_INDEX", kind: intKey, def: 0},
+	{name: "REDIS_PASSWORD", secret: true},
+	{name: "REDIS_EXPIRATION", kind: durationKey},
+	{name: "PLACEHOLDER_CACHE_TTL", kind: durationKey, reloadable: true, def: "0s"},
+
+	// KAFKA
+	{name: "KAFKA_BROKERS", required: true},
+	{name: "KAFKA_GROUP_ID", required: true},
+	{name: "PRODUCER_TOPICS", check: entries(nil)},
+	{name: "CONSUMER_TOPICS", check: entries(nil)},
+	{name: "CONSUMER_RESTART_INITIAL_BACKOFF", kind: durationKey, def: "1s"},
+	{name: "CONSUMER_RESTART_MAX_BACKOFF", kind: durationKey, def: "30s"},
+	{name: "CONSUMER_RETRY_TIERS", check: entries(checkDelays)},
+	{name: "CONSUMER_COMMIT_BATCH_SIZE", kind: intKey, def: 100},
+	{name: "CONSUMER_COMMIT_INTERVAL", kind: durationKey, def: "1s"},
+
+	// API
+	{name: "GRPC_PORT", kind: intKey},
+	{name: "HTTP_PORT", kind: intKey, def: 8080},
+	{name: "ADMIN_PORT", kind: intKey, def: 9090},
+	{name: "SHORT_TIMEOUT", kind: intKey, reloadable: true, def: 10},
+	{name: "ROUTE_TIMEOUTS", reloadable: true, check: entries(checkSeconds)},
+
+	// AUTH
+	{name: "AUTH_ENABLED", kind: boolKey, def: true},
+	{name: "JWT_HMAC_SECRET", secret: true},
+	{name: "JWT_PUBLIC_KEY_FILE"},
+	{name: "JWT_JWKS_FILE"},
+	{name: "JWT_AUDIENCE"},
+	{name: "JWT_ISSUER"},
+	{name: "JWT_LEEWAY", kind: intKey, def: 30},
+
+	// RATE LIMIT
+	{name: "RATE_LIMIT_ENABLED", kind: boolKey, def: false},
+	{name: "RATE_LIMIT_BACKEND", def: "memory"},
+	{name: "RATE_LIMIT_KEY_BY", def: "principal,ip"},
+	{name: "RATE_LIMIT_DEFAULT", reloadable: true, def: "100/1m", check: checkRateLimitRule},
+	{name: "RATE_LIMITS", reloadable: true, check: entries(checkRateLimitRule)},
+
+	// IDEMPOTENCY
+	{name: "IDEMPOTENCY_TTL", kind: durationKey, def: "24h"},
+	{name: "IDEMPOTENCY_LOCK_TTL", kind: durationKey, def: "1m"},
+	{name: "IDEMPOTENCY_WAIT", kind: durationKey, def: "5s"},
+
+	// HEALTH
+	{name: "HEALTH_CHECK_TIMEOUT", kind: durationKey, def: "1s"},
+	{name: "HEALTH_CHECK_TIMEOUTS", check: entries(checkDuration)},
+	{name: "HEALTH_NON_CRITICAL"},
+	{name: "HEALTH_SHUTDOWN_DELAY", kind: durationKey, def: "0s"},
+
+	// STARTUP
+	{name: "STARTUP_MAX_WAIT", kind: durationKey, def: "2m"},
+	{name: "STARTUP_INITIAL_BACKOFF", kind: durationKey, def: "500ms"},
+	{name: "STARTUP_MAX_BACKOFF", kind: durationKey, def: "10s"},
+
+	// TRACING
+	{name: "TRACING_EXPORTER", def: "none"},
+	{name: "TRACING_OTLP_ENDPOINT", def: "localhost:4318"},
+	{name: "TRACING_OTLP_INSECURE", kind: boolKey, def: false},
+	{name: "TRACING_SAMPLE_RATE", kind: floatKey, def: 1.0},
+
+	// PROXY
+	{name: "ALPHA_URL", required: true},
+	{name: "HTTP_CLIENT_TIMEOUT", kind: intKey, def: 10},
+
+	// DB
+	{name: "DB_HOST", required: true},
+	{name: "DB_PORT", kind: intKey, def: 5432},
+	{name: "DB_USER", required: true},
+	{name: "DB_PASSWORD", secret: true},
+	{name: "DB_NAME", required: true},
+	{name: "DB_SCHEMA"},
+	{name: "DB_DRIVER", def: "postgres"},
+	{name: "DB_SSL_MODE"},
+	{name: "DB_MIGRATION_USER"},
+	{name: "DB_MIGRATION_PASSWORD", secret: true},
+	{name: "DB_MAX_OPEN_CONNS", kind: intKey, def: 25},
+	{name: "DB_MAX_IDLE_CONNS", kind: intKey, def: 25},
+	{name: "DB_CONN_MAX_LIFETIME", kind: intKey, def: 5 * 60},
+	{name: "DB_CONN_MAX_IDLE_TIME", kind: intKey, def: 1 * 60},
+	{name: "DB_ECHO", kind: boolKey, def: false},
+}
+
+// setDefaults gives every key with a default its default value
+func setDefaults() {
+	for _, k := range keys {
+		if k.def != nil {
+			viper.SetDefault(k.name, k.def)
+		}
+	}
+}
+
+// values returns the value of every key, as read by viper
+func values() map[string]string {
+	values := make(map[string]string, len(keys))
+	for _, k := range keys {
+		values[k.name] = strings.TrimSpace(viper.GetString(k.name))
+	}
+
+	return values
+}
+
+// isReloadable reports whether the key is applied while the app runs
+func isReloadable(name string) bool {
+	k, found := keyOf(name)
+	return found && k.reloadable
+}
+
+// isSecretKey reports whether the value of the key is a secret
+func isSecretKey(name string) bool {
+	k, found := keyOf(name)
+	return found && k.secret
+}
+
+func keyOf(name string) (key, bool) {
+	for _, k := range keys {
+		if k.name == name {
+			return k, true
+		}
+	}
+
+	return key{}, false
+}
+
+// checkKeys reports the required keys without a value and the values which can't be parsed as their kind.
+// Viper reads such values as zero values, so they are checked before the configuration is built.
+func checkKeys(e *ValidationError) {
+	for _, k := range keys {
+		value := strings.TrimSpace(viper.GetString(k.name))
+		if value == "" {
+			if k.required {
+				e.add(k.name, "is required")
+			}
+
+			continue
+		}
+
+		if err := k.parse(value); err != nil {
+			e.add(k.name, err.Error())
+		}
+	}
+}
+
+func (k key) parse(value string) error {
+	switch k.kind {
+	case intKey:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
+	case boolKey:
+		if err := checkBool(value); err != nil {
+			return err
+		}
+	case floatKey:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+	case durationKey:
+		if err := checkDuration(value); err != nil {
+			return err
+		}
+	}
+
+	if k.check != nil {
+		return k.check(value)
+	}
+
+	return nil
+}
+
+// entries checks the lists written as {name}:{value} entries separated by semicolons, like kafka:2s;alpha:2s.
+// checkValue checks the value of each entry, when it is given.
+func entries(checkValue func(value string) error) func(string) error {
+	return func(list string) error {
+		for _, entry := range strings.Split(list, ";") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+
+			name, value, found := strings.Cut(entry, ":")
+			if !found || strings.TrimSpace(name) == "" || strings.Contains(value, ":") {
+				return fmt.Errorf("entry %q must be written as name:value", entry)
+			}
+
+			if checkValue == nil {
+				continue
+			}
+
+			if err := checkValue(strings.TrimSpace(value)); err != nil {
+				return fmt.Errorf("entry %q %s", entry, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkSeconds(value string) error {
+	if seconds, err := strconv.Atoi(value); err != nil || seconds <= 0 {
+		return fmt.Errorf("must be a positive number of seconds, got %q", value)
+	}
+
+	return nil
+}
+
+func checkBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("must be true or false, got %q", value)
+	}
+
+	return nil
+}
+
+func checkDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("must be a duration like 1m30s, got %q", value)
+	}
+
+	return nil
+}
+
+// checkDelays checks the lists of positive durations separated by commas, like 5s,1m
+func checkDelays(value string) error {
+	for _, delay := range strings.Split(value, ",") {
+		if d, err := time.ParseDuration(strings.TrimSpace(delay)); err != nil || d <= 0 {
+			return fmt.Errorf("must list positive durations like 5s,1m, got %q", value)
+		}
+	}
+
+	return nil
+}
+
+func checkRateLimitRule(value string) error {
+	if _, ok := parseRateLimitRule(value); !ok {
+		return fmt.Errorf("must be written as {requests}/{window} like 100/1m, got %q", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+type (
+	// Live holds the running configuration. Reload swaps in the reloadable settings of a new configuration at once,
+	// readers get either the previous or the new configuration, never a mix of both.
+	Live struct {
+		current atomic.Pointer[Configuration]
+
+		// mu serializes the reloads and the subscriptions
+		mu          sync.Mutex
+		subscribers []func(previous, current *Configuration)
+	}
+
+	// Change is the change of the value of a configuration key
+	Change struct {
+		Key  string
+		From string
+		To   string
+	}
+
+	// Changes are the changes of a reload, ordered by key
+	Changes []Change
+)
+
+// NewLive holds c as the running configuration
+func NewLive(c *Configuration) *Live {
+	live := &Live{}
+	live.current.Store(c)
+
+	return live
+}
+
+// Load returns the running configuration. It must not be modified, a reload replaces it instead.
+func (l *Live) Load() *Configuration {
+	return l.current.Load()
+}
+
+// Subscribe calls fn after every reload applying a change, with the previous and the new running configuration
+func (l *Live) Subscribe(fn func(previous, current *Configuration)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.subscribers = append(l.subscribers, fn)
+}
+
+// Reload applies the changes of the reloadable keys of next and notifies the subscribers. The changes of the other
+// keys are rejected, they need a restart, and nothing is applied when the new running configuration is invalid.
+func (l *Live) Reload(next *Configuration) (applied, rejected Changes, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	previous := l.current.Load()
+	for _, change := range Diff(previous, next) {
+		if isReloadable(change.Key) {
+			applied = append(applied, change)
+		} else {
+			rejected = append(rejected, change)
+		}
+	}
+
+	if len(applied) == 0 {
+		return nil, rejected, nil
+	}
+
+	reloaded := previous.reload(next)
+	if err = reloaded.Validate(); err != nil {
+		return nil, rejected, err
+	}
+
+	l.current.Store(reloaded)
+	for _, subscriber := range l.subscribers {
+		subscriber(previous, reloaded)
+	}
+
+	return applied, rejected, nil
+}
+
+// Watch reloads the configuration whenever the configuration file changes, then calls onReload with the outcome.
+// A configuration file failing the validation is not applied. Watch returns false when no file was loaded.
+func (l *Live) Watch(onReload func(applied, rejected Changes, err error)) bool {
+	if viper.ConfigFileUsed() == "" {
+		return false
+	}
+
+	viper.OnConfigChange(func(fsnotify.Event) {
+		next, err := build(l.Load().Environment)
+		if err != nil {
+			onReload(nil, nil, err)
+			return
+		}
+
+		onReload(l.Reload(next))
+	})
+	viper.WatchConfig()
+
+	return true
+}
+
+// reload returns a copy of c with the reloadable settings of next. Keep it in line with the reloadable keys.
+func (c *Configuration) reload(next *Configuration) *Configuration {
+	reloaded := *c
+	reloaded.LogLevel = next.LogLevel
+	reloaded.Features = next.Features
+	reloaded.Cache = next.Cache
+
+	constants := *c.Const
+	constants.ShortTimeout = next.Const.ShortTimeout
+	constants.RouteTimeouts = next.Const.RouteTimeouts
+	reloaded.Const = &constants
+
+	rateLimit := *c.RateLimit
+	rateLimit.Default = next.RateLimit.Default
+	rateLimit.Routes = next.RateLimit.Routes
+	reloaded.RateLimit = &rateLimit
+
+	reloaded.values = make(map[string]string, len(c.values))
+	for name, value := range c.values {
+		if isReloadable(name) {
+			value = next.values[name]
+		}
+
+		reloaded.values[name] = value
+	}
+
+	return &reloaded
+}
+
+// Diff returns the keys whose value differs between the configurations, with the values of the secrets redacted
+func Diff(previous, next *Configuration) Changes {
+	var changes Changes
+	for name, value := range next.values {
+		if from := previous.values[name]; from != value {
+			changes = append(changes, Change{Key: name, From: redactValue(name, from), To: redactValue(name, value)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+func redactValue(name, value string) string {
+	if value != "" && isSecretKey(name) {
+		return Redacted
+	}
+
+	return value
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Key, c.From, c.To)
+}
+
+func (c Changes) String() string {
+	changes := make([]string, 0, len(c))
+	for _, change := range c {
+		changes = append(changes, change.String())
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+// Keys returns the changed keys
+func (c Changes) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, change := range c {
+		keys = append(keys, change.Key)
+	}
+
+	return keys
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestConfig(t *testing.T, content string) *Configuration {
+	writeConfigFile(t, "test.env", content)
+
+	configuration, err := Load("test")
+	assert.Nil(t, err)
+
+	return configuration
+}
+
+func TestLive_Reload(t *testing.T) {
+	var (
+		running = loadTestConfig(t, requiredKeys+"SHORT_TIMEOUT=10\nLOG_LEVEL=INFO\n")
+		live    = NewLive(running)
+
+		notified []*Configuration
+	)
+
+	live.Subscribe(func(previous, current *Configuration) {
+		notified = append(notified, previous, current)
+	})
+
+	t.Run("positive - reloadable changes are applied, others are rejected", func(t *testing.T) {
+		next := loadTestConfig(t, requiredKeys+"SHORT_TIMEOUT=3\nLOG_LEVEL=DEBUG\nHTTP_PORT=8081\nFEATURE_FLAGS=\"bulk_import:true\"\nREDIS_PASSWORD=changed\n")
+
+		applied, rejected, err := live.Reload(next)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"FEATURE_FLAGS", "LOG_LEVEL", "SHORT_TIMEOUT"}, applied.Keys())
+		assert.Equal(t, []string{"HTTP_PORT", "REDIS_PASSWORD"}, rejected.Keys())
+		assert.Equal(t, `REDIS_PASSWORD: "" -> "***"`, rejected[1].String())
+
+		current := live.Load()
+		assert.Equal(t, 3*time.Second, current.Const.RouteTimeout("placeholder_get"))
+		assert.Equal(t, "DEBUG", current.LogLevel)
+		assert.True(t, current.FeatureEnabled("bulk_import"))
+		assert.Equal(t, 8080, current.Const.HTTPPort)
+		assert.Equal(t, Secret(""), current.Passwords.Redis)
+
+		assert.Equal(t, []*Configuration{running, current}, notified)
+		assert.Equal(t, 10, running.Const.ShortTimeout, "the previous configuration is left untouched")
+	})
+
+	t.Run("positive - nothing to apply", func(t *testing.T) {
+		notified = nil
+		next := loadTestConfig(t, requiredKeys+"SHORT_TIMEOUT=3\nLOG_LEVEL=DEBUG\nFEATURE_FLAGS=\"bulk_import:true\"\nHTTP_PORT=8082\n")
+
+		applied, rejected, err := live.Reload(next)
+		assert.Nil(t, err)
+		assert.Empty(t, applied)
+		assert.Equal(t, []string{"HTTP_PORT"}, rejected.Keys())
+		assert.Empty(t, notified)
+	})
+}
+
+func TestLive_Watch(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CONFIG_DIR", dir)
+
+	var (
+		file    = filepath.Join(dir, "test.env")
+		reloads = make(chan error, 10)
+	)
+
+	assert.Nil(t, os.WriteFile(file, []byte(requiredKeys+"SHORT_TIMEOUT=10\n"), 0o600))
+
+	configuration, err := Load("test")
+	assert.Nil(t, err)
+
+	live := NewLive(configuration)
+	assert.True(t, live.Watch(func(applied, rejected Changes, err error) {
+		reloads <- err
+	}))
+
+	t.Run("negative - invalid file is not applied", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(file, []byte(requiredKeys+"SHORT_TIMEOUT=soon\n"), 0o600))
+
+		assert.IsType(t, &ValidationError{}, waitReload(t, reloads))
+		assert.Equal(t, 10, live.Load().Const.ShortTimeout)
+	})
+
+	t.Run("positive - changed file is applied", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(file, []byte(requiredKeys+"SHORT_TIMEOUT=3\n"), 0o600))
+
+		assert.Eventually(t, func() bool {
+			return live.Load().Const.ShortTimeout == 3
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func waitReload(t *testing.T, reloads chan error) error {
+	for {
+		select {
+		case err := <-reloads:
+			if err != nil {
+				return err
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+			return nil
+		}
+	}
+}
+
+func TestLive_Watch_NoFile(t *testing.T) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+
+	// Nothing is read, the configuration is invalid
+	_, err := Load("test")
+	assert.NotNil(t, err)
+	assert.False(t, NewLive(&Configuration{}).Watch(func(Changes, Changes, error) {}))
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the value of a secret in the redacted configuration
+const Redacted = "***"
+
+var (
+	// secretFields are the words which mark a field name as holding a secret, for the fields of the adapters'
+	// configurations which can't be a Secret
+	secretFields = []string{"password", "secret", "token", "privatekey", "credential"}
+
+	secretType = reflect.TypeOf(Secret(""))
+)
+
+// Redact returns the configuration as nested maps, ready to be encoded, with the value of every secret
+// replaced by Redacted. Durations are written as strings, like 1m30s, and functions are left out.
+func (c *Configuration) Redact() map[string]interface{} {
+	redacted, _ := redact(reflect.ValueOf(c)).(map[string]interface{})
+	return redacted
+}
+
+// String returns the redacted configuration, so printing the configuration, with %v for instance, shows no secret
+func (c Configuration) String() string {
+	encoded, err := json.Marshal(c.Redact())
+	if err != nil {
+		return fmt.Sprintf("configuration can't be printed: %s", err)
+	}
+
+	return string(encoded)
+}
+
+func redact(v reflect.Value) interface{} {
+	if !isEncodable(v) {
+		return nil
+	}
+
+	switch v.Type() {
+	case reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String()
+	case secretType:
+		return Secret(v.String()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return redact(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || !isEncodable(v.Field(i)) {
+				continue
+			}
+
+			if isSecret(field.Name) && !v.Field(i).IsZero() {
+				fields[field.Name] = Redacted
+				continue
+			}
+
+			fields[field.Name] = redact(v.Field(i))
+		}
+
+		return fields
+	case reflect.Map:
+		entries := make(map[string]interface{}, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			entries[fmt.Sprint(iter.Key().Interface())] = redact(iter.Value())
+		}
+
+		return entries
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, redact(v.Index(i)))
+		}
+
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// isEncodable leaves out the fields which can't be written as JSON
+func isEncodable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return false
+	default:
+		return true
+	}
+}
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretFields {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/db"
+	"github.com/dityuiri/go-adapter/redis"
+)
+
+func TestConfiguration_Redact(t *testing.T) {
+	var (
+		configuration = &Configuration{
+			AppName: "go-baseline",
+			Const: &Constants{
+				HTTPPort:      8080,
+				RouteTimeouts: map[string]int{"placeholder_get": 3},
+			},
+			Redis: &redis.Config{Host: "localhost", Password: "redis-password"},
+			Database: &db.Configuration{
+				User:      "postgres",
+				Password:  "db-password",
+				Migration: &db.MigrationConfiguration{User: "migration", Password: "migration-password"},
+			},
+			Passwords: &Passwords{Database: "db-password", Redis: "redis-password"},
+			Auth:      &Auth{HMACSecret: "hmac-secret", Audience: "go-baseline"},
+			Startup:   &Startup{MaxWait: 2 * time.Minute},
+			Health:    &Health{NonCritical: []string{"alpha"}},
+		}
+
+		redacted = configuration.Redact()
+	)
+
+	t.Run("positive - secrets are redacted", func(t *testing.T) {
+		assert.Equal(t, Redacted, redacted["Redis"].(map[string]interface{})["Password"])
+		assert.Equal(t, Redacted, redacted["Database"].(map[string]interface{})["Password"])
+		assert.Equal(t, Redacted, redacted["Database"].(map[string]interface{})["Migration"].(map[string]interface{})["Password"])
+		assert.Equal(t, Redacted, redacted["Auth"].(map[string]interface{})["HMACSecret"])
+		assert.Equal(t, Redacted, redacted["Passwords"])
+
+		encoded, err := json.Marshal(redacted)
+		assert.Nil(t, err)
+		assert.NotContains(t, string(encoded), "password\"")
+		assert.NotContains(t, string(encoded), "hmac-secret")
+	})
+
+	t.Run("positive - other values are kept", func(t *testing.T) {
+		assert.Equal(t, "go-baseline", redacted["AppName"])
+		assert.Equal(t, 8080, redacted["Const"].(map[string]interface{})["HTTPPort"])
+		assert.Equal(t, map[string]interface{}{"placeholder_get": 3}, redacted["Const"].(map[string]interface{})["RouteTimeouts"])
+		assert.Equal(t, "postgres", redacted["Database"].(map[string]interface{})["User"])
+		assert.Equal(t, "2m0s", redacted["Startup"].(map[string]interface{})["MaxWait"])
+		assert.Equal(t, []interface{}{"alpha"}, redacted["Health"].(map[string]interface{})["NonCritical"])
+		assert.Nil(t, redacted["Kafka"])
+	})
+
+	t.Run("positive - empty secret is shown as empty", func(t *testing.T) {
+		emptySecret := (&Configuration{Auth: &Auth{}}).Redact()
+		assert.Equal(t, "", emptySecret["Auth"].(map[string]interface{})["HMACSecret"])
+	})
+}
+
+func TestConfiguration_String(t *testing.T) {
+	configuration := &Configuration{
+		AppName: "go-baseline",
+		Redis:   &redis.Config{Password: "redis-password"},
+		Auth:    &Auth{HMACSecret: "hmac-secret"},
+	}
+
+	for _, printed := range []string{fmt.Sprint(configuration), fmt.Sprintf("%+v", *configuration)} {
+		assert.Contains(t, printed, `"AppName":"go-baseline"`)
+		assert.NotContains(t, printed, "redis-password")
+		assert.NotContains(t, printed, "hmac-secret")
+	}
+}
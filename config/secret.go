@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// fileSuffix names the key giving the file of a secret, like DB_PASSWORD_FILE for DB_PASSWORD
+const fileSuffix = "_FILE"
+
+// Secret is the value of a secret setting. It prints as Redacted, so it is never logged, dumped or written in
+// an error by mistake. Value returns the secret itself, to be used where it is needed only.
+type Secret string
+
+// Value returns the secret
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String returns Redacted, or an empty string when the secret is not set
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return Redacted
+}
+
+// GoString keeps the secret out of the %#v output
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+// MarshalJSON keeps the secret out of the JSON encoding
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// loadSecrets sets every secret key mounted as a file: the file given by {KEY}_FILE, or else the file named after
+// the key, like DB_PASSWORD or db_password, in SECRETS_DIR. A mounted secret takes precedence over the other layers.
+// Files which can't be read are reported in e, a missing file in SECRETS_DIR is not a problem.
+func loadSecrets(e *ValidationError) {
+	dir := strings.TrimSpace(viper.GetString("SECRETS_DIR"))
+
+	for _, k := range keys {
+		if !k.secret {
+			continue
+		}
+
+		if file := strings.TrimSpace(viper.GetString(k.name + fileSuffix)); file != "" {
+			value, err := readSecret(file)
+			if err != nil {
+				e.add(k.name+fileSuffix, "can't be read: %s", err)
+				continue
+			}
+
+			viper.Set(k.name, value)
+			continue
+		}
+
+		if dir == "" {
+			continue
+		}
+
+		for _, name := range []string{k.name, strings.ToLower(k.name)} {
+			value, err := readSecret(filepath.Join(dir, name))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			if err != nil {
+				e.add("SECRETS_DIR", "can't be read: %s", err)
+				break
+			}
+
+			viper.Set(k.name, value)
+			break
+		}
+	}
+}
+
+// readSecret reads a secret file, without the line break editors leave at the end
+func readSecret(file string) (string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
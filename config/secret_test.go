@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecret(t *testing.T) {
+	var (
+		secret = Secret("hmac-secret")
+		auth   = Auth{Enabled: true, HMACSecret: secret}
+	)
+
+	t.Run("positive - secret is never printed", func(t *testing.T) {
+		for _, format := range []string{"%v", "%+v", "%s", "%#v"} {
+			assert.NotContains(t, fmt.Sprintf(format, auth), "hmac-secret", format)
+			assert.NotContains(t, fmt.Sprintf(format, &auth), "hmac-secret", format)
+		}
+
+		assert.Equal(t, "***", fmt.Sprint(secret))
+	})
+
+	t.Run("positive - secret is never encoded", func(t *testing.T) {
+		encoded, err := json.Marshal(auth)
+		assert.Nil(t, err)
+		assert.Contains(t, string(encoded), `"HMACSecret":"***"`)
+	})
+
+	t.Run("positive - value is the secret itself", func(t *testing.T) {
+		assert.Equal(t, "hmac-secret", secret.Value())
+		assert.Equal(t, []byte("hmac-secret"), []byte(secret))
+	})
+
+	t.Run("positive - empty secret prints empty", func(t *testing.T) {
+		assert.Equal(t, "", Secret("").String())
+	})
+}
+
+func TestLoad_Secrets(t *testing.T) {
+	writeSecret := func(t *testing.T, dir, name, value string) string {
+		file := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(file, []byte(value), 0o600))
+
+		return file
+	}
+
+	t.Run("positive - secret from its file", func(t *testing.T) {
+		writeConfigFile(t, "test.env", requiredKeys)
+		t.Setenv("DB_PASSWORD_FILE", writeSecret(t, t.TempDir(), "db", "db-password\n"))
+		t.Setenv("DB_PASSWORD", "plain-password")
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "db-password", configuration.Passwords.Database.Value())
+		assert.Equal(t, "db-password", configuration.DatabaseConfig().Password)
+	})
+
+	t.Run("positive - secrets from the directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, "test.env", requiredKeys+"SECRETS_DIR="+dir+"\n")
+		writeSecret(t, dir, "REDIS_PASSWORD", "redis-password")
+		writeSecret(t, dir, "jwt_hmac_secret", "hmac-secret")
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "redis-password", configuration.RedisConfig().Password)
+		assert.Equal(t, "hmac-secret", configuration.Auth.HMACSecret.Value())
+		assert.Equal(t, "", configuration.DatabaseConfig().Password)
+
+		assert.NotContains(t, fmt.Sprint(configuration), "redis-password")
+		assert.NotContains(t, fmt.Sprintf("%+v", *configuration), "hmac-secret")
+	})
+
+	t.Run("positive - adapter configurations hold no password", func(t *testing.T) {
+		writeConfigFile(t, "test.env", requiredKeys+"DB_PASSWORD=db-password\nDB_MIGRATION_PASSWORD=migration-password\nREDIS_PASSWORD=redis-password\n")
+
+		configuration, err := Load("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "migration-password", configuration.DatabaseConfig().Migration.Password)
+
+		for _, format := range []string{"%v", "%+v", "%#v"} {
+			printed := fmt.Sprintf(format, configuration.Database) + fmt.Sprintf(format, *configuration.Database) +
+				fmt.Sprintf(format, *configuration.Database.Migration) + fmt.Sprintf(format, *configuration.Redis) +
+				fmt.Sprintf(format, *configuration.Passwords)
+
+			for _, password := range []string{"db-password", "migration-password", "redis-password"} {
+				assert.NotContains(t, printed, password, format)
+			}
+		}
+	})
+
+	t.Run("negative - secret file can't be read", func(t *testing.T) {
+		writeConfigFile(t, "test.env", requiredKeys)
+		t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load("test")
+		assert.Equal(t, []string{"DB_PASSWORD_FILE"}, problemKeys(err))
+	})
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// logLevels are the levels accepted by LOG_LEVEL
+	logLevels = []string{"DEBUG", "INFO", "WARN", "ERROR", "PANIC"}
+
+	rateLimitBackends = []string{"memory", "redis"}
+	rateLimitKeys     = []string{"principal", "ip"}
+	tracingExporters  = []string{"none", "stdout", "otlp"}
+)
+
+type (
+	// ValidationError reports every invalid or missing key of the configuration at once
+	ValidationError struct {
+		Problems []Problem
+	}
+
+	// Problem is what is wrong with the value of a configuration key
+	Problem struct {
+		Key     string
+		Message string
+	}
+)
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.String())
+	}
+
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(problems, "; "))
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s %s", p.Key, p.Message)
+}
+
+// add reports a problem of the key, unless the key is already reported
+func (e *ValidationError) add(key string, format string, args ...interface{}) {
+	for _, problem := range e.Problems {
+		if problem.Key == key {
+			return
+		}
+	}
+
+	e.Problems = append(e.Problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns e when it has a problem, nil otherwise
+func (e *ValidationError) err() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Validate reports, as a *ValidationError, every value of the configuration the app can't run with
+func (c *Configuration) Validate() error {
+	e := &ValidationError{}
+	c.validate(e)
+
+	return e.err()
+}
+
+func (c *Configuration) validate(e *ValidationError) {
+	if c.AppName == "" {
+		e.add("APP_NAME", "is required")
+	}
+
+	if !oneOf(strings.ToUpper(strings.TrimSpace(c.LogLevel)), logLevels) {
+		e.add("LOG_LEVEL", "must be one of %s, got %q", strings.Join(logLevels, ", "), c.LogLevel)
+	}
+
+	if c.Const != nil {
+		checkPort(e, "HTTP_PORT", c.Const.HTTPPort)
+		checkPort(e, "ADMIN_PORT", c.Const.AdminPort)
+
+		if c.Const.GRPCPort != 0 {
+			checkPort(e, "GRPC_PORT", c.Const.GRPCPort)
+		}
+
+		if c.Const.HTTPPort == c.Const.AdminPort {
+			e.add("ADMIN_PORT", "must differ from HTTP_PORT")
+		}
+
+		if c.Const.ShortTimeout <= 0 {
+			e.add("SHORT_TIMEOUT", "must be a positive number of seconds")
+		}
+	}
+
+	if c.Kafka != nil {
+		if c.Kafka.Consumer != nil {
+			for _, broker := range c.Kafka.Consumer.Brokers {
+				if strings.TrimSpace(broker) == "" {
+					e.add("KAFKA_BROKERS", "must not have an empty broker")
+				}
+			}
+		}
+
+		if c.Kafka.RestartInitialBackoff <= 0 {
+			e.add("CONSUMER_RESTART_INITIAL_BACKOFF", "must be positive")
+		}
+
+		if c.Kafka.RestartMaxBackoff < c.Kafka.RestartInitialBackoff {
+			e.add("CONSUMER_RESTART_MAX_BACKOFF", "must not be less than CONSUMER_RESTART_INITIAL_BACKOFF")
+		}
+
+		if c.Kafka.CommitBatchSize < 1 {
+			e.add("CONSUMER_COMMIT_BATCH_SIZE", "must be at least 1")
+		}
+
+		if c.Kafka.CommitInterval <= 0 {
+			e.add("CONSUMER_COMMIT_INTERVAL", "must be positive")
+		}
+
+		names := make([]string, 0, len(c.Kafka.RetryTiers))
+		for name := range c.Kafka.RetryTiers {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			if _, found := c.Kafka.ConsumerTopics[name]; !found {
+				e.add("CONSUMER_RETRY_TIERS", "must only have topics of CONSUMER_TOPICS, got %q", name)
+			}
+
+			for _, delay := range c.Kafka.RetryTiers[name] {
+				if delay <= 0 {
+					e.add("CONSUMER_RETRY_TIERS", "must have positive delays, got %s for %q", delay, name)
+				}
+			}
+		}
+	}
+
+	if c.Redis != nil {
+		checkPort(e, "REDIS_PORT", c.Redis.Port)
+	}
+
+	if c.Database != nil {
+		checkPort(e, "DB_PORT", c.Database.Port)
+	}
+
+	if c.HTTPClient != nil && c.HTTPClient.ProxyURLs.AlphaURL == "" {
+		e.add("ALPHA_URL", "is required")
+	}
+
+	if c.Auth != nil && c.Auth.Enabled && c.Auth.HMACSecret == "" && c.Auth.PublicKeyFile == "" && c.Auth.JWKSFile == "" {
+		e.add("AUTH_ENABLED", "requires JWT_HMAC_SECRET, JWT_PUBLIC_KEY_FILE or JWT_JWKS_FILE")
+	}
+
+	if c.RateLimit != nil && c.RateLimit.Enabled {
+		if !oneOf(c.RateLimit.Backend, rateLimitBackends) {
+			e.add("RATE_LIMIT_BACKEND", "must be one of %s, got %q", strings.Join(rateLimitBackends, ", "), c.RateLimit.Backend)
+		}
+
+		for _, keyBy := range c.RateLimit.KeyBy {
+			if !oneOf(keyBy, rateLimitKeys) {
+				e.add("RATE_LIMIT_KEY_BY", "must list %s, got %q", strings.Join(rateLimitKeys, ", "), keyBy)
+			}
+		}
+	}
+
+	if c.Idempotency != nil {
+		if c.Idempotency.TTL <= 0 {
+			e.add("IDEMPOTENCY_TTL", "must be positive")
+		}
+
+		if c.Idempotency.LockTTL <= 0 {
+			e.add("IDEMPOTENCY_LOCK_TTL", "must be positive")
+		}
+	}
+
+	if c.Cache != nil && c.Cache.PlaceholderTTL < 0 {
+		e.add("PLACEHOLDER_CACHE_TTL", "must not be negative")
+	}
+
+	if c.Health != nil && c.Health.Timeout <= 0 {
+		e.add("HEALTH_CHECK_TIMEOUT", "must be positive")
+	}
+
+	if c.Startup != nil {
+		if c.Startup.InitialBackoff <= 0 {
+			e.add("STARTUP_INITIAL_BACKOFF", "must be positive")
+		}
+
+		if c.Startup.MaxBackoff < c.Startup.InitialBackoff {
+			e.add("STARTUP_MAX_BACKOFF", "must not be less than STARTUP_INITIAL_BACKOFF")
+		}
+
+		if c.Startup.MaxWait < 0 {
+			e.add("STARTUP_MAX_WAIT", "must not be negative")
+		}
+	}
+
+	if c.Tracing != nil {
+		if !oneOf(c.Tracing.Exporter, tracingExporters) {
+			e.add("TRACING_EXPORTER", "must be one of %s, got %q", strings.Join(tracingExporters, ", "), c.Tracing.Exporter)
+		}
+
+		if c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+			e.add("TRACING_OTLP_ENDPOINT", "is required by the otlp exporter")
+		}
+
+		if c.Tracing.SampleRate < 0 || c.Tracing.SampleRate > 1 {
+			e.add("TRACING_SAMPLE_RATE", "must be between 0 and 1, got %v", c.Tracing.SampleRate)
+		}
+	}
+}
+
+func checkPort(e *ValidationError, key string, port int) {
+	if port < 1 || port > 65535 {
+		e.add(key, "must be a port between 1 and 65535, got %d", port)
+	}
+}
+
+func oneOf(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
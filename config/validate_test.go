@@ -0,0 +1,130 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/db"
+	"github.com/dityuiri/go-adapter/redis"
+)
+
+func TestConfiguration_Validate(t *testing.T) {
+	valid := func() *Configuration {
+		return &Configuration{
+			AppName:  "go-baseline",
+			LogLevel: "info",
+			Const:    &Constants{HTTPPort: 8080, AdminPort: 9090, ShortTimeout: 10},
+			Kafka: &Kafka{
+				ConsumerTopics:        map[string]string{"placeholder": "placeholder-record"},
+				RetryTiers:            map[string][]time.Duration{"placeholder": {5 * time.Second, time.Minute}},
+				RestartInitialBackoff: time.Second,
+				RestartMaxBackoff:     30 * time.Second,
+				CommitBatchSize:       100,
+				CommitInterval:        time.Second,
+			},
+			Redis:       &redis.Config{Host: "localhost", Port: 6379},
+			Database:    &db.Configuration{Host: "localhost", Port: 5432},
+			HTTPClient:  &HttpClient{ProxyURLs: ProxyURLs{AlphaURL: "http://localhost:8700"}},
+			Auth:        &Auth{Enabled: true, HMACSecret: "secret"},
+			RateLimit:   &RateLimit{Enabled: true, Backend: "redis", KeyBy: []string{"principal", "ip"}},
+			Idempotency: &Idempotency{TTL: time.Hour, LockTTL: time.Minute},
+			Health:      &Health{Timeout: time.Second},
+			Startup:     &Startup{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second},
+			Tracing:     &Tracing{Exporter: "otlp", Endpoint: "localhost:4318", SampleRate: 0.1},
+		}
+	}
+
+	t.Run("positive - valid configuration", func(t *testing.T) {
+		assert.Nil(t, valid().Validate())
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *Configuration)
+		keys   []string
+	}{
+		{
+			name:   "negative - unknown log level",
+			modify: func(c *Configuration) { c.LogLevel = "verbose" },
+			keys:   []string{"LOG_LEVEL"},
+		},
+		{
+			name:   "negative - ports out of range or shared",
+			modify: func(c *Configuration) { c.Const.AdminPort = 8080; c.Redis.Port = 70000; c.Database.Port = 0 },
+			keys:   []string{"ADMIN_PORT", "REDIS_PORT", "DB_PORT"},
+		},
+		{
+			name:   "negative - authentication without a key",
+			modify: func(c *Configuration) { c.Auth.HMACSecret = "" },
+			keys:   []string{"AUTH_ENABLED"},
+		},
+		{
+			name:   "negative - unknown rate limit backend and key",
+			modify: func(c *Configuration) { c.RateLimit.Backend = "memcached"; c.RateLimit.KeyBy = []string{"cookie"} },
+			keys:   []string{"RATE_LIMIT_BACKEND", "RATE_LIMIT_KEY_BY"},
+		},
+		{
+			name:   "negative - backoff smaller than the initial one",
+			modify: func(c *Configuration) { c.Startup.MaxBackoff = time.Millisecond },
+			keys:   []string{"STARTUP_MAX_BACKOFF"},
+		},
+		{
+			name:   "negative - consumer restart backoff not positive",
+			modify: func(c *Configuration) { c.Kafka.RestartInitialBackoff = 0; c.Kafka.RestartMaxBackoff = -time.Second },
+			keys:   []string{"CONSUMER_RESTART_INITIAL_BACKOFF", "CONSUMER_RESTART_MAX_BACKOFF"},
+		},
+		{
+			name:   "negative - commit batch empty and commit interval not positive",
+			modify: func(c *Configuration) { c.Kafka.CommitBatchSize = 0; c.Kafka.CommitInterval = 0 },
+			keys:   []string{"CONSUMER_COMMIT_BATCH_SIZE", "CONSUMER_COMMIT_INTERVAL"},
+		},
+		{
+			name:   "negative - retry tiers of a topic not consumed",
+			modify: func(c *Configuration) { c.Kafka.RetryTiers["audit"] = []time.Duration{time.Second} },
+			keys:   []string{"CONSUMER_RETRY_TIERS"},
+		},
+		{
+			name:   "negative - retry tier without a delay",
+			modify: func(c *Configuration) { c.Kafka.RetryTiers["placeholder"] = []time.Duration{0} },
+			keys:   []string{"CONSUMER_RETRY_TIERS"},
+		},
+		{
+			name:   "negative - otlp exporter without endpoint and sample rate above 1",
+			modify: func(c *Configuration) { c.Tracing.Endpoint = ""; c.Tracing.SampleRate = 2 },
+			keys:   []string{"TRACING_OTLP_ENDPOINT", "TRACING_SAMPLE_RATE"},
+		},
+		{
+			name:   "negative - missing alpha url and app name",
+			modify: func(c *Configuration) { c.HTTPClient.ProxyURLs.AlphaURL = ""; c.AppName = "" },
+			keys:   []string{"APP_NAME", "ALPHA_URL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := valid()
+			tt.modify(configuration)
+
+			assert.ElementsMatch(t, tt.keys, problemKeys(configuration.Validate()))
+		})
+	}
+
+	t.Run("positive - disabled features are not validated", func(t *testing.T) {
+		configuration := valid()
+		configuration.Auth = &Auth{}
+		configuration.RateLimit = &RateLimit{Backend: "memcached"}
+
+		assert.Nil(t, configuration.Validate())
+	})
+}
+
+func TestValidationError_Error(t *testing.T) {
+	e := &ValidationError{}
+	e.add("HTTP_PORT", "must be an integer, got %q", "http")
+	e.add("HTTP_PORT", "must be a port between 1 and 65535, got %d", 0)
+	e.add("ALPHA_URL", "is required")
+
+	assert.Equal(t, `invalid configuration: HTTP_PORT must be an integer, got "http"; ALPHA_URL is required`, e.Error())
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+func configCommand() *command {
+	return &command{
+		name:  "config",
+		short: "Validate or print the configuration",
+		help: "The configuration is loaded as the app would load it, without connecting to anything.\n" +
+			"The environment variables still take precedence over the files, so CI should run it in a clean environment.",
+		subcommands: []*command{
+			{
+				name:  "validate",
+				args:  "[environment...]",
+				short: "Validate the configuration of each environment, the ENVIRONMENT by default",
+				help:  "Exits 0 when every configuration is valid, 1 otherwise.",
+				setup: func(*flag.FlagSet) runFunc {
+					return func(_ context.Context, s streams, args []string) int {
+						return validateConfig(s.out, args)
+					}
+				},
+			},
+			{
+				name:  "print",
+				args:  "[environment]",
+				short: "Print the configuration of the environment, the ENVIRONMENT by default, with the secrets redacted",
+				help:  "Exits 0 when the configuration is valid, 1 otherwise.",
+				setup: func(*flag.FlagSet) runFunc {
+					return func(_ context.Context, s streams, args []string) int {
+						if len(args) > 1 {
+							_, _ = fmt.Fprintln(s.err, "usage: config print [environment]")
+							return exitUsage
+						}
+
+						return printConfig(s, args)
+					}
+				},
+			},
+		},
+	}
+}
+
+// validateConfig validates the configuration of each environment and returns the exit code
+func validateConfig(out io.Writer, environments []string) int {
+	if len(environments) == 0 {
+		environments = []string{config.Environment()}
+	}
+
+	code := exitOK
+	for _, environment := range environments {
+		file, found := config.FindFile(environment)
+		if !found {
+			_, _ = fmt.Fprintf(out, "%s: no configuration file\n", environment)
+			code = exitFailure
+			continue
+		}
+
+		if _, err := config.Load(environment); err != nil {
+			_, _ = fmt.Fprintf(out, "%s: %s is invalid\n", environment, file)
+			printProblems(out, err)
+			code = exitFailure
+			continue
+		}
+
+		_, _ = fmt.Fprintf(out, "%s: %s is valid\n", environment, file)
+	}
+
+	return code
+}
+
+// printConfig prints the redacted configuration of the environment as JSON and returns the exit code
+func printConfig(s streams, args []string) int {
+	environment := config.Environment()
+	if len(args) > 0 {
+		environment = args[0]
+	}
+
+	configuration, err := config.Load(environment)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "%s: the configuration is invalid\n", environment)
+		printProblems(s.err, err)
+
+		return exitFailure
+	}
+
+	encoder := json.NewEncoder(s.out)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(configuration.Redact()); err != nil {
+		_, _ = fmt.Fprintf(s.err, "failed to encode the configuration: %s\n", err)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// printProblems writes every problem of a validation error on its own line
+func printProblems(out io.Writer, err error) {
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		_, _ = fmt.Fprintf(out, "  %s\n", err)
+		return
+	}
+
+	for _, problem := range validationErr.Problems {
+		_, _ = fmt.Fprintf(out, "  %s\n", problem)
+	}
+}
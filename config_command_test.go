@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigCommand(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		// secretsDir stands for the local secret files, which are not committed
+		secretsDir = func(t *testing.T) string {
+			dir := t.TempDir()
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, "JWT_HMAC_SECRET"), []byte("local-secret\n"), 0o600))
+
+			return dir
+		}
+	)
+
+	t.Run("positive - environment files of the repository are valid", func(t *testing.T) {
+		var out bytes.Buffer
+
+		t.Setenv("CONFIG_DIR", "config")
+		t.Setenv("SECRETS_DIR", secretsDir(t))
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"config", "validate", "local", "production"}, streams{out: &out, err: &out}), out.String())
+	})
+
+	t.Run("negative - every problem of an invalid file is listed", func(t *testing.T) {
+		var (
+			out bytes.Buffer
+			dir = t.TempDir()
+		)
+
+		t.Setenv("CONFIG_DIR", dir)
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "staging.yaml"), []byte("APP_NAME: go-baseline\nHTTP_PORT: http\n"), 0o600))
+
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"config", "validate", "staging"}, streams{out: &out, err: &out}))
+		assert.Contains(t, out.String(), "staging.yaml is invalid")
+		assert.Contains(t, out.String(), `  HTTP_PORT must be an integer, got "http"`)
+		assert.Contains(t, out.String(), "  ALPHA_URL is required")
+	})
+
+	t.Run("negative - missing file", func(t *testing.T) {
+		var out bytes.Buffer
+
+		t.Setenv("CONFIG_DIR", t.TempDir())
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"config", "validate", "staging"}, streams{out: &out, err: &out}))
+		assert.Equal(t, "staging: no configuration file\n", out.String())
+	})
+
+	t.Run("positive - print redacts the secrets", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		t.Setenv("CONFIG_DIR", "config")
+		t.Setenv("SECRETS_DIR", secretsDir(t))
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"config", "print", "local"}, streams{out: &out, err: &errOut}), errOut.String())
+		assert.Contains(t, out.String(), `"AppName": "go-baseline"`)
+		assert.NotContains(t, out.String(), "local-secret")
+	})
+
+	t.Run("negative - print an invalid configuration", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+
+		t.Setenv("CONFIG_DIR", t.TempDir())
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"config", "print", "staging"}, streams{out: &out, err: &errOut}))
+		assert.Empty(t, out.String())
+		assert.Contains(t, errOut.String(), "staging: the configuration is invalid")
+	})
+
+	t.Run("negative - unknown subcommand", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"config", "check"}, streams{out: &out, err: &out}))
+		assert.Contains(t, out.String(), `unknown command "check"`)
+	})
+}
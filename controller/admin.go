@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-baseline/common/buildinfo"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/util"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+type (
+	// IAdminController serves the operational endpoints of the admin server
+	IAdminController interface {
+		GetLogLevel(w http.ResponseWriter, r *http.Request)
+		SetLogLevel(w http.ResponseWriter, r *http.Request)
+		Config(w http.ResponseWriter, r *http.Request)
+		Version(w http.ResponseWriter, r *http.Request)
+	}
+
+	// AdminController holds what the admin endpoints inspect and change at runtime
+	AdminController struct {
+		Logger        logger.ILogger
+		LogLevel      *logging.Level
+		Configuration *config.Live
+	}
+)
+
+// GetLogLevel answers the current log level
+func (c *AdminController) GetLogLevel(w http.ResponseWriter, _ *http.Request) {
+	util.WriteResponse(w, model.LogLevelResponse{Level: c.LogLevel.String()}, http.StatusOK)
+}
+
+// SetLogLevel changes the log level right away, without restarting the app
+func (c *AdminController) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request model.LogLevelRequest
+	if !BindRequest(w, r, &request) {
+		return
+	}
+
+	previous := c.LogLevel.String()
+	if err := c.LogLevel.Set(request.Level); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	logging.WithContext(r.Context(), c.Logger, "from", previous, "to", c.LogLevel.String()).Warn("log level changed")
+	util.WriteResponse(w, model.LogLevelResponse{Level: c.LogLevel.String()}, http.StatusOK)
+}
+
+// Config answers the running configuration, with its secrets redacted
+func (c *AdminController) Config(w http.ResponseWriter, _ *http.Request) {
+	util.WriteResponse(w, c.Configuration.Load().Redact(), http.StatusOK)
+}
+
+// Version answers the commit, build time and Go version of the running binary
+func (c *AdminController) Version(w http.ResponseWriter, _ *http.Request) {
+	util.WriteResponse(w, buildinfo.Get(), http.StatusOK)
+}
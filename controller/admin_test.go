@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/logger"
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-adapter/redis"
+	"github.com/dityuiri/go-baseline/common/buildinfo"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestAdminController_LogLevel(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+	)
+
+	defer mockCtrl.Finish()
+
+	mockLogger.EXPECT().SetLevel(logger.Level.INFO)
+
+	var (
+		c = &AdminController{
+			Logger:   mockLogger,
+			LogLevel: logging.NewLevel(mockLogger, "info"),
+		}
+
+		setLogLevel = func(body string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPut, "/admin/loglevel", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+			c.SetLogLevel(recorder, request)
+
+			return recorder
+		}
+	)
+
+	t.Run("positive - get", func(t *testing.T) {
+		var response model.LogLevelResponse
+
+		recorder := httptest.NewRecorder()
+		c.GetLogLevel(recorder, httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "INFO", response.Level)
+	})
+
+	t.Run("positive - set", func(t *testing.T) {
+		var response model.LogLevelResponse
+
+		mockLogger.EXPECT().SetLevel(logger.Level.DEBUG)
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Warn("log level changed", gomock.Any())
+
+		recorder := setLogLevel(`{"level":"debug"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "DEBUG", response.Level)
+		assert.Equal(t, "DEBUG", c.LogLevel.String())
+	})
+
+	t.Run("negative - unknown level", func(t *testing.T) {
+		recorder := setLogLevel(`{"level":"verbose"}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, "DEBUG", c.LogLevel.String())
+	})
+
+	t.Run("negative - missing level", func(t *testing.T) {
+		recorder := setLogLevel(`{}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestAdminController_Config(t *testing.T) {
+	var (
+		c = &AdminController{
+			Configuration: config.NewLive(&config.Configuration{
+				AppName: "go-baseline",
+				Redis:   &redis.Config{Host: "localhost", Password: "redis-password"},
+			}),
+		}
+
+		response map[string]interface{}
+		recorder = httptest.NewRecorder()
+	)
+
+	c.Config(recorder, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "redis-password")
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "go-baseline", response["AppName"])
+	assert.Equal(t, config.Redacted, response["Redis"].(map[string]interface{})["Password"])
+}
+
+func TestAdminController_Version(t *testing.T) {
+	var (
+		c = &AdminController{}
+
+		response buildinfo.Info
+		recorder = httptest.NewRecorder()
+	)
+
+	buildinfo.Commit, buildinfo.BuildTime = "abc123", "2024-01-01T00:00:00Z"
+	defer func() { buildinfo.Commit, buildinfo.BuildTime = "", "" }()
+
+	c.Version(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, buildinfo.Info{Commit: "abc123", BuildTime: "2024-01-01T00:00:00Z", GoVersion: runtime.Version()}, response)
+}
@@ -1,6 +1,12 @@
 package controller
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/util"
+	"github.com/dityuiri/go-baseline/common/validator"
 	"github.com/dityuiri/go-baseline/model"
 )
 
@@ -12,3 +18,45 @@ func NewError(code model.APIErrorCode, err error) model.APIResponse {
 		},
 	}
 }
+
+// NewValidationError builds the error response listing every field that failed the validation
+func NewValidationError(validationErrors validator.ValidationErrors) model.APIResponse {
+	details := make([]model.APIErrorDetail, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		details = append(details, model.APIErrorDetail{
+			Field:   fieldError.Field,
+			Rule:    fieldError.Rule,
+			Message: fieldError.Message,
+		})
+	}
+
+	return model.APIResponse{
+		Error: &model.APIErrorResponse{
+			Code:    model.ValidationFailed.String(),
+			Message: common.ErrValidationFailed.Error(),
+			Details: details,
+		},
+	}
+}
+
+// BindRequest parses the JSON request body into data, a pointer to the request model, and validates it
+// against the `validate` tags of the model. When it returns false, the error response is already written.
+func BindRequest(w http.ResponseWriter, r *http.Request, data interface{}) bool {
+	if err := util.HttpRequestBodyParser(r, data); err != nil {
+		WriteError(w, common.ErrInvalidRequestBody)
+		return false
+	}
+
+	if err := validator.Validate(data); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			// Not a struct to validate, the body can't be bound to it
+			err = common.ErrInvalidRequestBody
+		}
+
+		WriteError(w, err)
+		return false
+	}
+
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/validator"
 	"github.com/dityuiri/go-baseline/model"
 )
 
@@ -27,3 +28,28 @@ func TestCommon_NewError(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestCommon_NewValidationError(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			validationErrors = validator.ValidationErrors{
+				{Field: "name", Rule: "required", Message: "name is required"},
+				{Field: "amount", Rule: "min", Param: "0", Message: "amount must be at least 0"},
+			}
+
+			expected = model.APIResponse{
+				Error: &model.APIErrorResponse{
+					Code:    model.ValidationFailed.String(),
+					Message: common.ErrValidationFailed.Error(),
+					Details: []model.APIErrorDetail{
+						{Field: "name", Rule: "required", Message: "name is required"},
+						{Field: "amount", Rule: "min", Message: "amount must be at least 0"},
+					},
+				},
+			}
+		)
+
+		result := NewValidationError(validationErrors)
+		assert.Equal(t, expected, result)
+	})
+}
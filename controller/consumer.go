@@ -2,8 +2,14 @@ package controller
 
 import (
 	"context"
+
+	"github.com/dityuiri/go-adapter/kafka"
 	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/requestid"
+	"github.com/dityuiri/go-baseline/common/tracing"
 
 	"github.com/dityuiri/go-baseline/model"
 	"github.com/dityuiri/go-baseline/service"
@@ -14,17 +20,47 @@ type ConsumerHandler struct {
 	PlaceholderFeedService service.IPlaceholderFeedService
 }
 
-func (ch *ConsumerHandler) Placeholder(msg []byte) (bool, error) {
+// Placeholder handles a placeholder message. When the handling fails, the bool result tells whether the message
+// is done with: false means it can be retried later, true means it can't, like for a message which can't be unmarshalled.
+func (ch *ConsumerHandler) Placeholder(msg kafka.Message) (bool, error) {
 	var (
-		ctx         = context.Background()
+		ctx         = ch.messageContext(msg)
 		placeholder = &model.PlaceholderMessage{}
 	)
 
-	err := common.JsonUnmarshal(msg, placeholder)
+	ctx, span := tracing.StartConsumer(ctx, "ConsumerHandler.Placeholder", tracing.KafkaMessage(msg)...)
+	defer span.End()
+
+	value, _ := msg.Value.([]byte)
+	err := common.JsonUnmarshal(value, placeholder)
 	if err != nil {
-		ch.Logger.Error("error unmarshalling message")
+		logging.WithContext(ctx, ch.Logger).Error("error unmarshalling message", log.WithError(err))
+		tracing.RecordError(span, err)
 		return true, err
 	}
 
-	return ch.PlaceholderFeedService.PlaceholderRecorded(ctx, *placeholder)
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholder.ID)
+
+	done, err := ch.PlaceholderFeedService.PlaceholderRecorded(ctx, *placeholder)
+	if err != nil {
+		logging.WithContext(ctx, ch.Logger, "done", done).Error("error handling placeholder message", log.WithError(err))
+	}
+
+	tracing.RecordError(span, err)
+
+	return done, err
+}
+
+// messageContext restores the request ID and the trace context of the message producer,
+// and carries the position of the message to its logs. A new request ID is created for messages without it.
+func (*ConsumerHandler) messageContext(msg kafka.Message) context.Context {
+	requestID := string(msg.Headers[requestid.MessageHeader])
+	if !requestid.Valid(requestID) {
+		requestID = requestid.New()
+	}
+
+	ctx := tracing.ExtractMessage(context.Background(), msg)
+	ctx = logging.NewContext(ctx, "partition", msg.Partition, "offset", msg.Offset)
+
+	return requestid.NewContext(ctx, requestID)
 }
@@ -4,25 +4,42 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/dityuiri/go-adapter/kafka"
 	"github.com/dityuiri/go-adapter/logger/mock"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/requestid"
+	"github.com/dityuiri/go-baseline/common/tracing"
+	baselineMock "github.com/dityuiri/go-baseline/mock"
 	serviceMock "github.com/dityuiri/go-baseline/mock/service"
 	"github.com/dityuiri/go-baseline/model"
 )
 
+func TestMain(m *testing.M) {
+	baselineMock.NewSpanRecorder()
+	os.Exit(m.Run())
+}
+
 func TestConsumer_Placeholder(t *testing.T) {
 	var (
 		mockCtrl                   = gomock.NewController(t)
 		mockLogger                 = mock.NewMockILogger(mockCtrl)
 		mockPlaceholderFeedService = serviceMock.NewMockIPlaceholderFeedService(mockCtrl)
 
-		ctx    = context.Background()
-		msg, _ = common.JsonMarshal(model.PlaceholderMessage{})
+		value, _ = common.JsonMarshal(model.PlaceholderMessage{})
+		msg      = kafka.Message{
+			Value: value,
+			Headers: kafka.Header{
+				requestid.MessageHeader: []byte("request-1"),
+			},
+		}
 
 		consumer = ConsumerHandler{
 			Logger:                 mockLogger,
@@ -33,13 +50,68 @@ func TestConsumer_Placeholder(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	t.Run("positive", func(t *testing.T) {
-		mockPlaceholderFeedService.EXPECT().PlaceholderRecorded(ctx, gomock.Any()).Return(true, nil)
+		mockPlaceholderFeedService.EXPECT().PlaceholderRecorded(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ model.PlaceholderMessage) (bool, error) {
+				assert.Equal(t, "request-1", requestid.FromContext(ctx))
+				return true, nil
+			})
 
 		res, err := consumer.Placeholder(msg)
 		assert.Nil(t, err)
 		assert.True(t, res)
 	})
 
+	t.Run("positive - message without request id", func(t *testing.T) {
+		mockPlaceholderFeedService.EXPECT().PlaceholderRecorded(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ model.PlaceholderMessage) (bool, error) {
+				assert.NotEmpty(t, requestid.FromContext(ctx))
+				return true, nil
+			})
+
+		res, err := consumer.Placeholder(kafka.Message{Value: value})
+		assert.Nil(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("positive - trace context is continued", func(t *testing.T) {
+		producerCtx, span := tracing.StartProducer(context.Background(), "test")
+		defer span.End()
+
+		tracedMsg := kafka.Message{Value: value}
+		tracing.InjectMessage(producerCtx, &tracedMsg)
+
+		mockPlaceholderFeedService.EXPECT().PlaceholderRecorded(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ model.PlaceholderMessage) (bool, error) {
+				consumerSpan, ok := trace.SpanFromContext(ctx).(sdktrace.ReadOnlySpan)
+				assert.True(t, ok)
+				assert.Equal(t, "ConsumerHandler.Placeholder", consumerSpan.Name())
+				assert.Equal(t, span.SpanContext().WithRemote(true), consumerSpan.Parent())
+				return true, nil
+			})
+
+		res, err := consumer.Placeholder(tracedMsg)
+		assert.Nil(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("negative - placeholder recorded failed", func(t *testing.T) {
+		var (
+			feedErr     = errors.New("error")
+			placeholder = model.PlaceholderMessage{ID: "placeholder-1"}
+		)
+
+		value, _ := common.JsonMarshal(placeholder)
+
+		mockPlaceholderFeedService.EXPECT().PlaceholderRecorded(gomock.Any(), placeholder).Return(false, feedErr)
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Error("error handling placeholder message",
+			baselineMock.LogWith(feedErr, "request_id=request-1", "partition=3", "offset=42", "placeholder_id=placeholder-1", "done=false"))
+
+		res, err := consumer.Placeholder(kafka.Message{Partition: 3, Offset: 42, Value: value, Headers: msg.Headers})
+		assert.EqualError(t, err, "error")
+		assert.False(t, res)
+	})
+
 	t.Run("unmarshal failed", func(t *testing.T) {
 		// Patching the unmarshal method
 		jsonUnmarshal := json.Unmarshal
@@ -47,7 +119,8 @@ func TestConsumer_Placeholder(t *testing.T) {
 			return errors.New("error")
 		}
 
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockLogger.EXPECT().GetSkip().Return(nil)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		res, err := consumer.Placeholder(msg)
 		assert.EqualError(t, err, "error")
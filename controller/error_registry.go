@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/util"
+	"github.com/dityuiri/go-baseline/common/validator"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+type (
+	// ErrorMapping tells how an error is answered to the client
+	ErrorMapping struct {
+		Status int
+		Code   model.APIErrorCode
+
+		// Expose tells whether the error text is safe to be shown to clients.
+		// Hidden errors are answered with the generic text of the HTTP status.
+		Expose bool
+
+		// Response optionally builds the whole error response from the matched error, e.g. to add details
+		Response func(err error) model.APIResponse
+	}
+
+	// errorEntry pairs a mapping with the function that finds its error in an error chain
+	errorEntry struct {
+		match   func(err error) (error, bool)
+		mapping ErrorMapping
+	}
+)
+
+var (
+	errorRegistryMu sync.RWMutex
+	errorRegistry   []errorEntry
+
+	// internalErrorMapping answers every error that is not registered
+	internalErrorMapping = ErrorMapping{
+		Status: http.StatusInternalServerError,
+		Code:   model.InternalServerError,
+	}
+)
+
+func init() {
+	// Parameter Validation Errors
+	RegisterError(common.ErrInvalidUUIDPlaceholderID, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterError(common.ErrInvalidRequestBody, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidRequestBody, Expose: true})
+	RegisterError(common.ErrUnsupportedMediaType, ErrorMapping{Status: http.StatusUnsupportedMediaType, Code: model.InvalidRequestBody, Expose: true})
+	RegisterError(common.ErrValidationFailed, ErrorMapping{Status: http.StatusUnprocessableEntity, Code: model.ValidationFailed, Expose: true})
+	RegisterError(common.ErrMissingPlaceholderID, ErrorMapping{Status: http.StatusBadRequest, Code: model.MissingParameter, Expose: true})
+	RegisterError(common.ErrInvalidPagination, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterError(common.ErrInvalidAmountRange, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterError(common.ErrInvalidCreatedAtRange, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterError(common.ErrInvalidSortParameter, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterErrorType[validator.ValidationErrors](ErrorMapping{
+		Status: http.StatusUnprocessableEntity,
+		Code:   model.ValidationFailed,
+		Expose: true,
+		Response: func(err error) model.APIResponse {
+			return NewValidationError(err.(validator.ValidationErrors))
+		},
+	})
+
+	// Proxy Errors
+	RegisterError(common.ErrAlphaProxyNotFound, ErrorMapping{Status: http.StatusNotFound, Code: model.ObjectNotFound, Expose: true})
+	RegisterError(common.ErrAlphaProxyBadRequest, ErrorMapping{Status: http.StatusBadGateway, Code: model.UpstreamFailure})
+	RegisterError(common.ErrAlphaInternalServerError, ErrorMapping{Status: http.StatusBadGateway, Code: model.UpstreamFailure})
+
+	// Repository Errors
+	RegisterError(common.ErrPlaceholderNotFound, ErrorMapping{Status: http.StatusNotFound, Code: model.ObjectNotFound, Expose: true})
+	RegisterError(common.ErrVersionMismatch, ErrorMapping{Status: http.StatusPreconditionFailed, Code: model.PreconditionFailed, Expose: true})
+
+	// Auth Errors
+	RegisterError(common.ErrUnauthorized, ErrorMapping{Status: http.StatusUnauthorized, Code: model.Unauthorized, Expose: true})
+	RegisterError(common.ErrForbidden, ErrorMapping{Status: http.StatusForbidden, Code: model.Forbidden, Expose: true})
+	RegisterError(common.ErrNotOwner, ErrorMapping{Status: http.StatusForbidden, Code: model.Forbidden, Expose: true})
+
+	// Request Errors
+	RegisterError(common.ErrRequestTimeout, ErrorMapping{Status: http.StatusGatewayTimeout, Code: model.RequestTimeout, Expose: true})
+	RegisterError(context.DeadlineExceeded, ErrorMapping{Status: http.StatusGatewayTimeout, Code: model.RequestTimeout})
+	RegisterError(common.ErrRateLimited, ErrorMapping{Status: http.StatusTooManyRequests, Code: model.TooManyRequests, Expose: true})
+
+	// Conditional Request Errors
+	RegisterError(common.ErrMissingIfMatch, ErrorMapping{Status: http.StatusPreconditionRequired, Code: model.PreconditionRequired, Expose: true})
+
+	// Idempotency Errors
+	RegisterError(common.ErrInvalidIdempotencyKey, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+	RegisterError(common.ErrIdempotencyKeyReused, ErrorMapping{Status: http.StatusUnprocessableEntity, Code: model.IdempotencyKeyReused, Expose: true})
+	RegisterError(common.ErrIdempotencyInProgress, ErrorMapping{Status: http.StatusConflict, Code: model.RequestInProgress, Expose: true})
+
+	// Admin Errors
+	RegisterError(common.ErrInvalidLogLevel, ErrorMapping{Status: http.StatusBadRequest, Code: model.InvalidParameter, Expose: true})
+}
+
+// RegisterError maps a sentinel error, matched with errors.Is, to its HTTP answer.
+// Errors are matched in registration order, so register them during initialization.
+func RegisterError(target error, mapping ErrorMapping) {
+	registerError(func(err error) (error, bool) {
+		return target, errors.Is(err, target)
+	}, mapping)
+}
+
+// RegisterErrorType maps every error of type T, matched with errors.As, to its HTTP answer.
+// The matched error handed to ErrorMapping.Response is of type T.
+func RegisterErrorType[T error](mapping ErrorMapping) {
+	registerError(func(err error) (error, bool) {
+		var target T
+		if errors.As(err, &target) {
+			return target, true
+		}
+
+		return nil, false
+	}, mapping)
+}
+
+func registerError(match func(err error) (error, bool), mapping ErrorMapping) {
+	errorRegistryMu.Lock()
+	defer errorRegistryMu.Unlock()
+
+	errorRegistry = append(errorRegistry, errorEntry{match: match, mapping: mapping})
+}
+
+// lookupError returns the mapping of the first registered error found in the chain of err
+func lookupError(err error) (ErrorMapping, error) {
+	errorRegistryMu.RLock()
+	defer errorRegistryMu.RUnlock()
+
+	for _, entry := range errorRegistry {
+		if matched, ok := entry.match(err); ok {
+			return entry.mapping, matched
+		}
+	}
+
+	return internalErrorMapping, err
+}
+
+// NewErrorResponse builds the error response and picks the HTTP status of err from the error registry
+func NewErrorResponse(err error) (model.APIResponse, int) {
+	mapping, matched := lookupError(err)
+
+	if mapping.Response != nil {
+		return mapping.Response(matched), mapping.Status
+	}
+
+	// Only the registered error is exposed, wrapping context may carry internal details
+	message := strings.ToLower(http.StatusText(mapping.Status))
+	if mapping.Expose {
+		message = matched.Error()
+	}
+
+	return model.APIResponse{
+		Error: &model.APIErrorResponse{
+			Code:    mapping.Code.String(),
+			Message: message,
+		},
+	}, mapping.Status
+}
+
+// WriteError answers the request with the status and error code registered for err.
+// Unregistered errors are answered as internal server error without exposing their text.
+func WriteError(w http.ResponseWriter, err error) {
+	errResponse, status := NewErrorResponse(err)
+	util.WriteResponse(w, errResponse, status)
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/validator"
+	"github.com/dityuiri/go-baseline/mock"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+type testTypedError struct {
+	reason string
+}
+
+func (e testTypedError) Error() string {
+	return "typed error: " + e.reason
+}
+
+func TestErrorRegistry_NewErrorResponse(t *testing.T) {
+	t.Run("positive - registered sentinel error", func(t *testing.T) {
+		resp, status := NewErrorResponse(common.ErrPlaceholderNotFound)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, model.ObjectNotFound.String(), resp.Error.Code)
+		assert.Equal(t, common.ErrPlaceholderNotFound.Error(), resp.Error.Message)
+	})
+
+	t.Run("positive - wrapped sentinel error only exposes the registered error", func(t *testing.T) {
+		err := fmt.Errorf("select placeholder from db-01.internal: %w", common.ErrPlaceholderNotFound)
+
+		resp, status := NewErrorResponse(err)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, common.ErrPlaceholderNotFound.Error(), resp.Error.Message)
+	})
+
+	t.Run("positive - typed error with custom response", func(t *testing.T) {
+		err := fmt.Errorf("patch: %w", validator.ValidationErrors{
+			{Field: "amount", Rule: "min", Param: "0", Message: "amount must be at least 0"},
+		})
+
+		resp, status := NewErrorResponse(err)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, model.ValidationFailed.String(), resp.Error.Code)
+		assert.Equal(t, []model.APIErrorDetail{
+			{Field: "amount", Rule: "min", Message: "amount must be at least 0"},
+		}, resp.Error.Details)
+	})
+
+	t.Run("positive - registered typed error", func(t *testing.T) {
+		RegisterErrorType[testTypedError](ErrorMapping{Status: http.StatusConflict, Code: model.InvalidParameter, Expose: true})
+
+		resp, status := NewErrorResponse(fmt.Errorf("wrapped: %w", testTypedError{reason: "conflict"}))
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, "typed error: conflict", resp.Error.Message)
+	})
+
+	t.Run("positive - hidden error text", func(t *testing.T) {
+		resp, status := NewErrorResponse(common.ErrAlphaInternalServerError)
+		assert.Equal(t, http.StatusBadGateway, status)
+		assert.Equal(t, model.UpstreamFailure.String(), resp.Error.Code)
+		assert.Equal(t, "bad gateway", resp.Error.Message)
+	})
+
+	t.Run("positive - deadline exceeded", func(t *testing.T) {
+		resp, status := NewErrorResponse(fmt.Errorf("query: %w", context.DeadlineExceeded))
+		assert.Equal(t, http.StatusGatewayTimeout, status)
+		assert.Equal(t, model.RequestTimeout.String(), resp.Error.Code)
+	})
+
+	t.Run("negative - unregistered error is internal and hidden", func(t *testing.T) {
+		resp, status := NewErrorResponse(errors.New("pq: password authentication failed for user \"admin\""))
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, model.InternalServerError.String(), resp.Error.Code)
+		assert.Equal(t, "internal server error", resp.Error.Message)
+	})
+}
+
+func TestErrorRegistry_WriteError(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockWriter = mock.NewMockResponseWriter(mockCtrl)
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		WriteError(mockWriter, common.ErrInvalidRequestBody)
+	})
+}
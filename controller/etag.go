@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/service"
+)
+
+// entityTag builds the strong ETag identifying the given version of a placeholder
+func entityTag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// versionFromIfMatch reads the placeholder version the client expects to modify from the If-Match header.
+// * matches any stored version, as RFC 9110 requires, and is read as service.AnyVersion. Otherwise only
+// a single strong ETag of the placeholder is accepted, anything else can't match the stored version.
+func versionFromIfMatch(r *http.Request) (int64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch ifMatch {
+	case "":
+		return 0, common.ErrMissingIfMatch
+	case "*":
+		return service.AnyVersion, nil
+	}
+
+	tag, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return 0, common.ErrVersionMismatch
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return 0, common.ErrVersionMismatch
+	}
+
+	return version, nil
+}
+
+// notModified reports whether the If-None-Match header of the request matches the ETag,
+// comparing the tags weakly as RFC 9110 requires for If-None-Match
+func notModified(r *http.Request, etag string) bool {
+	ifNoneMatch := strings.TrimSpace(r.Header.Get("If-None-Match"))
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if ifNoneMatch == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeNotModified answers a conditional GET whose representation the client already holds
+func writeNotModified(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/service"
+)
+
+func TestVersionFromIfMatch(t *testing.T) {
+	newRequest := func(ifMatch string) *http.Request {
+		request, _ := http.NewRequest("PUT", "/", nil)
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+
+		return request
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		version, err := versionFromIfMatch(newRequest(entityTag(42)))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(42), version)
+	})
+
+	t.Run("positive - any version", func(t *testing.T) {
+		version, err := versionFromIfMatch(newRequest("*"))
+		assert.Nil(t, err)
+		assert.Equal(t, service.AnyVersion, version)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		_, err := versionFromIfMatch(newRequest(""))
+		assert.Equal(t, common.ErrMissingIfMatch, err)
+	})
+
+	for _, ifMatch := range []string{`W/"42"`, `42`, `"forty-two"`, `"0"`, `"1", "2"`} {
+		t.Run("not a placeholder etag "+ifMatch, func(t *testing.T) {
+			_, err := versionFromIfMatch(newRequest(ifMatch))
+			assert.Equal(t, common.ErrVersionMismatch, err)
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	var etag = entityTag(7)
+
+	for ifNoneMatch, expected := range map[string]bool{
+		"":           false,
+		`"7"`:        true,
+		`W/"7"`:      true,
+		`"6", "7"`:   true,
+		`*`:          true,
+		`"6"`:        false,
+		`"70"`:       false,
+		`"6", W/"8"`: false,
+	} {
+		request, _ := http.NewRequest("GET", "/", nil)
+		request.Header.Set("If-None-Match", ifNoneMatch)
+
+		assert.Equal(t, expected, notModified(request, etag), ifNoneMatch)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-baseline/common/health"
 	"github.com/dityuiri/go-baseline/common/util"
 	"github.com/dityuiri/go-baseline/service"
 )
@@ -12,6 +13,11 @@ type (
 	// IHealthCheckController is an interface ...
 	IHealthCheckController interface {
 		Ping(w http.ResponseWriter, r *http.Request)
+		Livez(w http.ResponseWriter, r *http.Request)
+		Readyz(w http.ResponseWriter, r *http.Request)
+		Startupz(w http.ResponseWriter, r *http.Request)
+		PublicLivez(w http.ResponseWriter, r *http.Request)
+		PublicReadyz(w http.ResponseWriter, r *http.Request)
 	}
 
 	// HealthCheckController is an app health check struct that consists of all the dependencies needed for health check controller
@@ -23,5 +29,61 @@ type (
 
 // Ping is a controller function to health check the app.
 func (c *HealthCheckController) Ping(w http.ResponseWriter, r *http.Request) {
-	util.WriteResponse(w, c.HealthCheckService.Ping(), http.StatusOK)
+	util.WriteResponse(w, c.HealthCheckService.Ping(r.Context()), http.StatusOK)
+}
+
+// Livez answers the liveness probe, the process is alive as long as it answers
+func (c *HealthCheckController) Livez(w http.ResponseWriter, _ *http.Request) {
+	util.WriteResponse(w, c.HealthCheckService.Liveness(), http.StatusOK)
+}
+
+// Readyz answers the readiness probe. It responds with 503 while a critical dependency is down,
+// or the app is starting or shutting down, so no traffic is routed to the app.
+func (c *HealthCheckController) Readyz(w http.ResponseWriter, r *http.Request) {
+	var (
+		report = c.HealthCheckService.Readiness(r.Context())
+		status = http.StatusOK
+	)
+
+	if !report.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	util.WriteResponse(w, report, status)
+}
+
+// PublicLivez answers the liveness probe of the public port, with the status only. The state of the
+// components and their errors are served on the admin port.
+func (c *HealthCheckController) PublicLivez(w http.ResponseWriter, _ *http.Request) {
+	util.WriteResponse(w, health.Report{Status: c.HealthCheckService.Liveness().Status}, http.StatusOK)
+}
+
+// PublicReadyz answers the readiness probe of the public port like Readyz, with the status only and from the
+// dependencies probed within a few seconds, so anonymous callers neither see the dependency errors nor
+// get the dependencies probed on every request
+func (c *HealthCheckController) PublicReadyz(w http.ResponseWriter, r *http.Request) {
+	var (
+		report = c.HealthCheckService.Status(r.Context())
+		status = http.StatusOK
+	)
+
+	if !report.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	util.WriteResponse(w, report, status)
+}
+
+// Startupz answers the startup probe. It responds with 503 until the app is done waiting for its dependencies.
+func (c *HealthCheckController) Startupz(w http.ResponseWriter, _ *http.Request) {
+	var (
+		report = c.HealthCheckService.Startup()
+		status = http.StatusOK
+	)
+
+	if !report.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	util.WriteResponse(w, report, status)
 }
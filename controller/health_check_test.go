@@ -2,11 +2,14 @@ package controller
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-chi/chi"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 
+	"github.com/dityuiri/go-baseline/common/health"
 	"github.com/dityuiri/go-baseline/mock"
 	serviceMock "github.com/dityuiri/go-baseline/mock/service"
 )
@@ -28,7 +31,7 @@ func TestHealthCheck_Ping(t *testing.T) {
 		router := chi.NewRouter()
 		router.Get("/ping", h.Ping)
 
-		mockHealthCheck.EXPECT().Ping().Return(map[string]string{"status": "OK"})
+		mockHealthCheck.EXPECT().Ping(gomock.Any()).Return(map[string]string{"status": "OK"})
 		mockWriter.EXPECT().Header().Return(http.Header{})
 		mockWriter.EXPECT().WriteHeader(http.StatusOK)
 		mockWriter.EXPECT().Write(gomock.Any()).Return(0, nil)
@@ -37,3 +40,136 @@ func TestHealthCheck_Ping(t *testing.T) {
 		router.ServeHTTP(mockWriter, request)
 	})
 }
+
+func TestHealthCheck_Livez(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		mockHealthCheck = serviceMock.NewMockIHealthCheckService(mockCtrl)
+		mockWriter      = mock.NewMockResponseWriter(mockCtrl)
+
+		h = &HealthCheckController{
+			HealthCheckService: mockHealthCheck,
+		}
+	)
+
+	router := chi.NewRouter()
+	router.Get("/livez", h.Livez)
+
+	mockHealthCheck.EXPECT().Liveness().Return(health.Report{Status: health.StatusUp})
+	mockWriter.EXPECT().Header().Return(http.Header{})
+	mockWriter.EXPECT().WriteHeader(http.StatusOK)
+	mockWriter.EXPECT().Write(gomock.Any()).Return(0, nil)
+
+	request, _ := http.NewRequest("GET", "/livez", nil)
+	router.ServeHTTP(mockWriter, request)
+}
+
+func TestHealthCheck_Readyz(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		mockHealthCheck = serviceMock.NewMockIHealthCheckService(mockCtrl)
+		mockWriter      = mock.NewMockResponseWriter(mockCtrl)
+
+		h = &HealthCheckController{
+			HealthCheckService: mockHealthCheck,
+		}
+	)
+
+	router := chi.NewRouter()
+	router.Get("/readyz", h.Readyz)
+
+	for status, expected := range map[string]int{
+		health.StatusUp:           http.StatusOK,
+		health.StatusDegraded:     http.StatusOK,
+		health.StatusDown:         http.StatusServiceUnavailable,
+		health.StatusShuttingDown: http.StatusServiceUnavailable,
+		health.StatusStarting:     http.StatusServiceUnavailable,
+	} {
+		t.Run(status, func(t *testing.T) {
+			mockHealthCheck.EXPECT().Readiness(gomock.Any()).Return(health.Report{Status: status})
+			mockWriter.EXPECT().Header().Return(http.Header{})
+			mockWriter.EXPECT().WriteHeader(expected)
+			mockWriter.EXPECT().Write(gomock.Any()).Return(0, nil)
+
+			request, _ := http.NewRequest("GET", "/readyz", nil)
+			router.ServeHTTP(mockWriter, request)
+		})
+	}
+}
+
+func TestHealthCheck_Startupz(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		mockHealthCheck = serviceMock.NewMockIHealthCheckService(mockCtrl)
+		mockWriter      = mock.NewMockResponseWriter(mockCtrl)
+
+		h = &HealthCheckController{
+			HealthCheckService: mockHealthCheck,
+		}
+	)
+
+	router := chi.NewRouter()
+	router.Get("/startupz", h.Startupz)
+
+	for status, expected := range map[string]int{
+		health.StatusUp:       http.StatusOK,
+		health.StatusStarting: http.StatusServiceUnavailable,
+	} {
+		t.Run(status, func(t *testing.T) {
+			mockHealthCheck.EXPECT().Startup().Return(health.Report{Status: status})
+			mockWriter.EXPECT().Header().Return(http.Header{})
+			mockWriter.EXPECT().WriteHeader(expected)
+			mockWriter.EXPECT().Write(gomock.Any()).Return(0, nil)
+
+			request, _ := http.NewRequest("GET", "/startupz", nil)
+			router.ServeHTTP(mockWriter, request)
+		})
+	}
+}
+
+func TestHealthCheck_PublicProbes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		mockHealthCheck = serviceMock.NewMockIHealthCheckService(mockCtrl)
+
+		h = &HealthCheckController{
+			HealthCheckService: mockHealthCheck,
+		}
+
+		components = map[string]health.ComponentStatus{
+			"db": {Status: health.StatusDown, Critical: true, LastError: "dial tcp 10.0.0.5:5432: connection refused"},
+		}
+	)
+
+	router := chi.NewRouter()
+	router.Get("/livez", h.PublicLivez)
+	router.Get("/readyz", h.PublicReadyz)
+
+	t.Run("livez without the components", func(t *testing.T) {
+		mockHealthCheck.EXPECT().Liveness().Return(health.Report{Status: health.StatusUp, Components: components})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"status":"up"}`, recorder.Body.String())
+	})
+
+	t.Run("readyz from the status", func(t *testing.T) {
+		mockHealthCheck.EXPECT().Status(gomock.Any()).Return(health.Report{Status: health.StatusDown})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.JSONEq(t, `{"status":"down"}`, recorder.Body.String())
+	})
+}
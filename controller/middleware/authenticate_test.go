@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/auth"
+	authMock "github.com/dityuiri/go-baseline/mock/auth"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestAuthenticate(t *testing.T) {
+	var (
+		mockCtrl     = gomock.NewController(t)
+		mockLogger   = loggerMock.NewMockILogger(mockCtrl)
+		mockVerifier = authMock.NewMockIVerifier(mockCtrl)
+
+		principal = auth.Principal{Subject: "user-1", Roles: []string{"admin"}}
+		captured  auth.Principal
+		called    bool
+
+		handler = Authenticate(mockVerifier, mockLogger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			captured, _ = auth.FromContext(r.Context())
+		}))
+
+		serve = func(authorization string) *httptest.ResponseRecorder {
+			called = false
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if authorization != "" {
+				request.Header.Set("Authorization", authorization)
+			}
+
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		assertUnauthorized = func(t *testing.T, recorder *httptest.ResponseRecorder) {
+			var response model.APIResponse
+
+			assert.False(t, called)
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, model.Unauthorized.String(), response.Error.Code)
+		}
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive", func(t *testing.T) {
+		mockVerifier.EXPECT().Verify("token").Return(principal, nil).Times(1)
+
+		recorder := serve("bearer token")
+		assert.True(t, called)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, principal, captured)
+	})
+
+	t.Run("negative - missing authorization header", func(t *testing.T) {
+		recorder := serve("")
+		assertUnauthorized(t, recorder)
+		assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("negative - not a bearer token", func(t *testing.T) {
+		assertUnauthorized(t, serve("Basic dXNlcjpwYXNz"))
+	})
+
+	t.Run("negative - invalid token", func(t *testing.T) {
+		mockVerifier.EXPECT().Verify("token").Return(auth.Principal{}, errors.New("token is expired")).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		recorder := serve("Bearer token")
+		assertUnauthorized(t, recorder)
+		assert.Equal(t, `Bearer error="invalid_token"`, recorder.Header().Get("WWW-Authenticate"))
+	})
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dityuiri/go-adapter/logger"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/controller"
+)
+
+// Authorize requires the principal stored by Authenticate to meet the policy requirement of the route.
+// Routes of the group without a requirement are denied, unknown routes are left to the router.
+func Authorize(policy auth.Policy, l logger.ILogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.FromContext(r.Context())
+			if !ok {
+				controller.WriteError(w, common.ErrUnauthorized)
+				return
+			}
+
+			pattern, ok := routePattern(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := auth.Route(r.Method, pattern)
+			if requirement, ok := policy[route]; !ok || !requirement.Allows(principal) {
+				logging.WithContext(r.Context(), l, logging.FieldRoute, route).Info("principal is denied to the route")
+				controller.WriteError(w, common.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// routePattern resolves the pattern of the route the request is going to be routed to.
+// Group middlewares run before the routing, so the request is matched against the root router.
+func routePattern(r *http.Request) (string, bool) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return "", false
+	}
+
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	match := chi.NewRouteContext()
+	if !rctx.Routes.Match(match, r.Method, path) {
+		return "", false
+	}
+
+	return match.RoutePattern(), true
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestAuthorize(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+
+		policy = auth.Policy{
+			auth.Route(http.MethodGet, "/v1/test/{testID}"):    {Scopes: []string{"test:read"}},
+			auth.Route(http.MethodDelete, "/v1/test/{testID}"): {Roles: []string{"admin"}},
+		}
+
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		router = chi.NewRouter()
+
+		serve = func(method string, path string, principal *auth.Principal) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, path, nil)
+			if principal != nil {
+				request = request.WithContext(auth.NewContext(request.Context(), *principal))
+			}
+
+			router.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		assertForbidden = func(t *testing.T, recorder *httptest.ResponseRecorder) {
+			var response model.APIResponse
+
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, model.Forbidden.String(), response.Error.Code)
+		}
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	router.Group(func(r chi.Router) {
+		r.Use(Authorize(policy, mockLogger))
+		r.Route("/v1/test", func(r chi.Router) {
+			r.Get("/{testID}", handler)
+			r.Put("/{testID}", handler)
+			r.Delete("/{testID}", handler)
+		})
+	})
+
+	t.Run("positive - scope granted", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/v1/test/1", &auth.Principal{Scopes: []string{"test:read"}})
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+
+	t.Run("positive - role granted", func(t *testing.T) {
+		recorder := serve(http.MethodDelete, "/v1/test/1", &auth.Principal{Roles: []string{"admin"}})
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+
+	t.Run("positive - unknown route is left to the router", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/v1/unknown", &auth.Principal{})
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("negative - scope not granted", func(t *testing.T) {
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		assertForbidden(t, serve(http.MethodDelete, "/v1/test/1", &auth.Principal{Scopes: []string{"test:read"}}))
+	})
+
+	t.Run("negative - route without requirement", func(t *testing.T) {
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		assertForbidden(t, serve(http.MethodPut, "/v1/test/1", &auth.Principal{Roles: []string{"admin"}}))
+	})
+
+	t.Run("negative - not authenticated", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/v1/test/1", nil)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
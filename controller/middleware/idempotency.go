@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/controller"
+	"github.com/dityuiri/go-baseline/model"
+	"github.com/dityuiri/go-baseline/repository"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength   = 255
+	idempotencyPollInterval   = 100 * time.Millisecond
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+)
+
+// Idempotency replays the response of the first request sent with the same Idempotency-Key.
+// Keys are scoped to the client, and bound to the fingerprint of the request they were first sent with.
+type Idempotency struct {
+	Cache  repository.IIdempotencyCache
+	Config *config.Idempotency
+	Logger logger.ILogger
+}
+
+// Handle makes the route idempotent for requests carrying an Idempotency-Key, other requests are served as is.
+// Server errors are not stored, so the request can be retried with the same key.
+func (i *Idempotency) Handle(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > MaxIdempotencyKeyLength {
+			controller.WriteError(w, common.ErrInvalidIdempotencyKey)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			controller.WriteError(w, common.ErrInvalidRequestBody)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var (
+			ctx         = r.Context()
+			cacheKey    = idempotencyScope(r) + ":" + key
+			fingerprint = requestFingerprint(r, body)
+		)
+
+		record, err := i.Cache.GetRecord(ctx, cacheKey)
+		switch {
+		case err == redis.Nil:
+			i.serveFirst(w, r, next, cacheKey, fingerprint)
+		case err != nil:
+			logging.WithContext(ctx, i.Logger).Error("error getting idempotency record from redis", log.WithError(err))
+			controller.WriteError(w, err)
+		default:
+			i.serveRepeated(w, r, cacheKey, fingerprint, record)
+		}
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// serveFirst claims the key while the request is served, then stores its response. A concurrent request
+// which claimed the key since it was looked up is waited for like a repeated request.
+func (i *Idempotency) serveFirst(w http.ResponseWriter, r *http.Request, next http.Handler, cacheKey string, fingerprint string) {
+	var (
+		ctx    = r.Context()
+		record = model.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			State:       model.IdempotencyInProgress,
+		}
+	)
+
+	claimed, err := i.Cache.ClaimRecord(ctx, cacheKey, record, i.lockTTL())
+	if err != nil {
+		logging.WithContext(ctx, i.Logger).Error("error claiming idempotency record in redis", log.WithError(err))
+		controller.WriteError(w, err)
+		return
+	}
+
+	if !claimed {
+		held, err := i.Cache.GetRecord(ctx, cacheKey)
+		if err != nil {
+			// The concurrent request failed and released the key meanwhile, the client may retry
+			controller.WriteError(w, common.ErrIdempotencyInProgress)
+			return
+		}
+
+		i.serveRepeated(w, r, cacheKey, fingerprint, held)
+		return
+	}
+
+	rw := &recordingWriter{ResponseWriter: w}
+	next.ServeHTTP(rw, r)
+
+	// The response is stored even when the request deadline has passed meanwhile
+	storeCtx := context.Background()
+
+	if rw.status() >= http.StatusInternalServerError {
+		if err = i.Cache.DeleteRecord(storeCtx, cacheKey); err != nil {
+			logging.WithContext(ctx, i.Logger).Error("error deleting idempotency record from redis", log.WithError(err))
+		}
+
+		return
+	}
+
+	record.State = model.IdempotencyCompleted
+	record.StatusCode = rw.status()
+	record.Header = http.Header{"Content-Type": rw.Header().Values("Content-Type"), "Etag": rw.Header().Values("ETag")}
+	record.Body = rw.body.Bytes()
+
+	if err = i.Cache.SetRecord(storeCtx, cacheKey, record, i.ttl()); err != nil {
+		logging.WithContext(ctx, i.Logger).Error("error setting idempotency record to redis", log.WithError(err))
+	}
+}
+
+// serveRepeated replays the stored response, waiting for it while the first request is in progress
+func (i *Idempotency) serveRepeated(w http.ResponseWriter, r *http.Request, cacheKey string, fingerprint string, record *model.IdempotencyRecord) {
+	var (
+		ctx      = r.Context()
+		deadline = time.Now().Add(i.Config.Wait)
+	)
+
+	if record.Fingerprint != fingerprint {
+		controller.WriteError(w, common.ErrIdempotencyKeyReused)
+		return
+	}
+
+	for record.State != model.IdempotencyCompleted {
+		if !time.Now().Before(deadline) {
+			controller.WriteError(w, common.ErrIdempotencyInProgress)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			controller.WriteError(w, common.ErrIdempotencyInProgress)
+			return
+		case <-time.After(idempotencyPollInterval):
+		}
+
+		var err error
+		if record, err = i.Cache.GetRecord(ctx, cacheKey); err != nil {
+			// The first request failed and released the key, the client may retry
+			controller.WriteError(w, common.ErrIdempotencyInProgress)
+			return
+		}
+	}
+
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+func (i *Idempotency) ttl() time.Duration {
+	if i.Config.TTL > 0 {
+		return i.Config.TTL
+	}
+
+	return defaultIdempotencyTTL
+}
+
+func (i *Idempotency) lockTTL() time.Duration {
+	if i.Config.LockTTL > 0 {
+		return i.Config.LockTTL
+	}
+
+	return defaultIdempotencyLockTTL
+}
+
+// idempotencyScope identifies the client the key belongs to as the rate limiter does: by its principal, or by its
+// IP when the authentication is disabled, so clients sending the same key don't get each other's responses
+func idempotencyScope(r *http.Request) string {
+	if subject := auth.SubjectFromContext(r.Context()); subject != "" {
+		return "sub:" + subject
+	}
+
+	return "ip:" + clientIP(r)
+}
+
+// requestFingerprint hashes what makes two requests the same: method, path and body
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter keeps a copy of the response written through it
+type recordingWriter struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(code int) {
+	if rw.code == 0 {
+		rw.code = code
+	}
+
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingWriter) Write(p []byte) (int, error) {
+	if rw.code == 0 {
+		rw.code = http.StatusOK
+	}
+
+	rw.body.Write(p)
+	return rw.ResponseWriter.Write(p)
+}
+
+func (rw *recordingWriter) status() int {
+	if rw.code == 0 {
+		return http.StatusOK
+	}
+
+	return rw.code
+}
@@ -0,0 +1,334 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/config"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestIdempotency_Handle(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+		mockCache  = repositoryMock.NewMockIIdempotencyCache(mockCtrl)
+
+		idempotency = Idempotency{
+			Cache:  mockCache,
+			Logger: mockLogger,
+			Config: &config.Idempotency{TTL: time.Hour, LockTTL: time.Minute},
+		}
+
+		body     = `{"name":"Aoi","amount":10000}`
+		cacheKey = "sub:user-1:key-1"
+
+		calls      int
+		statusCode = http.StatusOK
+		handler    = idempotency.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(`{"result":"created"}`))
+		}))
+
+		newRequest = func(key string, body string) *http.Request {
+			request := httptest.NewRequest(http.MethodPost, "/v1/placeholder", strings.NewReader(body))
+			request = request.WithContext(auth.NewContext(request.Context(), auth.Principal{Subject: "user-1"}))
+			if key != "" {
+				request.Header.Set(IdempotencyKeyHeader, key)
+			}
+
+			return request
+		}
+
+		serve = func(request *http.Request) *httptest.ResponseRecorder {
+			calls = 0
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		fingerprint = requestFingerprint(newRequest("", ""), []byte(body))
+		completed   = &model.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			State:       model.IdempotencyCompleted,
+			StatusCode:  http.StatusOK,
+			Header:      http.Header{"Content-Type": {"application/json"}},
+			Body:        []byte(`{"result":"stored"}`),
+		}
+		inProgress = &model.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			State:       model.IdempotencyInProgress,
+		}
+
+		// holdKey expects the first request to claim the key
+		holdKey = func() {
+			mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(&model.IdempotencyRecord{}, redis.Nil).Times(1)
+			mockCache.EXPECT().ClaimRecord(gomock.Any(), cacheKey, gomock.Any(), time.Minute).DoAndReturn(
+				func(_ context.Context, _ string, record model.IdempotencyRecord, _ time.Duration) (bool, error) {
+					assert.Equal(t, model.IdempotencyInProgress, record.State)
+					assert.Equal(t, fingerprint, record.Fingerprint)
+					return true, nil
+				}).Times(1)
+		}
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive - request without key", func(t *testing.T) {
+		recorder := serve(newRequest("", body))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("positive - first request stores the response", func(t *testing.T) {
+		holdKey()
+		mockCache.EXPECT().SetRecord(gomock.Any(), cacheKey, gomock.Any(), time.Hour).DoAndReturn(
+			func(_ context.Context, _ string, record model.IdempotencyRecord, _ time.Duration) error {
+				assert.Equal(t, model.IdempotencyCompleted, record.State)
+				assert.Equal(t, http.StatusOK, record.StatusCode)
+				assert.Equal(t, `{"result":"created"}`, string(record.Body))
+				assert.Equal(t, "application/json", record.Header.Get("Content-Type"))
+				return nil
+			}).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `{"result":"created"}`, recorder.Body.String())
+	})
+
+	t.Run("positive - server error releases the key", func(t *testing.T) {
+		statusCode = http.StatusInternalServerError
+		defer func() { statusCode = http.StatusOK }()
+
+		holdKey()
+		mockCache.EXPECT().DeleteRecord(gomock.Any(), cacheKey).Return(nil).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("positive - repeated request replays the response", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(completed, nil).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "true", recorder.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"result":"stored"}`, recorder.Body.String())
+	})
+
+	t.Run("positive - repeated request waits for the first one", func(t *testing.T) {
+		idempotency.Config.Wait = time.Second
+		defer func() { idempotency.Config.Wait = 0 }()
+
+		gomock.InOrder(
+			mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(inProgress, nil).Times(1),
+			mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(completed, nil).Times(1),
+		)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, `{"result":"stored"}`, recorder.Body.String())
+	})
+
+	t.Run("negative - key reused with a different body", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(completed, nil).Times(1)
+
+		recorder := serve(newRequest("key-1", `{"name":"Aoi","amount":1}`))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.Run("negative - first request is in progress", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(inProgress, nil).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("negative - concurrent request claimed the key", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(&model.IdempotencyRecord{}, redis.Nil).Times(1)
+		mockCache.EXPECT().ClaimRecord(gomock.Any(), cacheKey, gomock.Any(), time.Minute).Return(false, nil).Times(1)
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(inProgress, nil).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("negative - claim record returning error", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(&model.IdempotencyRecord{}, redis.Nil).Times(1)
+		mockCache.EXPECT().ClaimRecord(gomock.Any(), cacheKey, gomock.Any(), time.Minute).Return(false, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("negative - key too long", func(t *testing.T) {
+		recorder := serve(newRequest(strings.Repeat("k", MaxIdempotencyKeyLength+1), body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("negative - get record returning error", func(t *testing.T) {
+		mockCache.EXPECT().GetRecord(gomock.Any(), cacheKey).Return(&model.IdempotencyRecord{}, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		recorder := serve(newRequest("key-1", body))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+// memoryIdempotencyCache keeps the records in memory, claiming a key atomically like redis SET NX
+type memoryIdempotencyCache struct {
+	mu      sync.Mutex
+	records map[string]model.IdempotencyRecord
+}
+
+func (c *memoryIdempotencyCache) GetRecord(_ context.Context, key string) (*model.IdempotencyRecord, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	record, ok := c.records[key]
+	if !ok {
+		return &model.IdempotencyRecord{}, redis.Nil
+	}
+
+	return &record, nil
+}
+
+func (c *memoryIdempotencyCache) SetRecord(_ context.Context, key string, record model.IdempotencyRecord, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.records[key] = record
+	return nil
+}
+
+func (c *memoryIdempotencyCache) ClaimRecord(_ context.Context, key string, record model.IdempotencyRecord, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.records[key]; ok {
+		return false, nil
+	}
+
+	c.records[key] = record
+	return true, nil
+}
+
+func (c *memoryIdempotencyCache) DeleteRecord(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.records, key)
+	return nil
+}
+
+func TestIdempotency_Handle_Concurrent(t *testing.T) {
+	var (
+		calls       int32
+		started     = make(chan struct{})
+		idempotency = Idempotency{
+			Cache:  &memoryIdempotencyCache{records: map[string]model.IdempotencyRecord{}},
+			Config: &config.Idempotency{Wait: 5 * time.Second},
+		}
+
+		handler = idempotency.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"result":"created"}`))
+		}))
+
+		wg        sync.WaitGroup
+		recorders = make([]*httptest.ResponseRecorder, 2)
+	)
+
+	for n := range recorders {
+		wg.Add(1)
+
+		go func(n int) {
+			defer wg.Done()
+
+			request := httptest.NewRequest(http.MethodPost, "/v1/placeholder", strings.NewReader(`{"name":"Aoi"}`))
+			request = request.WithContext(auth.NewContext(request.Context(), auth.Principal{Subject: "user-1"}))
+			request.Header.Set(IdempotencyKeyHeader, "key-1")
+
+			recorders[n] = httptest.NewRecorder()
+			<-started
+			handler.ServeHTTP(recorders[n], request)
+		}(n)
+	}
+
+	close(started)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, recorder := range recorders {
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, `{"result":"created"}`, recorder.Body.String())
+	}
+
+	assert.NotEqual(t, recorders[0].Header().Get(IdempotentReplayedHeader), recorders[1].Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_Handle_Anonymous(t *testing.T) {
+	var (
+		calls       int
+		idempotency = Idempotency{
+			Cache:  &memoryIdempotencyCache{records: map[string]model.IdempotencyRecord{}},
+			Config: &config.Idempotency{},
+		}
+
+		handler = idempotency.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"result":"created by %s"}`, r.RemoteAddr)))
+		}))
+
+		serve = func(remoteAddr string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPost, "/v1/placeholder", strings.NewReader(`{"name":"Aoi"}`))
+			request.RemoteAddr = remoteAddr
+			request.Header.Set(IdempotencyKeyHeader, "key-1")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+	)
+
+	first := serve("10.0.0.1:5000")
+	other := serve("10.0.0.2:5000")
+	repeated := serve("10.0.0.1:6000")
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, `{"result":"created by 10.0.0.2:5000"}`, other.Body.String())
+	assert.Empty(t, other.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Body.String(), repeated.Body.String())
+	assert.Equal(t, "true", repeated.Header().Get(IdempotentReplayedHeader))
+}
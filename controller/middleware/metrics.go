@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/metrics"
+)
+
+// Metrics records the count, the latency and the status of the requests per chi route pattern.
+// The pattern is only known once the request is routed, so the middleware must wrap the router.
+func Metrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			var (
+				start = common.TimeNow()
+				sw    = &statusWriter{ResponseWriter: w}
+			)
+
+			next.ServeHTTP(sw, r)
+			m.ObserveHTTPRequest(r.Method, servedRoute(r), sw.status(), start)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// servedRoute returns the chi route pattern which served the request, empty when no route matched
+func servedRoute(r *http.Request) string {
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+		return routeCtx.RoutePattern()
+	}
+
+	return ""
+}
+
+// statusWriter remembers the status code written through it
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (sw *statusWriter) WriteHeader(code int) {
+	if sw.code == 0 {
+		sw.code = code
+	}
+
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+func (sw *statusWriter) Write(p []byte) (int, error) {
+	if sw.code == 0 {
+		sw.code = http.StatusOK
+	}
+
+	return sw.ResponseWriter.Write(p)
+}
+
+func (sw *statusWriter) status() int {
+	if sw.code == 0 {
+		return http.StatusOK
+	}
+
+	return sw.code
+}
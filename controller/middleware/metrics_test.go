@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	var (
+		m      = metrics.New()
+		router = chi.NewRouter()
+	)
+
+	router.Use(Metrics(m))
+	router.Route("/v1/placeholder", func(r chi.Router) {
+		r.Get("/{placeholderID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+
+	for _, path := range []string{"/v1/placeholder/1", "/v1/placeholder/2", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, recorder.Body.String(), `http_requests_total{method="GET",route="/v1/placeholder/{placeholderID}",status="204"} 2`)
+	assert.Contains(t, recorder.Body.String(), `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/ratelimit"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/controller"
+)
+
+// RateLimiter limits the requests of each client with the limits of the running config.RateLimit
+type RateLimiter struct {
+	Limiter ratelimit.ILimiter
+	Config  *config.Live
+	Logger  logger.ILogger
+}
+
+// Limit limits the requests to the given route name. Requests over the limit are answered with
+// 429 and Retry-After, every counted response carries the X-RateLimit-* headers.
+// Requests are served without a limit when the limiter fails, so its outage doesn't take the API down.
+// The limit is looked up on every request, so a reloaded limit applies right away.
+func (rl *RateLimiter) Limit(route string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			var (
+				rateLimit = rl.Config.Load().RateLimit
+				rule      = rateLimit.RouteLimit(route)
+			)
+
+			// No limit is configured for the route
+			if rule.Requests <= 0 || rule.Window <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := rl.Limiter.Allow(r.Context(), route+":"+clientKey(rateLimit, r), rule)
+			if err != nil {
+				logging.WithContext(r.Context(), rl.Logger).Error("rate limiter failed, request is not limited", log.WithError(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("X-RateLimit-Reset", ceilSeconds(result.Reset))
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+				controller.WriteError(w, common.ErrRateLimited)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// clientKey identifies the client by the first available of the configured identities, falling back to the IP.
+// Only verified identities are used, a client sending a new unverified one on every request would get a new limit.
+func clientKey(rateLimit *config.RateLimit, r *http.Request) string {
+	for _, keyBy := range rateLimit.KeyBy {
+		switch keyBy {
+		case ratelimit.KeyByPrincipal:
+			if subject := auth.SubjectFromContext(r.Context()); subject != "" {
+				return "sub:" + subject
+			}
+		case ratelimit.KeyByIP:
+			return "ip:" + clientIP(r)
+		}
+	}
+
+	return "ip:" + clientIP(r)
+}
+
+// clientIP is the remote address, the server's RealIP middleware already replaced it from the X-Forwarded-For
+// and X-Real-IP headers. Those are set by the client unless a trusted proxy overwrites them, so the IP only
+// identifies the client when the API is served behind such a proxy.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/ratelimit"
+	"github.com/dityuiri/go-baseline/config"
+	ratelimitMock "github.com/dityuiri/go-baseline/mock/ratelimit"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestRateLimiter_Limit(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockLogger  = loggerMock.NewMockILogger(mockCtrl)
+		mockLimiter = ratelimitMock.NewMockILimiter(mockCtrl)
+
+		rule        = config.RateLimitRule{Requests: 10, Window: time.Minute}
+		rateLimiter = RateLimiter{
+			Limiter: mockLimiter,
+			Logger:  mockLogger,
+			Config: config.NewLive(&config.Configuration{
+				RateLimit: &config.RateLimit{
+					KeyBy:   []string{ratelimit.KeyByPrincipal, ratelimit.KeyByIP},
+					Default: rule,
+					Routes: map[string]config.RateLimitRule{
+						"unlimited": {},
+					},
+				},
+			}),
+		}
+
+		called  bool
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+
+		newRequest = func() *http.Request {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.RemoteAddr = "10.0.0.1:5000"
+			return request
+		}
+
+		serve = func(route string, request *http.Request) *httptest.ResponseRecorder {
+			called = false
+			recorder := httptest.NewRecorder()
+			rateLimiter.Limit(route)(handler).ServeHTTP(recorder, request)
+			return recorder
+		}
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive - allowed, keyed by ip", func(t *testing.T) {
+		mockLimiter.EXPECT().Allow(gomock.Any(), "route:ip:10.0.0.1", rule).
+			Return(ratelimit.Result{Allowed: true, Limit: 10, Remaining: 9, Reset: 5500 * time.Millisecond}, nil).Times(1)
+
+		recorder := serve("route", newRequest())
+		assert.True(t, called)
+		assert.Equal(t, "10", recorder.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "9", recorder.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "6", recorder.Header().Get("X-RateLimit-Reset"))
+		assert.Empty(t, recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("positive - keyed by principal", func(t *testing.T) {
+		request := newRequest()
+		request = request.WithContext(auth.NewContext(request.Context(), auth.Principal{Subject: "user-1"}))
+		mockLimiter.EXPECT().Allow(gomock.Any(), "route:sub:user-1", rule).Return(ratelimit.Result{Allowed: true}, nil).Times(1)
+
+		serve("route", request)
+		assert.True(t, called)
+	})
+
+	t.Run("positive - unverified api key is not a client identity", func(t *testing.T) {
+		request := newRequest()
+		request.Header.Set("X-API-Key", "random")
+		mockLimiter.EXPECT().Allow(gomock.Any(), "route:ip:10.0.0.1", rule).Return(ratelimit.Result{Allowed: true}, nil).Times(1)
+
+		serve("route", request)
+		assert.True(t, called)
+	})
+
+	t.Run("positive - route without limit", func(t *testing.T) {
+		serve("unlimited", newRequest())
+		assert.True(t, called)
+	})
+
+	t.Run("positive - limiter failure lets the request through", func(t *testing.T) {
+		mockLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), rule).Return(ratelimit.Result{}, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		serve("route", newRequest())
+		assert.True(t, called)
+	})
+
+	t.Run("negative - limited", func(t *testing.T) {
+		var response model.APIResponse
+
+		mockLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), rule).
+			Return(ratelimit.Result{Limit: 10, RetryAfter: 1500 * time.Millisecond, Reset: time.Minute}, nil).Times(1)
+
+		recorder := serve("route", newRequest())
+		assert.False(t, called)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+		assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, model.TooManyRequests.String(), response.Error.Code)
+	})
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	chiMiddleware "github.com/go-chi/chi/middleware"
+
+	"github.com/dityuiri/go-baseline/common/requestid"
+)
+
+// RequestID accepts the X-Request-ID sent by the client, or creates one when it is missing or invalid.
+// The ID is stored in the request context and returned in the response header.
+func RequestID(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestid.Header)
+		if !requestid.Valid(requestID) {
+			requestID = requestid.New()
+		}
+
+		ctx := requestid.NewContext(r.Context(), requestID)
+
+		// Keep chi's request ID in line so chi middlewares log the same ID
+		ctx = context.WithValue(ctx, chiMiddleware.RequestIDKey, requestID)
+
+		w.Header().Set(requestid.Header, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common/requestid"
+)
+
+func TestRequestID(t *testing.T) {
+	var (
+		captured string
+		handler  = RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = requestid.FromContext(r.Context())
+			assert.Equal(t, captured, chiMiddleware.GetReqID(r.Context()))
+		}))
+	)
+
+	t.Run("positive - request id from client", func(t *testing.T) {
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		request.Header.Set(requestid.Header, "request-1")
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "request-1", captured)
+		assert.Equal(t, "request-1", recorder.Header().Get(requestid.Header))
+	})
+
+	t.Run("positive - request id is created when missing", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.NotEmpty(t, captured)
+		assert.Equal(t, captured, recorder.Header().Get(requestid.Header))
+	})
+
+	t.Run("negative - invalid request id is replaced", func(t *testing.T) {
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		request.Header.Set(requestid.Header, "forged id\nlevel=ERROR")
+		handler.ServeHTTP(recorder, request)
+
+		assert.True(t, requestid.Valid(captured))
+		assert.NotEqual(t, "forged id\nlevel=ERROR", captured)
+		assert.Equal(t, captured, recorder.Header().Get(requestid.Header))
+	})
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/controller"
+)
+
+const (
+	// DefaultTimeout is used when a route has no timeout configured
+	DefaultTimeout = 5 * time.Second
+)
+
+// Timeout bounds the request context with the given timeout so the deadline reaches every layer
+// that receives r.Context(). The handler output is buffered and only flushed when the handler
+// finishes in time. Otherwise, a 504 APIResponse is written and any later write is discarded.
+func Timeout(timeout time.Duration) func(next http.Handler) http.Handler {
+	return TimeoutOf(func() time.Duration {
+		return timeout
+	})
+}
+
+// TimeoutOf is Timeout with the timeout looked up on every request, so a reloaded timeout applies right away
+func TimeoutOf(timeoutOf func() time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			timeout := timeoutOf()
+			if timeout <= 0 {
+				timeout = DefaultTimeout
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			var (
+				tw = &timeoutWriter{
+					w:      w,
+					header: make(http.Header),
+				}
+
+				done      = make(chan struct{})
+				panicChan = make(chan interface{}, 1)
+			)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicChan <- p
+					}
+				}()
+
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicChan:
+				// Re-panic on the serving goroutine so the recoverer middleware can handle it
+				panic(p)
+			case <-done:
+				tw.flush()
+			case <-ctx.Done():
+				tw.timeout(ctx.Err())
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// timeoutWriter buffers the handler response until it is known whether the handler finished in time
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+	buf    bytes.Buffer
+
+	mu          sync.Mutex
+	code        int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+
+	return tw.buf.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+
+	tw.writeHeaderLocked(code)
+}
+
+func (tw *timeoutWriter) writeHeaderLocked(code int) {
+	tw.wroteHeader = true
+	tw.code = code
+}
+
+// flush copies the buffered response to the underlying writer
+func (tw *timeoutWriter) flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	dst := tw.w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+
+	if !tw.wroteHeader {
+		tw.code = http.StatusOK
+	}
+
+	tw.w.WriteHeader(tw.code)
+	_, _ = tw.w.Write(tw.buf.Bytes())
+}
+
+// timeout marks the writer as timed out so the handler can no longer write,
+// then answers the client if the deadline was the reason the context ended
+func (tw *timeoutWriter) timeout(err error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+	if err != context.DeadlineExceeded {
+		// The client went away, there is nobody to answer to
+		return
+	}
+
+	controller.WriteError(tw.w, common.ErrRequestTimeout)
+}
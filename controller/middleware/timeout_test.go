@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Run("positive - handler finishes in time", func(t *testing.T) {
+		var (
+			handler = Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, hasDeadline := r.Context().Deadline()
+				assert.True(t, hasDeadline)
+
+				w.Header().Set("X-Test", "ok")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("done"))
+			}))
+
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, "ok", recorder.Header().Get("X-Test"))
+		assert.Equal(t, "done", recorder.Body.String())
+	})
+
+	t.Run("negative - deadline exceeded", func(t *testing.T) {
+		var (
+			writeErr = make(chan error, 1)
+			handler  = Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+				time.Sleep(10 * time.Millisecond)
+
+				_, err := w.Write([]byte("too late"))
+				writeErr <- err
+			}))
+
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+			response model.APIResponse
+		)
+
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+		assert.Equal(t, model.RequestTimeout.String(), response.Error.Code)
+
+		assert.Equal(t, http.ErrHandlerTimeout, <-writeErr)
+		assert.NotContains(t, recorder.Body.String(), "too late")
+	})
+
+	t.Run("negative - handler panics", func(t *testing.T) {
+		var (
+			handler = Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			}))
+
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		assert.PanicsWithValue(t, "boom", func() {
+			handler.ServeHTTP(recorder, request)
+		})
+	})
+
+	t.Run("positive - zero timeout uses default", func(t *testing.T) {
+		var (
+			handler = Timeout(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, _ := r.Context().Deadline()
+				assert.WithinDuration(t, time.Now().Add(DefaultTimeout), deadline, time.Second)
+			}))
+
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("positive - timeout is looked up on every request", func(t *testing.T) {
+		var (
+			timeout  = time.Minute
+			deadline time.Time
+
+			handler = TimeoutOf(func() time.Duration { return timeout })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, _ = r.Context().Deadline()
+			}))
+		)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+		timeout = time.Hour
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Second)
+	})
+}
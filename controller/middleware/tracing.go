@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/dityuiri/go-baseline/common/tracing"
+)
+
+// Tracing starts the server span of every request, continuing the trace of the W3C traceparent header sent by the client.
+// The span is named after the chi route pattern, only known once the request is routed, so the middleware must wrap the router.
+func Tracing(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracing.ExtractHTTP(r.Context(), r.Header)
+		ctx, span := tracing.StartServer(ctx, r.Method)
+		defer span.End()
+
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		// Requests no route matched keep the method as span name
+		if route := servedRoute(r); route != "" {
+			tracing.SetRoute(span, r.Method, route)
+		}
+
+		tracing.RecordStatus(span, sw.status())
+	}
+
+	return http.HandlerFunc(fn)
+}
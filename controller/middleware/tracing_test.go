@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-baseline/common/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	var (
+		provider = sdktrace.NewTracerProvider()
+		router   = chi.NewRouter()
+
+		traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		statusCode  = http.StatusOK
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	router.Use(Tracing)
+	router.Get("/v1/placeholder/{placeholderID}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracing.Start(r.Context(), "PlaceholderService.GetPlaceholder")
+		span.End()
+
+		w.WriteHeader(statusCode)
+	})
+
+	t.Run("positive - continues the trace of the client", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider.RegisterSpanProcessor(recorder)
+		defer provider.UnregisterSpanProcessor(recorder)
+
+		request := httptest.NewRequest(http.MethodGet, "/v1/placeholder/1", nil)
+		request.Header.Set("traceparent", traceparent)
+		router.ServeHTTP(httptest.NewRecorder(), request)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+
+		child, server := spans[0], spans[1]
+		assert.Equal(t, "GET /v1/placeholder/{placeholderID}", server.Name())
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+		assert.True(t, server.Parent().IsRemote())
+		assert.Equal(t, server.SpanContext(), child.Parent())
+		assert.Equal(t, codes.Unset, server.Status().Code)
+	})
+
+	t.Run("positive - starts a trace", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider.RegisterSpanProcessor(recorder)
+		defer provider.UnregisterSpanProcessor(recorder)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/placeholder/1", nil))
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+		assert.False(t, spans[1].Parent().IsValid())
+		assert.Equal(t, spans[1].SpanContext(), spans[0].Parent())
+	})
+
+	t.Run("negative - server error fails the span", func(t *testing.T) {
+		statusCode = http.StatusInternalServerError
+		defer func() { statusCode = http.StatusOK }()
+
+		recorder := tracetest.NewSpanRecorder()
+		provider.RegisterSpanProcessor(recorder)
+		defer provider.UnregisterSpanProcessor(recorder)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/placeholder/1", nil))
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	})
+
+	t.Run("negative - no route matched", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider.RegisterSpanProcessor(recorder)
+		defer provider.UnregisterSpanProcessor(recorder)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, http.MethodGet, spans[0].Name())
+	})
+}
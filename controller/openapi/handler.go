@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// Handler serves the OpenAPI document and the docs page rendering it.
+// The document is set once the routes are registered, before the server starts serving.
+type Handler struct {
+	document []byte
+}
+
+// SetDocument encodes the document served by Spec
+func (h *Handler) SetDocument(doc *Document) error {
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	h.document = document
+	return nil
+}
+
+// Spec serves the OpenAPI document as JSON
+func (h *Handler) Spec(w http.ResponseWriter, _ *http.Request) {
+	if h.document == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.document)
+}
+
+// Docs serves the docs page. It is self-contained, so it works without internet access.
+func (*Handler) Docs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(docsPage)
+}
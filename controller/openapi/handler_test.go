@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Spec(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			handler  = &Handler{}
+			recorder = httptest.NewRecorder()
+		)
+
+		err := handler.SetDocument(&Document{OpenAPI: Version, Info: Info{Title: "test", Version: "1.0.0"}})
+		assert.Nil(t, err)
+
+		handler.Spec(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"test","version":"1.0.0"},"paths":null,"components":{}}`, recorder.Body.String())
+	})
+
+	t.Run("negative - document is not set", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		(&Handler{}).Spec(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestHandler_Docs(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	(&Handler{}).Docs(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `fetch("openapi.json")`)
+}
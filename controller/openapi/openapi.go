@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi"
+)
+
+const (
+	// Version of the OpenAPI specification the document follows
+	Version = "3.0.3"
+
+	jsonMediaType = "application/json"
+)
+
+var (
+	ErrUndocumentedRoute = errors.New("route has no documentation")
+	ErrUnknownRoute      = errors.New("documented route is not registered")
+
+	pathParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+)
+
+type (
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Info       Info                `json:"info"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+	}
+
+	Info struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	}
+
+	// PathItem holds the operations of a path keyed by lower-case HTTP method
+	PathItem map[string]*Operation
+
+	Operation struct {
+		OperationID string                `json:"operationId,omitempty"`
+		Summary     string                `json:"summary,omitempty"`
+		Description string                `json:"description,omitempty"`
+		Tags        []string              `json:"tags,omitempty"`
+		Parameters  []Parameter           `json:"parameters,omitempty"`
+		RequestBody *RequestBody          `json:"requestBody,omitempty"`
+		Responses   map[string]Response   `json:"responses"`
+		Security    []SecurityRequirement `json:"security,omitempty"`
+	}
+
+	// SecurityRequirement maps the security scheme names to their required scopes
+	SecurityRequirement map[string][]string
+
+	SecurityScheme struct {
+		Type         string `json:"type"`
+		Scheme       string `json:"scheme,omitempty"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+		Description  string `json:"description,omitempty"`
+	}
+
+	Parameter struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required,omitempty"`
+		Deprecated  bool    `json:"deprecated,omitempty"`
+		Schema      *Schema `json:"schema,omitempty"`
+	}
+
+	RequestBody struct {
+		Required bool                 `json:"required,omitempty"`
+		Content  map[string]MediaType `json:"content"`
+	}
+
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content,omitempty"`
+	}
+
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	Components struct {
+		Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+		SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+	}
+
+	// Endpoint documents a single route. Request and Reply bodies are either
+	// models, whose schema is generated from their type, or a *Schema.
+	Endpoint struct {
+		OperationID string
+		Summary     string
+		Description string
+		Tags        []string
+		Parameters  []Parameter
+
+		// Security lists the names of the security schemes any of which authorizes the route
+		Security []string
+
+		// Request is the request body, RequestTypes defaults to application/json
+		Request      interface{}
+		RequestTypes []string
+
+		Replies map[int]Reply
+	}
+
+	Reply struct {
+		Description string
+		Body        interface{}
+	}
+
+	// Endpoints documents the routes keyed by Route(method, pattern)
+	Endpoints map[string]Endpoint
+)
+
+// Route builds the Endpoints key of the route with the given method and chi pattern
+func Route(method, pattern string) string {
+	return strings.ToUpper(method) + " " + normalizePath(pattern)
+}
+
+// Build documents every route registered on the router with its endpoint. The document is always
+// returned, the error lists the routes without endpoint and the endpoints without route.
+func Build(info Info, routes chi.Routes, endpoints Endpoints) (*Document, error) {
+	var (
+		doc = &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas: make(map[string]*Schema),
+			},
+		}
+
+		generator  = &schemaGenerator{schemas: doc.Components.Schemas}
+		documented = make(map[string]bool)
+		errs       []error
+	)
+
+	err := chi.Walk(routes, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		var (
+			path = normalizePath(route)
+			key  = Route(method, path)
+		)
+
+		endpoint, ok := endpoints[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUndocumentedRoute, key))
+			return nil
+		}
+
+		documented[key] = true
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+
+		doc.Paths[path][strings.ToLower(method)] = generator.operation(path, endpoint)
+		return nil
+	})
+	if err != nil {
+		return doc, err
+	}
+
+	for key := range endpoints {
+		if !documented[key] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownRoute, key))
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	return doc, errors.Join(errs...)
+}
+
+func (g *schemaGenerator) operation(path string, endpoint Endpoint) *Operation {
+	operation := &Operation{
+		OperationID: endpoint.OperationID,
+		Summary:     endpoint.Summary,
+		Description: endpoint.Description,
+		Tags:        endpoint.Tags,
+		Parameters:  withPathParameters(path, endpoint.Parameters),
+		Responses:   make(map[string]Response),
+	}
+
+	for _, scheme := range endpoint.Security {
+		operation.Security = append(operation.Security, SecurityRequirement{scheme: {}})
+	}
+
+	if endpoint.Request != nil {
+		requestTypes := endpoint.RequestTypes
+		if len(requestTypes) == 0 {
+			requestTypes = []string{jsonMediaType}
+		}
+
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  make(map[string]MediaType),
+		}
+
+		schema := g.schemaOf(endpoint.Request)
+		for _, requestType := range requestTypes {
+			operation.RequestBody.Content[requestType] = MediaType{Schema: schema}
+		}
+	}
+
+	for status, reply := range endpoint.Replies {
+		response := Response{Description: reply.Description}
+		if response.Description == "" {
+			response.Description = http.StatusText(status)
+		}
+
+		if reply.Body != nil {
+			response.Content = map[string]MediaType{
+				jsonMediaType: {Schema: g.schemaOf(reply.Body)},
+			}
+		}
+
+		operation.Responses[fmt.Sprint(status)] = response
+	}
+
+	return operation
+}
+
+// withPathParameters documents the path parameters of the pattern that are not documented by the endpoint
+func withPathParameters(path string, parameters []Parameter) []Parameter {
+	declared := make(map[string]bool)
+	for _, parameter := range parameters {
+		if parameter.In == "path" {
+			declared[parameter.Name] = true
+		}
+	}
+
+	result := append([]Parameter(nil), parameters...)
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		if !declared[match[1]] {
+			result = append(result, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	return result
+}
+
+// normalizePath turns a chi route pattern into an OpenAPI path,
+// dropping the trailing slash of sub-routers and parameter regexps
+func normalizePath(pattern string) string {
+	path := pathParamPattern.ReplaceAllString(pattern, "{$1}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return path
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testRequest struct {
+		Name string `json:"name" validate:"required,max=10"`
+	}
+
+	testResponse struct {
+		ID string `json:"id"`
+	}
+)
+
+func TestRoute(t *testing.T) {
+	assert.Equal(t, "GET /v1/placeholder", Route("get", "/v1/placeholder/"))
+	assert.Equal(t, "PUT /v1/placeholder/{placeholderID}", Route(http.MethodPut, "/v1/placeholder/{placeholderID:[a-f0-9-]+}/"))
+	assert.Equal(t, "GET /", Route(http.MethodGet, "/"))
+}
+
+func TestBuild(t *testing.T) {
+	var (
+		info    = Info{Title: "test", Version: "1.0.0"}
+		handler = func(w http.ResponseWriter, r *http.Request) {}
+
+		newRouter = func() chi.Router {
+			router := chi.NewRouter()
+			router.Route("/v1/test", func(r chi.Router) {
+				r.Post("/", handler)
+				r.Get("/{testID}", handler)
+			})
+
+			return router
+		}
+
+		endpoints = Endpoints{
+			Route(http.MethodPost, "/v1/test"): {
+				OperationID: "createTest",
+				Request:     testRequest{},
+				Replies: map[int]Reply{
+					http.StatusOK:         {Body: Result("test", testResponse{})},
+					http.StatusBadRequest: {Description: "Invalid request body", Body: testResponse{}},
+				},
+			},
+			Route(http.MethodGet, "/v1/test/{testID}"): {
+				OperationID: "getTest",
+				Security:    []string{"bearerAuth"},
+				Replies: map[int]Reply{
+					http.StatusNoContent: {},
+				},
+			},
+		}
+	)
+
+	t.Run("positive", func(t *testing.T) {
+		doc, err := Build(info, newRouter(), endpoints)
+		assert.Nil(t, err)
+		assert.Equal(t, Version, doc.OpenAPI)
+		assert.Len(t, doc.Paths, 2)
+
+		create := doc.Paths["/v1/test"]["post"]
+		assert.Equal(t, "createTest", create.OperationID)
+		assert.Equal(t, componentsPrefix+"testRequest", create.RequestBody.Content[jsonMediaType].Schema.Ref)
+		assert.Equal(t, "OK", create.Responses["200"].Description)
+		assert.Equal(t, componentsPrefix+"testResponse",
+			create.Responses["200"].Content[jsonMediaType].Schema.Properties["result"].Properties["test"].Ref)
+		assert.Equal(t, "Invalid request body", create.Responses["400"].Description)
+
+		get := doc.Paths["/v1/test/{testID}"]["get"]
+		assert.Equal(t, []Parameter{{Name: "testID", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, get.Parameters)
+		assert.Nil(t, get.Responses["204"].Content)
+		assert.Equal(t, []SecurityRequirement{{"bearerAuth": {}}}, get.Security)
+		assert.Nil(t, create.Security)
+
+		assert.Contains(t, doc.Components.Schemas, "testRequest")
+		assert.Contains(t, doc.Components.Schemas, "testResponse")
+	})
+
+	t.Run("negative - undocumented route", func(t *testing.T) {
+		router := newRouter()
+		router.Delete("/v1/test/{testID}", handler)
+
+		doc, err := Build(info, router, endpoints)
+		assert.ErrorIs(t, err, ErrUndocumentedRoute)
+		assert.ErrorContains(t, err, "DELETE /v1/test/{testID}")
+		assert.Len(t, doc.Paths, 2)
+	})
+
+	t.Run("negative - documented route is not registered", func(t *testing.T) {
+		router := chi.NewRouter()
+		router.Post("/v1/test", handler)
+
+		_, err := Build(info, router, endpoints)
+		assert.ErrorIs(t, err, ErrUnknownRoute)
+		assert.ErrorContains(t, err, "GET /v1/test/{testID}")
+	})
+}
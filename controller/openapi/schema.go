@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	componentsPrefix = "#/components/schemas/"
+
+	jsonTag     = "json"
+	validateTag = "validate"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+type (
+	Schema struct {
+		Ref                  string             `json:"$ref,omitempty"`
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Description          string             `json:"description,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		Enum                 []string           `json:"enum,omitempty"`
+		Pattern              string             `json:"pattern,omitempty"`
+		Minimum              *float64           `json:"minimum,omitempty"`
+		Maximum              *float64           `json:"maximum,omitempty"`
+		MinLength            *int               `json:"minLength,omitempty"`
+		MaxLength            *int               `json:"maxLength,omitempty"`
+		MinItems             *int               `json:"minItems,omitempty"`
+		MaxItems             *int               `json:"maxItems,omitempty"`
+	}
+
+	// result documents an APIResponse holding the value under the key of its result
+	result struct {
+		key   string
+		value interface{}
+	}
+
+	// schemaGenerator generates schemas from types. Named structs are added to the components.
+	schemaGenerator struct {
+		schemas map[string]*Schema
+	}
+)
+
+// Result documents a successful APIResponse whose result holds v under the given key
+func Result(key string, v interface{}) interface{} {
+	return result{key: key, value: v}
+}
+
+func (g *schemaGenerator) schemaOf(v interface{}) *Schema {
+	switch body := v.(type) {
+	case *Schema:
+		return body
+	case result:
+		return &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"result": {
+					Type:       "object",
+					Properties: map[string]*Schema{body.key: g.schemaOf(body.value)},
+				},
+			},
+		}
+	default:
+		return g.schemaFor(reflect.TypeOf(v))
+	}
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		if _, ok := g.schemas[t.Name()]; !ok {
+			// Reserve the name first so recursive types refer to themselves
+			schema := &Schema{}
+			g.schemas[t.Name()] = schema
+			*schema = *g.structSchema(t)
+		}
+
+		return &Schema{Ref: componentsPrefix + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(jsonTag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaFor(field.Type)
+		if applyRules(property, field.Tag.Get(validateTag)) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// applyRules documents the validation rules of the `validate` tag, and reports whether the field is required
+func applyRules(schema *Schema, tag string) bool {
+	var required bool
+
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch name {
+		case "required":
+			required = true
+		case "notblank":
+			schema.Pattern = `\S`
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max":
+			applyLimit(schema, name, param)
+		}
+	}
+
+	return required
+}
+
+func applyLimit(schema *Schema, rule string, param string) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	length := int(limit)
+
+	switch schema.Type {
+	case "integer", "number":
+		if rule == "min" {
+			schema.Minimum = &limit
+		} else {
+			schema.Maximum = &limit
+		}
+	case "string":
+		if rule == "min" {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "array":
+		if rule == "min" {
+			schema.MinItems = &length
+		} else {
+			schema.MaxItems = &length
+		}
+	}
+}
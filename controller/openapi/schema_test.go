@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testNode struct {
+		Name     string   `json:"name" validate:"required,notblank,min=1,max=255"`
+		Amount   int      `json:"amount" validate:"min=0,max=100"`
+		Status   string   `json:"status" validate:"oneof=active inactive"`
+		Tags     []string `json:"tags,omitempty" validate:"max=3"`
+		Labels   map[string]string
+		Created  time.Time  `json:"created_at"`
+		Owner    uuid.UUID  `json:"owner"`
+		Parent   *testNode  `json:"parent"`
+		Children []testNode `json:"children"`
+		Ignored  string     `json:"-"`
+		hidden   string
+	}
+)
+
+func TestSchemaGenerator(t *testing.T) {
+	var (
+		schemas   = make(map[string]*Schema)
+		generator = &schemaGenerator{schemas: schemas}
+	)
+
+	t.Run("positive - named struct is added to the components", func(t *testing.T) {
+		schema := generator.schemaOf(testNode{})
+		assert.Equal(t, &Schema{Ref: componentsPrefix + "testNode"}, schema)
+
+		node := schemas["testNode"]
+		assert.Equal(t, []string{"name"}, node.Required)
+		assert.NotContains(t, node.Properties, "Ignored")
+		assert.NotContains(t, node.Properties, "hidden")
+
+		var (
+			minLength, maxLength = 1, 255
+			minimum, maximum     = 0.0, 100.0
+			maxItems             = 3
+		)
+
+		assert.Equal(t, &Schema{Type: "string", Pattern: `\S`, MinLength: &minLength, MaxLength: &maxLength}, node.Properties["name"])
+		assert.Equal(t, &Schema{Type: "integer", Minimum: &minimum, Maximum: &maximum}, node.Properties["amount"])
+		assert.Equal(t, []string{"active", "inactive"}, node.Properties["status"].Enum)
+		assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}, MaxItems: &maxItems}, node.Properties["tags"])
+		assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, node.Properties["Labels"])
+		assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, node.Properties["created_at"])
+		assert.Equal(t, &Schema{Type: "string", Format: "uuid"}, node.Properties["owner"])
+		assert.Equal(t, &Schema{Ref: componentsPrefix + "testNode"}, node.Properties["parent"])
+		assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: componentsPrefix + "testNode"}}, node.Properties["children"])
+	})
+
+	t.Run("positive - schema is used as is", func(t *testing.T) {
+		schema := &Schema{Type: "object"}
+		assert.Same(t, schema, generator.schemaOf(schema))
+	})
+
+	t.Run("positive - anonymous struct and interface", func(t *testing.T) {
+		schema := generator.schemaOf(struct {
+			Value interface{} `json:"value"`
+			Data  []byte      `json:"data"`
+		}{})
+
+		assert.Equal(t, "object", schema.Type)
+		assert.Equal(t, &Schema{}, schema.Properties["value"])
+		assert.Equal(t, &Schema{Type: "string", Format: "byte"}, schema.Properties["data"])
+	})
+}
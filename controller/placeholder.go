@@ -1,22 +1,32 @@
 package controller
 
 import (
-	"github.com/dityuiri/go-baseline/model"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 
 	"github.com/dityuiri/go-adapter/logger"
 	"github.com/dityuiri/go-baseline/common"
 	"github.com/dityuiri/go-baseline/common/util"
+	"github.com/dityuiri/go-baseline/model"
 	"github.com/dityuiri/go-baseline/service"
 )
 
 type (
 	IPlaceholderController interface {
 		GetPlaceholder(w http.ResponseWriter, r *http.Request)
+		GetPlaceholderByID(w http.ResponseWriter, r *http.Request)
+		ListPlaceholders(w http.ResponseWriter, r *http.Request)
 		CreatePlaceholder(w http.ResponseWriter, r *http.Request)
+		UpdatePlaceholder(w http.ResponseWriter, r *http.Request)
+		PatchPlaceholder(w http.ResponseWriter, r *http.Request)
+		DeletePlaceholder(w http.ResponseWriter, r *http.Request)
 	}
 
 	PlaceholderController struct {
@@ -25,6 +35,10 @@ type (
 	}
 )
 
+const (
+	mergePatchMediaType = "application/merge-patch+json"
+)
+
 func (c *PlaceholderController) GetPlaceholder(w http.ResponseWriter, r *http.Request) {
 	var (
 		resp = model.APIResponse{}
@@ -34,36 +48,29 @@ func (c *PlaceholderController) GetPlaceholder(w http.ResponseWriter, r *http.Re
 	)
 
 	if queryPlaceholderID == "" {
-		errResponse := NewError(model.MissingParameter, common.ErrMissingPlaceholderID)
-		util.WriteResponse(w, errResponse, http.StatusBadRequest)
+		WriteError(w, common.ErrMissingPlaceholderID)
 		return
 	}
 
 	_, err := uuid.Parse(strings.TrimSpace(queryPlaceholderID))
 	if err != nil {
-		errResponse := NewError(model.InvalidParameter, common.ErrInvalidUUIDPlaceholderID)
-		util.WriteResponse(w, errResponse, http.StatusBadRequest)
+		WriteError(w, common.ErrInvalidUUIDPlaceholderID)
 		return
 	}
 
 	result, err := c.PlaceholderService.GetPlaceholder(ctx, queryPlaceholderID)
 	if err != nil {
-		var (
-			status = http.StatusInternalServerError
-			code   = model.InternalServerError
-		)
-
-		switch err {
-		case common.ErrPlaceholderNotFound, common.ErrAlphaProxyNotFound:
-			status = http.StatusNotFound
-			code = model.ObjectNotFound
-		}
+		WriteError(w, err)
+		return
+	}
 
-		errResponse := NewError(code, err)
-		util.WriteResponse(w, errResponse, status)
+	etag := entityTag(result.Version)
+	if notModified(r, etag) {
+		writeNotModified(w, etag)
 		return
 	}
 
+	w.Header().Set("ETag", etag)
 	resp.Result = map[string]interface{}{
 		common.PlaceholderKey: result,
 	}
@@ -76,31 +83,281 @@ func (c *PlaceholderController) CreatePlaceholder(w http.ResponseWriter, r *http
 		ctx  = r.Context()
 		resp = model.APIResponse{}
 
-		placeholderCreateRequest *model.PlaceholderCreateRequest
+		placeholderCreateRequest model.PlaceholderCreateRequest
 	)
 
-	if err := util.HttpRequestBodyParser(r, &placeholderCreateRequest); err != nil {
-		errResponse := NewError(model.InvalidRequestBody, common.ErrInvalidRequestBody)
-		util.WriteResponse(w, errResponse, http.StatusBadRequest)
+	if !BindRequest(w, r, &placeholderCreateRequest) {
 		return
 	}
 
-	createResponse, err := c.PlaceholderService.CreateNewPlaceholder(ctx, *placeholderCreateRequest)
+	createResponse, err := c.PlaceholderService.CreateNewPlaceholder(ctx, placeholderCreateRequest)
 	if err != nil {
-		var (
-			status = http.StatusInternalServerError
-			code   = model.InternalServerError
-		)
-
-		errResponse := NewError(code, err)
-		util.WriteResponse(w, errResponse, status)
+		WriteError(w, err)
 		return
 	}
 
+	w.Header().Set("ETag", entityTag(createResponse.Version))
 	resp.Result = map[string]interface{}{
 		common.PlaceholderKey: createResponse,
 	}
 
 	util.WriteResponse(w, resp, http.StatusOK)
+}
+
+func (c *PlaceholderController) GetPlaceholderByID(w http.ResponseWriter, r *http.Request) {
+	var (
+		resp = model.APIResponse{}
+		ctx  = r.Context()
+	)
+
+	placeholderID, err := c.placeholderIDFromURL(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	result, err := c.PlaceholderService.GetPlaceholder(ctx, placeholderID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	etag := entityTag(result.Version)
+	if notModified(r, etag) {
+		writeNotModified(w, etag)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	resp.Result = map[string]interface{}{
+		common.PlaceholderKey: result,
+	}
+
+	util.WriteResponse(w, resp, http.StatusOK)
+}
+
+// ListPlaceholders serves the placeholder collection. Requests that still use the
+// ?placeholder_id= query are answered by GetPlaceholder for backward compatibility.
+func (c *PlaceholderController) ListPlaceholders(w http.ResponseWriter, r *http.Request) {
+	var (
+		resp = model.APIResponse{}
+		ctx  = r.Context()
+	)
+
+	if r.URL.Query().Has("placeholder_id") {
+		c.GetPlaceholder(w, r)
+		return
+	}
+
+	listRequest, err := c.parseListRequest(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	result, err := c.PlaceholderService.ListPlaceholders(ctx, listRequest)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	resp.Result = map[string]interface{}{
+		common.PlaceholdersKey: result,
+	}
+
+	util.WriteResponse(w, resp, http.StatusOK)
+}
+
+// UpdatePlaceholder replaces a placeholder. The If-Match header has to carry the ETag of the placeholder
+// being replaced, so updates based on an outdated read are rejected.
+func (c *PlaceholderController) UpdatePlaceholder(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		resp = model.APIResponse{}
+
+		placeholderUpdateRequest model.PlaceholderUpdateRequest
+	)
+
+	placeholderID, err := c.placeholderIDFromURL(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	version, err := versionFromIfMatch(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if !BindRequest(w, r, &placeholderUpdateRequest) {
+		return
+	}
+
+	updateResponse, err := c.PlaceholderService.UpdatePlaceholder(ctx, placeholderID, version, placeholderUpdateRequest)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", entityTag(updateResponse.Version))
+	resp.Result = map[string]interface{}{
+		common.PlaceholderKey: updateResponse,
+	}
+
+	util.WriteResponse(w, resp, http.StatusOK)
+}
+
+// PatchPlaceholder partially updates a placeholder using a JSON Merge Patch (RFC 7386) body.
+// Like UpdatePlaceholder, the If-Match header has to carry the ETag of the placeholder.
+func (c *PlaceholderController) PatchPlaceholder(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		resp = model.APIResponse{}
+	)
+
+	placeholderID, err := c.placeholderIDFromURL(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	version, err := versionFromIfMatch(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediatype != mergePatchMediaType && mediatype != "application/json" {
+		WriteError(w, common.ErrUnsupportedMediaType)
+		return
+	}
+
+	defer r.Body.Close()
+	mergePatch, err := io.ReadAll(r.Body)
+	if err != nil || len(mergePatch) == 0 {
+		WriteError(w, common.ErrInvalidRequestBody)
+		return
+	}
+
+	updateResponse, err := c.PlaceholderService.PatchPlaceholder(ctx, placeholderID, version, mergePatch)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", entityTag(updateResponse.Version))
+	resp.Result = map[string]interface{}{
+		common.PlaceholderKey: updateResponse,
+	}
+
+	util.WriteResponse(w, resp, http.StatusOK)
+}
+
+func (c *PlaceholderController) DeletePlaceholder(w http.ResponseWriter, r *http.Request) {
+	var ctx = r.Context()
+
+	placeholderID, err := c.placeholderIDFromURL(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	err = c.PlaceholderService.DeletePlaceholder(ctx, placeholderID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// placeholderIDFromURL extracts and validates the {placeholderID} URL parameter
+func (*PlaceholderController) placeholderIDFromURL(r *http.Request) (string, error) {
+	placeholderID := strings.TrimSpace(chi.URLParam(r, "placeholderID"))
+	if placeholderID == "" {
+		return "", common.ErrMissingPlaceholderID
+	}
+
+	if _, err := uuid.Parse(placeholderID); err != nil {
+		return "", common.ErrInvalidUUIDPlaceholderID
+	}
+
+	return placeholderID, nil
+}
+
+func (*PlaceholderController) parseListRequest(r *http.Request) (model.PlaceholderListRequest, error) {
+	var (
+		query       = r.URL.Query()
+		listRequest = model.PlaceholderListRequest{
+			NamePrefix: strings.TrimSpace(query.Get("name_prefix")),
+			SortBy:     strings.TrimSpace(query.Get("sort_by")),
+			SortOrder:  strings.ToLower(strings.TrimSpace(query.Get("sort_order"))),
+			Page:       common.DefaultPage,
+			PageSize:   common.DefaultPageSize,
+		}
+	)
+
+	if page := query.Get("page"); page != "" {
+		value, err := strconv.Atoi(page)
+		if err != nil || value < 1 {
+			return listRequest, common.ErrInvalidPagination
+		}
+
+		listRequest.Page = value
+	}
+
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		value, err := strconv.Atoi(pageSize)
+		if err != nil || value < 1 || value > common.MaxPageSize {
+			return listRequest, common.ErrInvalidPagination
+		}
+
+		listRequest.PageSize = value
+	}
+
+	for param, target := range map[string]**int{
+		"min_amount": &listRequest.MinAmount,
+		"max_amount": &listRequest.MaxAmount,
+	} {
+		if raw := query.Get(param); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return listRequest, common.ErrInvalidAmountRange
+			}
+
+			*target = &value
+		}
+	}
+
+	if listRequest.MinAmount != nil && listRequest.MaxAmount != nil && *listRequest.MinAmount > *listRequest.MaxAmount {
+		return listRequest, common.ErrInvalidAmountRange
+	}
+
+	for param, target := range map[string]**time.Time{
+		"created_from": &listRequest.CreatedFrom,
+		"created_to":   &listRequest.CreatedTo,
+	} {
+		if raw := query.Get(param); raw != "" {
+			value, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return listRequest, common.ErrInvalidCreatedAtRange
+			}
+
+			*target = &value
+		}
+	}
+
+	if listRequest.CreatedFrom != nil && listRequest.CreatedTo != nil && listRequest.CreatedFrom.After(*listRequest.CreatedTo) {
+		return listRequest, common.ErrInvalidCreatedAtRange
+	}
+
+	switch listRequest.SortOrder {
+	case "", common.SortOrderAsc, common.SortOrderDesc:
+	default:
+		return listRequest, common.ErrInvalidSortParameter
+	}
 
+	return listRequest, nil
 }
@@ -4,13 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/validator"
 	"github.com/dityuiri/go-baseline/model"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 
 	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
 	"github.com/dityuiri/go-baseline/mock"
@@ -40,12 +43,26 @@ func TestPlaceholderController_GetPlaceholder(t *testing.T) {
 	t.Run("positive", func(t *testing.T) {
 		router.Get(baseRoute, placeholderController.GetPlaceholder)
 
-		mockWriter.EXPECT().Header().Return(http.Header{})
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header).Times(2)
 		mockWriter.EXPECT().WriteHeader(http.StatusOK)
 		mockWriter.EXPECT().Write(gomock.Any())
-		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{}, nil)
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{Version: 2}, nil)
+
+		request, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(mockWriter, request)
+		assert.Equal(t, `"2"`, header.Get("ETag"))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		router.Get(baseRoute, placeholderController.GetPlaceholder)
+
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusNotModified)
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{Version: 2}, nil)
 
 		request, _ := http.NewRequest("GET", url, nil)
+		request.Header.Set("If-None-Match", `"2"`)
 		router.ServeHTTP(mockWriter, request)
 	})
 
@@ -96,3 +113,545 @@ func TestPlaceholderController_GetPlaceholder(t *testing.T) {
 		router.ServeHTTP(mockWriter, request)
 	})
 }
+
+func TestPlaceholderController_CreatePlaceholder(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		body = `{"name":"Aoi","amount":10000}`
+	)
+
+	defer mockCtrl.Finish()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest("POST", "/v1/placeholder", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header).Times(2)
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().CreateNewPlaceholder(gomock.Any(), model.PlaceholderCreateRequest{
+			Name:   "Aoi",
+			Amount: 10000,
+		}).Return(model.PlaceholderCreateResponse{Version: 1}, nil)
+
+		placeholderController.CreatePlaceholder(mockWriter, newRequest(body))
+		assert.Equal(t, `"1"`, header.Get("ETag"))
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		placeholderController.CreatePlaceholder(mockWriter, newRequest("potato"))
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusUnprocessableEntity)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		placeholderController.CreatePlaceholder(mockWriter, newRequest(`{"amount":-1}`))
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().CreateNewPlaceholder(gomock.Any(), gomock.Any()).Return(model.PlaceholderCreateResponse{}, errors.New("error"))
+
+		placeholderController.CreatePlaceholder(mockWriter, newRequest(body))
+	})
+}
+
+func TestPlaceholderController_GetPlaceholderByID(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		route         = "/v1/placeholder/{placeholderID}"
+		placeholderID = uuid.New()
+		url           = fmt.Sprintf("/v1/placeholder/%v", placeholderID)
+		router        = chi.NewRouter()
+	)
+
+	defer mockCtrl.Finish()
+
+	router.Get(route, placeholderController.GetPlaceholderByID)
+
+	t.Run("positive", func(t *testing.T) {
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header).Times(2)
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{Version: 2}, nil)
+
+		request, _ := http.NewRequest("GET", url, nil)
+		request.Header.Set("If-None-Match", `"1"`)
+		router.ServeHTTP(mockWriter, request)
+		assert.Equal(t, `"2"`, header.Get("ETag"))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header)
+		mockWriter.EXPECT().WriteHeader(http.StatusNotModified)
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{Version: 2}, nil)
+
+		request, _ := http.NewRequest("GET", url, nil)
+		request.Header.Set("If-None-Match", `"1", W/"2"`)
+		router.ServeHTTP(mockWriter, request)
+		assert.Equal(t, `"2"`, header.Get("ETag"))
+	})
+
+	t.Run("non uuid placeholder id", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		request, _ := http.NewRequest("GET", "/v1/placeholder/sausage", nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{}, common.ErrPlaceholderNotFound)
+
+		request, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{}, errors.New("error"))
+
+		request, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+}
+
+func TestPlaceholderController_ListPlaceholders(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		baseRoute = "/v1/placeholder"
+		router    = chi.NewRouter()
+	)
+
+	defer mockCtrl.Finish()
+
+	router.Get(baseRoute, placeholderController.ListPlaceholders)
+
+	t.Run("positive", func(t *testing.T) {
+		var (
+			url       = baseRoute + "?name_prefix=Ao&min_amount=10&max_amount=100&created_from=2024-01-01T00:00:00Z&sort_by=amount&sort_order=ASC&page=2&page_size=10"
+			minAmount = 10
+			maxAmount = 100
+		)
+
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().ListPlaceholders(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, listRequest model.PlaceholderListRequest) (model.PlaceholderListResponse, error) {
+				assert.Equal(t, "Ao", listRequest.NamePrefix)
+				assert.Equal(t, &minAmount, listRequest.MinAmount)
+				assert.Equal(t, &maxAmount, listRequest.MaxAmount)
+				assert.NotNil(t, listRequest.CreatedFrom)
+				assert.Nil(t, listRequest.CreatedTo)
+				assert.Equal(t, "amount", listRequest.SortBy)
+				assert.Equal(t, common.SortOrderAsc, listRequest.SortOrder)
+				assert.Equal(t, 2, listRequest.Page)
+				assert.Equal(t, 10, listRequest.PageSize)
+				return model.PlaceholderListResponse{}, nil
+			})
+
+		request, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("positive - default pagination", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().ListPlaceholders(gomock.Any(), model.PlaceholderListRequest{
+			Page:     common.DefaultPage,
+			PageSize: common.DefaultPageSize,
+		}).Return(model.PlaceholderListResponse{}, nil)
+
+		request, _ := http.NewRequest("GET", baseRoute, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("positive - legacy placeholder_id query", func(t *testing.T) {
+		placeholderID := uuid.New()
+
+		mockWriter.EXPECT().Header().Return(http.Header{}).Times(2)
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().GetPlaceholder(gomock.Any(), placeholderID.String()).Return(model.PlaceholderGetResponse{}, nil)
+
+		request, _ := http.NewRequest("GET", fmt.Sprintf("%s?placeholder_id=%v", baseRoute, placeholderID), nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	for name, query := range map[string]string{
+		"invalid page":            "?page=0",
+		"page size too big":       "?page_size=1000",
+		"invalid amount":          "?min_amount=ten",
+		"inverted amount range":   "?min_amount=100&max_amount=10",
+		"invalid created at":      "?created_from=yesterday",
+		"inverted created window": "?created_from=2024-02-01T00:00:00Z&created_to=2024-01-01T00:00:00Z",
+		"invalid sort order":      "?sort_order=sideways",
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockWriter.EXPECT().Header().Return(http.Header{})
+			mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+			mockWriter.EXPECT().Write(gomock.Any())
+
+			request, _ := http.NewRequest("GET", baseRoute+query, nil)
+			router.ServeHTTP(mockWriter, request)
+		})
+	}
+
+	t.Run("invalid sort column", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().ListPlaceholders(gomock.Any(), gomock.Any()).Return(model.PlaceholderListResponse{}, common.ErrInvalidSortParameter)
+
+		request, _ := http.NewRequest("GET", baseRoute+"?sort_by=potato", nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().ListPlaceholders(gomock.Any(), gomock.Any()).Return(model.PlaceholderListResponse{}, errors.New("error"))
+
+		request, _ := http.NewRequest("GET", baseRoute, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+}
+
+func TestPlaceholderController_UpdatePlaceholder(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		route         = "/v1/placeholder/{placeholderID}"
+		placeholderID = uuid.New()
+		url           = fmt.Sprintf("/v1/placeholder/%v", placeholderID)
+		body          = `{"name":"Aoi","amount":10000}`
+		router        = chi.NewRouter()
+	)
+
+	defer mockCtrl.Finish()
+
+	router.Put(route, placeholderController.UpdatePlaceholder)
+
+	newRequest := func(url string, body string) *http.Request {
+		request, _ := http.NewRequest("PUT", url, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("If-Match", `"3"`)
+		return request
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header).Times(2)
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().UpdatePlaceholder(gomock.Any(), placeholderID.String(), int64(3), model.PlaceholderUpdateRequest{
+			Name:   "Aoi",
+			Amount: 10000,
+		}).Return(model.PlaceholderUpdateResponse{Version: 4}, nil)
+
+		router.ServeHTTP(mockWriter, newRequest(url, body))
+		assert.Equal(t, `"4"`, header.Get("ETag"))
+	})
+
+	t.Run("missing if-match", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusPreconditionRequired)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		request := newRequest(url, body)
+		request.Header.Del("If-Match")
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("if-match not holding a placeholder etag", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusPreconditionFailed)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		request := newRequest(url, body)
+		request.Header.Set("If-Match", `W/"3"`)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("placeholder modified meanwhile", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusPreconditionFailed)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().UpdatePlaceholder(gomock.Any(), placeholderID.String(), int64(3), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, common.ErrVersionMismatch)
+
+		router.ServeHTTP(mockWriter, newRequest(url, body))
+	})
+
+	t.Run("non uuid placeholder id", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest("/v1/placeholder/sausage", body))
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest(url, "potato"))
+	})
+
+	t.Run("null request body", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusUnprocessableEntity)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest(url, "null"))
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusUnprocessableEntity)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest(url, `{"name":" ","amount":-1}`))
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().UpdatePlaceholder(gomock.Any(), placeholderID.String(), gomock.Any(), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, common.ErrPlaceholderNotFound)
+
+		router.ServeHTTP(mockWriter, newRequest(url, body))
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().UpdatePlaceholder(gomock.Any(), placeholderID.String(), gomock.Any(), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, errors.New("error"))
+
+		router.ServeHTTP(mockWriter, newRequest(url, body))
+	})
+}
+
+func TestPlaceholderController_PatchPlaceholder(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		route         = "/v1/placeholder/{placeholderID}"
+		placeholderID = uuid.New()
+		url           = fmt.Sprintf("/v1/placeholder/%v", placeholderID)
+		body          = `{"amount":25000}`
+		router        = chi.NewRouter()
+	)
+
+	defer mockCtrl.Finish()
+
+	router.Patch(route, placeholderController.PatchPlaceholder)
+
+	newRequest := func(url string, body string, contentType string) *http.Request {
+		request, _ := http.NewRequest("PATCH", url, strings.NewReader(body))
+		request.Header.Set("Content-Type", contentType)
+		request.Header.Set("If-Match", `"3"`)
+		return request
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		header := http.Header{}
+		mockWriter.EXPECT().Header().Return(header).Times(2)
+		mockWriter.EXPECT().WriteHeader(http.StatusOK)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().PatchPlaceholder(gomock.Any(), placeholderID.String(), int64(3), []byte(body)).Return(model.PlaceholderUpdateResponse{Version: 4}, nil)
+
+		router.ServeHTTP(mockWriter, newRequest(url, body, mergePatchMediaType))
+		assert.Equal(t, `"4"`, header.Get("ETag"))
+	})
+
+	t.Run("missing if-match", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusPreconditionRequired)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		request := newRequest(url, body, mergePatchMediaType)
+		request.Header.Del("If-Match")
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("non uuid placeholder id", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest("/v1/placeholder/sausage", body, mergePatchMediaType))
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusUnsupportedMediaType)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest(url, body, "text/plain"))
+	})
+
+	t.Run("empty request body", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		router.ServeHTTP(mockWriter, newRequest(url, "", mergePatchMediaType))
+	})
+
+	t.Run("invalid merge patch", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().PatchPlaceholder(gomock.Any(), placeholderID.String(), gomock.Any(), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, common.ErrInvalidRequestBody)
+
+		router.ServeHTTP(mockWriter, newRequest(url, "potato", mergePatchMediaType))
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusUnprocessableEntity)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().PatchPlaceholder(gomock.Any(), placeholderID.String(), gomock.Any(), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, validator.ValidationErrors{
+			{Field: "amount", Rule: "min", Param: "0", Message: "amount must be at least 0"},
+		})
+
+		router.ServeHTTP(mockWriter, newRequest(url, `{"amount":-1}`, mergePatchMediaType))
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().PatchPlaceholder(gomock.Any(), placeholderID.String(), gomock.Any(), gomock.Any()).Return(model.PlaceholderUpdateResponse{}, common.ErrPlaceholderNotFound)
+
+		router.ServeHTTP(mockWriter, newRequest(url, body, "application/json"))
+	})
+}
+
+func TestPlaceholderController_DeletePlaceholder(t *testing.T) {
+	var (
+		mockCtrl               = gomock.NewController(t)
+		mockLogger             = loggerMock.NewMockILogger(mockCtrl)
+		mockWriter             = mock.NewMockResponseWriter(mockCtrl)
+		mockPlaceholderService = serviceMock.NewMockIPlaceholderService(mockCtrl)
+
+		placeholderController = PlaceholderController{
+			Logger:             mockLogger,
+			PlaceholderService: mockPlaceholderService,
+		}
+
+		route         = "/v1/placeholder/{placeholderID}"
+		placeholderID = uuid.New()
+		url           = fmt.Sprintf("/v1/placeholder/%v", placeholderID)
+		router        = chi.NewRouter()
+	)
+
+	defer mockCtrl.Finish()
+
+	router.Delete(route, placeholderController.DeletePlaceholder)
+
+	t.Run("positive", func(t *testing.T) {
+		mockWriter.EXPECT().WriteHeader(http.StatusNoContent)
+		mockPlaceholderService.EXPECT().DeletePlaceholder(gomock.Any(), placeholderID.String()).Return(nil)
+
+		request, _ := http.NewRequest("DELETE", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("non uuid placeholder id", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		mockWriter.EXPECT().Write(gomock.Any())
+
+		request, _ := http.NewRequest("DELETE", "/v1/placeholder/sausage", nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().DeletePlaceholder(gomock.Any(), placeholderID.String()).Return(common.ErrPlaceholderNotFound)
+
+		request, _ := http.NewRequest("DELETE", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockWriter.EXPECT().Header().Return(http.Header{})
+		mockWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		mockWriter.EXPECT().Write(gomock.Any())
+		mockPlaceholderService.EXPECT().DeletePlaceholder(gomock.Any(), placeholderID.String()).Return(errors.New("error"))
+
+		request, _ := http.NewRequest("DELETE", url, nil)
+		router.ServeHTTP(mockWriter, request)
+	})
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS placeholder (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    amount     INTEGER      NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_placeholder_name ON placeholder (name text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_placeholder_created_at ON placeholder (created_at);
@@ -0,0 +1 @@
+ALTER TABLE placeholder DROP COLUMN IF EXISTS version;
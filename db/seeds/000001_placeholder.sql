@@ -0,0 +1,4 @@
+INSERT INTO placeholder (id, name, amount, created_by, updated_by)
+VALUES ('7f1d5a3e-2b4c-4e8a-9c1d-0a6b3e5f7d21', 'first placeholder', 100, 'seed', 'seed'),
+       ('b2c4e6f8-1a3d-4b5e-8f7a-9c0d2e4f6a81', 'second placeholder', 250, 'seed', 'seed')
+ON CONFLICT (id) DO NOTHING;
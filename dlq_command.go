@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka/consumer"
+
+	"github.com/dityuiri/go-baseline/application"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/model"
+	"github.com/dityuiri/go-baseline/repository"
+)
+
+// dlqGroupSuffix names the consumer group of the dlq command after KAFKA_GROUP_ID. The command never commits,
+// so every run lists the dead letters from the oldest one.
+const dlqGroupSuffix = "-dlq-inspect"
+
+func dlqCommand() *command {
+	return &command{
+		name:  "dlq",
+		args:  "[topic]",
+		short: "List the messages of the dead letter topic of a consumed topic, placeholder by default",
+		help: "The topic is the logical name of CONSUMER_TOPICS, its dead letter topic is {topic}_dlq of PRODUCER_TOPICS.\n" +
+			"The messages are read without committing, they stay in the dead letter topic.\n" +
+			"Exits 0 once listed, 1 when the dead letter topic can't be read.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			limit := flags.Int("limit", 20, "maximum number of messages to list")
+			wait := flags.Duration("wait", 5*time.Second, "stop once no message comes within this wait")
+			asJSON := flags.Bool("json", false, "print each message as a line of JSON")
+
+			return func(ctx context.Context, s streams, args []string) int {
+				if len(args) > 1 {
+					_, _ = fmt.Fprintln(s.err, "usage: dlq [flags] [topic]")
+					return exitUsage
+				}
+
+				name := common.TopicPlaceholder
+				if len(args) > 0 {
+					name = args[0]
+				}
+
+				app, err := application.SetupApplication(ctx)
+				if err != nil {
+					_, _ = fmt.Fprintf(s.err, "failed to set up the app: %s\n", err)
+					return exitFailure
+				}
+
+				defer app.Close()
+
+				// The configuration of the app is shared, the consumer gets its own group
+				consumerConfig := *app.Config.Kafka.Consumer
+				consumerConfig.GroupID += dlqGroupSuffix
+				consumerConfig.StartOffset = consumer.FirstOffset
+
+				kafkaConsumer := consumer.NewConsumer(&consumerConfig)
+				defer kafkaConsumer.Close()
+
+				reader := &repository.DeadLetterConsumer{Consumer: kafkaConsumer, KafkaConfig: app.Config.Kafka}
+
+				return listDeadLetters(ctx, s, reader, name, *limit, *wait, *asJSON)
+			}
+		},
+	}
+}
+
+// listDeadLetters prints the dead letters of the topic until limit are printed, or none comes within wait
+func listDeadLetters(ctx context.Context, s streams, reader repository.IDeadLetterConsumer, name string, limit int, wait time.Duration, asJSON bool) int {
+	listed := 0
+	for listed < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, wait)
+		letter, err := reader.FetchDeadLetter(fetchCtx, name)
+		cancel()
+
+		switch {
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF):
+			_, _ = fmt.Fprintf(s.out, "%d dead letters listed\n", listed)
+			return exitOK
+		case errors.Is(err, common.ErrNotDeadLetter):
+			_, _ = fmt.Fprintf(s.err, "skipped %s\n", err)
+			continue
+		case err != nil:
+			_, _ = fmt.Fprintf(s.err, "dlq: %s\n", err)
+			return exitFailure
+		}
+
+		if asJSON {
+			_ = json.NewEncoder(s.out).Encode(newDeadLetterView(letter))
+		} else {
+			printDeadLetter(s.out, letter)
+		}
+
+		listed++
+	}
+
+	_, _ = fmt.Fprintf(s.out, "%d dead letters listed, more may follow, raise -limit to list them\n", listed)
+
+	return exitOK
+}
+
+// deadLetterView is the JSON of a dead letter. The value is kept as JSON when it is JSON, as text otherwise.
+type deadLetterView struct {
+	Topic     string            `json:"topic"`
+	Partition int               `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Error     string            `json:"error"`
+	Failures  int               `json:"failures"`
+	FailedAt  time.Time         `json:"failed_at"`
+	Key       string            `json:"key,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Value     json.RawMessage   `json:"value"`
+}
+
+func newDeadLetterView(letter model.DeadLetter) deadLetterView {
+	view := deadLetterView{
+		Topic:     letter.Topic,
+		Partition: letter.Partition,
+		Offset:    letter.Offset,
+		Error:     letter.Error,
+		Failures:  letter.Failures,
+		FailedAt:  letter.FailedAt,
+		Key:       string(letter.Key),
+		Value:     letter.Value,
+	}
+
+	if !json.Valid(letter.Value) {
+		view.Value, _ = json.Marshal(string(letter.Value))
+	}
+
+	if len(letter.Headers) > 0 {
+		view.Headers = make(map[string]string, len(letter.Headers))
+		for key, value := range letter.Headers {
+			view.Headers[key] = string(value)
+		}
+	}
+
+	return view
+}
+
+// printDeadLetter writes the dead letter for a reader, with its value indented when it is JSON
+func printDeadLetter(out io.Writer, letter model.DeadLetter) {
+	_, _ = fmt.Fprintf(out, "%s[%d]@%d failed %d times at %s\n", letter.Topic, letter.Partition, letter.Offset,
+		letter.Failures, letter.FailedAt.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(out, "  error: %s\n", letter.Error)
+
+	if len(letter.Key) > 0 {
+		_, _ = fmt.Fprintf(out, "  key:   %s\n", letter.Key)
+	}
+
+	keys := make([]string, 0, len(letter.Headers))
+	for key := range letter.Headers {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(out, "  header %s: %s\n", key, letter.Headers[key])
+	}
+
+	var value bytes.Buffer
+	if err := json.Indent(&value, letter.Value, "    ", "  "); err != nil {
+		value.Reset()
+		value.Write(letter.Value)
+	}
+
+	_, _ = fmt.Fprintf(out, "  value:\n    %s\n\n", value.String())
+}
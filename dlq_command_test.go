@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestListDeadLetters(t *testing.T) {
+	var (
+		mockCtrl        = gomock.NewController(t)
+		mockDeadLetters = repositoryMock.NewMockIDeadLetterConsumer(mockCtrl)
+
+		ctx    = context.Background()
+		letter = model.DeadLetter{
+			Topic:     "placeholder-record",
+			Partition: 1,
+			Offset:    42,
+			Key:       []byte("key"),
+			Value:     []byte(`{"id":"1"}`),
+			Headers:   map[string][]byte{"request_id": []byte("request-1")},
+			Error:     "alpha unavailable",
+			Failures:  4,
+			FailedAt:  time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC),
+		}
+		newStream = func() (*bytes.Buffer, *bytes.Buffer, streams) {
+			var out, errOut bytes.Buffer
+			return &out, &errOut, streams{out: &out, err: &errOut}
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - pretty printed until no message comes", func(t *testing.T) {
+		out, _, s := newStream()
+		text := letter
+		text.Value = []byte("not json")
+
+		gomock.InOrder(
+			mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").Return(letter, nil),
+			mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").Return(text, nil),
+			mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").Return(model.DeadLetter{}, context.DeadlineExceeded),
+		)
+
+		assert.Equal(t, exitOK, listDeadLetters(ctx, s, mockDeadLetters, "placeholder", 20, time.Second, false))
+		assert.Equal(t, `placeholder-record[1]@42 failed 4 times at 2026-10-18T08:30:00Z
+  error: alpha unavailable
+  key:   key
+  header request_id: request-1
+  value:
+    {
+      "id": "1"
+    }
+
+placeholder-record[1]@42 failed 4 times at 2026-10-18T08:30:00Z
+  error: alpha unavailable
+  key:   key
+  header request_id: request-1
+  value:
+    not json
+
+2 dead letters listed
+`, out.String())
+	})
+
+	t.Run("positive - json lines up to the limit", func(t *testing.T) {
+		out, _, s := newStream()
+		mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").Return(letter, nil).Times(1)
+
+		assert.Equal(t, exitOK, listDeadLetters(ctx, s, mockDeadLetters, "placeholder", 1, time.Second, true))
+		assert.Equal(t, `{"topic":"placeholder-record","partition":1,"offset":42,"error":"alpha unavailable","failures":4,`+
+			`"failed_at":"2026-10-18T08:30:00Z","key":"key","headers":{"request_id":"request-1"},"value":{"id":"1"}}`+"\n"+
+			"1 dead letters listed, more may follow, raise -limit to list them\n", out.String())
+	})
+
+	t.Run("positive - messages which aren't dead letters are skipped", func(t *testing.T) {
+		out, errOut, s := newStream()
+
+		gomock.InOrder(
+			mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").
+				Return(model.DeadLetter{}, fmt.Errorf("placeholder_dlq[0]3: %w", common.ErrNotDeadLetter)),
+			mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "placeholder").Return(model.DeadLetter{}, context.DeadlineExceeded),
+		)
+
+		assert.Equal(t, exitOK, listDeadLetters(ctx, s, mockDeadLetters, "placeholder", 20, time.Second, false))
+		assert.Equal(t, "0 dead letters listed\n", out.String())
+		assert.Contains(t, errOut.String(), "skipped placeholder_dlq[0]3")
+	})
+
+	t.Run("negative - fetch failed", func(t *testing.T) {
+		_, errOut, s := newStream()
+		mockDeadLetters.EXPECT().FetchDeadLetter(gomock.Any(), "audit").Return(model.DeadLetter{}, common.ErrDeadLetterTopicNotFound)
+
+		assert.Equal(t, exitFailure, listDeadLetters(ctx, s, mockDeadLetters, "audit", 20, time.Second, false))
+		assert.Contains(t, errOut.String(), "dlq: ")
+	})
+
+	t.Run("negative - unexpected arguments", func(t *testing.T) {
+		_, errOut, s := newStream()
+
+		assert.Equal(t, exitUsage, runCLI(ctx, []string{"dlq", "placeholder", "audit"}, s))
+		assert.Contains(t, errOut.String(), "usage: dlq")
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dityuiri/go-baseline/config"
+)
+
+func healthcheckCommand() *command {
+	return &command{
+		name:  "healthcheck",
+		short: "Check the readiness of a running app",
+		help: "Gets /readyz of the admin server, for the HEALTHCHECK of the container image which has no curl.\n" +
+			"Exits 0 when the app is ready, 1 otherwise.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			url := flags.String("url", "", "URL of the readiness probe, /readyz on ADMIN_PORT of localhost by default")
+			timeout := flags.Duration("timeout", 2*time.Second, "time to wait for the answer")
+
+			return func(ctx context.Context, s streams, _ []string) int {
+				target := *url
+				if target == "" {
+					configuration, err := config.LoadConfiguration()
+					if err != nil {
+						_, _ = fmt.Fprintf(s.err, "no -url and the configuration is invalid: %s\n", err)
+						return exitFailure
+					}
+
+					target = fmt.Sprintf("http://localhost:%d/readyz", configuration.Const.AdminPort)
+				}
+
+				return healthcheck(ctx, s, target, *timeout)
+			}
+		},
+	}
+}
+
+// healthcheck gets the URL and returns exitOK when it answers 200 OK
+func healthcheck(ctx context.Context, s streams, url string, timeout time.Duration) int {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "invalid url %q: %s\n", url, err)
+		return exitUsage
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "%s is unreachable: %s\n", url, err)
+		return exitFailure
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		_, _ = fmt.Fprintf(s.err, "%s answered %s\n", url, response.Status)
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(s.out, "%s answered %s\n", url, response.Status)
+
+	return exitOK
+}
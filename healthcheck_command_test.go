@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthcheckCommand(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		ready = true
+
+		probe = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if !ready {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+	)
+
+	defer probe.Close()
+
+	t.Run("positive - ready", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"healthcheck", "-url", probe.URL}, streams{out: &out, err: &out}))
+		assert.Equal(t, probe.URL+" answered 200 OK\n", out.String())
+	})
+
+	t.Run("negative - not ready", func(t *testing.T) {
+		var out bytes.Buffer
+
+		ready = false
+		defer func() { ready = true }()
+
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"healthcheck", "-url", probe.URL}, streams{out: &out, err: &out}))
+		assert.Equal(t, probe.URL+" answered 503 Service Unavailable\n", out.String())
+	})
+
+	t.Run("negative - unreachable", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"healthcheck", "-url", "http://127.0.0.1:1/readyz"}, streams{out: &out, err: &out}))
+		assert.Contains(t, out.String(), "is unreachable")
+	})
+
+	t.Run("negative - invalid configuration without url", func(t *testing.T) {
+		var out bytes.Buffer
+
+		t.Setenv("CONFIG_DIR", t.TempDir())
+		t.Setenv("ENVIRONMENT", "staging")
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"healthcheck"}, streams{out: &out, err: &out}))
+		assert.Contains(t, out.String(), "no -url and the configuration is invalid")
+	})
+}
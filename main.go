@@ -2,198 +2,254 @@ package main
 
 import (
 	"context"
-	"io"
-	"log"
 	"net/http"
+	"net/http/pprof"
 	"os"
-	"os/signal"
 	"runtime"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/go-chi/chi"
 
+	logOption "github.com/dityuiri/go-adapter/logger/log"
 	"github.com/dityuiri/go-adapter/server"
 	"github.com/dityuiri/go-baseline/application"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/consumer"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/retry"
+	"github.com/dityuiri/go-baseline/config"
 	"github.com/dityuiri/go-baseline/controller"
+	"github.com/dityuiri/go-baseline/controller/middleware"
+	"github.com/dityuiri/go-baseline/controller/openapi"
 )
 
-const (
-	clientMode = "client"
-
-	// Uncomment if you want to use kafka consumer
-	// consumerMode = "consumer"
-
-	defaultTimeout = 5 * time.Second
-)
+// consumerBackoffJitter randomizes the restart backoff of the consumers, so replicas don't restart in lockstep
+const consumerBackoffJitter = 0.2
 
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
-	var (
-		args = os.Args[1:]
-		mode string
-	)
-
-	if len(args) > 0 {
-		mode = os.Args[1]
-	}
+	os.Exit(runCLI(context.Background(), os.Args[1:], streams{out: os.Stdout, err: os.Stderr}))
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	app, err := application.SetupApplication(ctx)
-	if err != nil {
-		panic(err)
+func serveHTTP(app *application.App, dep *application.Dependency, port int) server.IServer {
+	config := &server.Configuration{
+		AppName: app.Config.AppName,
+		Port:    port,
 	}
 
-	defer app.Close()
-
-	// Setup dependency injection
-	dep := application.SetupDependency(app)
+	httpServer := server.NewServer(app.Context, config)
 
-	// Goroutine to cancel when Os interrupt happens
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
-		<-c
-		log.Println("Received system interrupt. Stopping servers...")
-		cancel()
-	}()
+	controllers := httpControllers{
+		HealthCheck: &controller.HealthCheckController{
+			HealthCheckService: dep.HealthCheckService,
+		},
+		Placeholder: &controller.PlaceholderController{
+			Logger:             app.Logger,
+			PlaceholderService: dep.PlaceholderService,
+		},
+		Docs: &openapi.Handler{},
+	}
 
-	switch mode {
-	case clientMode:
-		var (
-			httpServer = serveHTTP(app, dep)
+	var guards []func(http.Handler) http.Handler
+	if app.Verifier != nil {
+		guards = append(guards,
+			middleware.Authenticate(app.Verifier, app.Logger),
+			middleware.Authorize(apiPolicy, app.Logger),
 		)
-		if err := httpServer.Serve(); err != nil {
-			panic(err)
-		}
-
-		<-app.Context.Done()
-		_ = httpServer.Close()
-
-	// Uncomment if you want to use kafka consumer
-	//case consumerMode:
-	//	consumeKafkaMessages(app, dep)
+	} else {
+		app.Logger.Warn("authentication is disabled, set AUTH_ENABLED to require bearer tokens")
+	}
 
-	default: // All services run in this mode as default
-		var (
-			httpServer = serveHTTP(app, dep)
-		)
+	routes := routeMiddlewares{
+		live:    app.LiveConfig,
+		metrics: app.Metrics,
+		idempotency: &middleware.Idempotency{
+			Cache:  dep.IdempotencyCache,
+			Config: app.Config.Idempotency,
+			Logger: app.Logger,
+		},
+	}
 
-		if err := httpServer.Serve(); err != nil {
-			panic(err)
+	if dep.RateLimiter != nil {
+		routes.rateLimiter = &middleware.RateLimiter{
+			Limiter: dep.RateLimiter,
+			Config:  app.LiveConfig,
+			Logger:  app.Logger,
 		}
+	}
 
-		// Uncomment if you want to use kafka consumer
-		//consumeKafkaMessages(app, dep)
+	registerRoutes(httpServer.GetRouter(), routes, controllers, guards...)
 
-		<-app.Context.Done()
-		_ = httpServer.Close()
+	// The document is built from the registered routes, undocumented ones are left out
+	doc, err := buildAPIDocument(app.Config.AppName, httpServer.GetRouter())
+	if err != nil {
+		app.Logger.Warn("api document is incomplete", logOption.WithError(err))
 	}
-}
 
-func serveHTTP(app *application.App, dep *application.Dependency) server.IServer {
-	var shortTimeout = time.Duration(app.Config.Const.ShortTimeout) * time.Second
+	if err = controllers.Docs.SetDocument(doc); err != nil {
+		app.Logger.Error("failed to encode api document", logOption.WithError(err))
+	}
+
+	return httpServer
+}
 
+// serveAdmin builds the server of the operational endpoints, listening away from the public traffic
+func serveAdmin(app *application.App, dep *application.Dependency, port int) server.IServer {
 	config := &server.Configuration{
 		AppName: app.Config.AppName,
-		Port:    app.Config.Const.HTTPPort,
+		Port:    port,
 	}
 
-	httpServer := server.NewServer(app.Context, config)
+	adminServer := server.NewServer(app.Context, config)
 
-	healthCheckController := &controller.HealthCheckController{
+	registerAdminRoutes(adminServer.GetRouter(), app.Metrics, &controller.HealthCheckController{
 		HealthCheckService: dep.HealthCheckService,
-	}
+	}, &controller.AdminController{
+		Logger:        app.Logger,
+		LogLevel:      app.LogLevel,
+		Configuration: app.LiveConfig,
+	})
 
-	placeholderController := &controller.PlaceholderController{
-		Logger:             app.Logger,
-		PlaceholderService: dep.PlaceholderService,
-	}
+	return adminServer
+}
 
-	// Endpoint Routing
-	httpServer.Get("/ping", healthCheckController.Ping)
+// registerAdminRoutes registers the operational routes. They are meant for operators only, so the admin port
+// must not be exposed publicly. Every command serving requests or consuming messages serves them, so the probes
+// are served here as well for the consume command, which has no HTTP server.
+func registerAdminRoutes(router chi.Router, m *metrics.Metrics, h *controller.HealthCheckController, c *controller.AdminController) {
+	router.Handle("/metrics", m.Handler())
+	router.Get("/version", c.Version)
+	router.Get("/livez", h.Livez)
+	router.Get("/readyz", h.Readyz)
+	router.Get("/startupz", h.Startupz)
+
+	router.Route("/admin", func(r chi.Router) {
+		r.Get("/loglevel", c.GetLogLevel)
+		r.Put("/loglevel", c.SetLogLevel)
+		r.Get("/config", c.Config)
+	})
 
-	httpServer.GetRouter().Route("/v1", func(r chi.Router) {
-		r.With(withTimeout(shortTimeout)).Route("/placeholder", func(r chi.Router) {
-			r.Get("/", placeholderController.GetPlaceholder)
-			r.Post("/", placeholderController.CreatePlaceholder)
-		})
+	// The index serves the named profiles, like /debug/pprof/heap
+	router.Route("/debug/pprof", func(r chi.Router) {
+		r.HandleFunc("/*", pprof.Index)
+		r.HandleFunc("/cmdline", pprof.Cmdline)
+		r.HandleFunc("/profile", pprof.Profile)
+		r.HandleFunc("/symbol", pprof.Symbol)
+		r.HandleFunc("/trace", pprof.Trace)
 	})
-	return httpServer
 }
 
-func withTimeout(timeout time.Duration) func(next http.Handler) http.Handler { //nolint
-	if timeout == 0 {
-		timeout = defaultTimeout
-	}
+// httpControllers holds the controllers served by the HTTP server
+type httpControllers struct {
+	HealthCheck *controller.HealthCheckController
+	Placeholder *controller.PlaceholderController
+	Docs        *openapi.Handler
+}
 
-	return func(next http.Handler) http.Handler {
-		fn := func(w http.ResponseWriter, r *http.Request) {
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			defer cancel()
+// registerRoutes registers every HTTP route. Routes outside of the authenticated group are public,
+// guards authenticate and authorize the others, there is none when the authentication is disabled.
+func registerRoutes(router chi.Router, routes routeMiddlewares, c httpControllers, guards ...func(http.Handler) http.Handler) {
+	router.Use(middleware.RequestID, middleware.Tracing, middleware.Metrics(routes.metrics))
+
+	// Public Endpoint Routing, the probes answer with the status only, the details are on the admin port
+	router.Get("/ping", c.HealthCheck.Ping)
+	router.Get("/livez", c.HealthCheck.PublicLivez)
+	router.Get("/readyz", c.HealthCheck.PublicReadyz)
+	router.Get("/startupz", c.HealthCheck.Startupz)
+	router.Get("/openapi.json", c.Docs.Spec)
+	router.Get("/docs", c.Docs.Docs)
+
+	// Authenticated Endpoint Routing
+	router.Group(func(router chi.Router) {
+		router.Use(guards...)
+		registerAPIRoutes(router, routes, c)
+	})
+}
 
-			next.ServeHTTP(w, r)
+func registerAPIRoutes(router chi.Router, routes routeMiddlewares, c httpControllers) {
+	router.Route("/v1", func(r chi.Router) {
+		r.Route("/placeholder", func(r chi.Router) {
+			r.With(routes.of("placeholder_list")...).Get("/", c.Placeholder.ListPlaceholders)
+			r.With(routes.of("placeholder_create")...).With(routes.idempotent).Post("/", c.Placeholder.CreatePlaceholder)
+
+			r.Route("/{placeholderID}", func(r chi.Router) {
+				r.With(routes.of("placeholder_get")...).Get("/", c.Placeholder.GetPlaceholderByID)
+				r.With(routes.of("placeholder_update")...).Put("/", c.Placeholder.UpdatePlaceholder)
+				r.With(routes.of("placeholder_update")...).Patch("/", c.Placeholder.PatchPlaceholder)
+				r.With(routes.of("placeholder_delete")...).Delete("/", c.Placeholder.DeletePlaceholder)
+			})
+		})
+	})
+}
 
-			select {
-			case <-ctx.Done():
-				// TODO: if possible, respond with "Timeout" message
-			default:
-			}
-		}
+// routeMiddlewares applies the metrics of every route, the limits configured for each route name,
+// and the idempotency of the routes opting in
+type routeMiddlewares struct {
+	live    *config.Live
+	metrics *metrics.Metrics
 
-		return http.HandlerFunc(fn)
-	}
+	// rateLimiter is nil when the rate limiting is disabled
+	rateLimiter *middleware.RateLimiter
+	idempotency *middleware.Idempotency
 }
 
-func consumeKafkaMessages(ctx context.Context, app *application.App, dep *application.Dependency, wg *sync.WaitGroup) {
-	topics := app.Config.Kafka.ConsumerTopics
-	consumerHandler := &controller.ConsumerHandler{
-		PlaceholderFeedService: dep.PlaceholderFeedService,
+// of returns the middlewares applying the ROUTE_TIMEOUTS timeout and the RATE_LIMITS limit of the route name
+func (m routeMiddlewares) of(route string) []func(next http.Handler) http.Handler {
+	middlewares := []func(next http.Handler) http.Handler{
+		middleware.TimeoutOf(func() time.Duration {
+			return m.live.Load().Const.RouteTimeout(route)
+		}),
 	}
 
-	wg.Add(1)
+	if m.rateLimiter != nil {
+		// The limit is counted before the deadline starts, limited requests are answered right away
+		middlewares = append([]func(next http.Handler) http.Handler{m.rateLimiter.Limit(route)}, middlewares...)
+	}
 
-	go func(t string, h func([]byte) (bool, error)) {
-		defer wg.Done()
-		log.Printf("creating consumer for topic %s", t)
-		kafkaListener(ctx, app, t, h)
-	}(topics["placeholder"], consumerHandler.Placeholder)
+	return middlewares
 }
 
-func kafkaListener(ctx context.Context, app *application.App, topic string, messageHandler func([]byte) (bool, error)) {
-	log.Printf("Kafka Listener(%s) START", topic)
-
-loop:
-	for {
-		select {
-		case <-ctx.Done():
-			log.Printf("consumer stop signal %s", topic)
-			break loop
-		default:
-			msg, err := app.Consumer.Consume(ctx, topic)
-			if err != nil {
-				if err == io.EOF || err == context.Canceled {
-					log.Printf("shutting down kafka consumers %s", topic)
-					break loop
-				}
-
-				log.Printf("error when consuming kafka message %s", topic)
-				continue
-			} else if msg.Value == nil {
-				// no message, no error. skip
-				continue
-			}
-			// Process the message.
-			log.Printf("kafka message consumed %s[%d]%d", topic, msg.Partition, msg.Offset)
-
-			if _, err := messageHandler(msg.Value.([]byte)); err != nil {
-				log.Printf("error processing message %s[%d]%d", topic, msg.Partition, msg.Offset)
-			}
-		}
+// idempotent honors the Idempotency-Key header of the route
+func (m routeMiddlewares) idempotent(next http.Handler) http.Handler {
+	if m.idempotency == nil {
+		return next
 	}
 
-	_ = app.Consumer.Close()
-	log.Printf("Listener (%s) STOP", topic)
+	return m.idempotency.Handle(next)
+}
+
+// consumeKafkaMessages consumes the topic of every handler of registerConsumers until ctx is done, wg is done
+// once the consumers have stopped. The retries and the dead letters are sent with the producer of the app,
+// closed once wg is done.
+func consumeKafkaMessages(ctx context.Context, app *application.App, dep *application.Dependency, wg *sync.WaitGroup) error {
+	consumers := &consumer.Runtime{
+		Consumer:    app.Consumer,
+		Producer:    app.Producer,
+		DeadLetters: dep.DeadLetterProducer,
+		Logger:      app.Logger,
+		Metrics:     app.Metrics,
+		Topics:      app.Config.Kafka.ConsumerTopics,
+		Backoff: retry.Backoff{
+			Initial: app.Config.Kafka.RestartInitialBackoff,
+			Max:     app.Config.Kafka.RestartMaxBackoff,
+			Jitter:  consumerBackoffJitter,
+		},
+		RetryTiers:      app.Config.Kafka.RetryTiers,
+		CommitBatchSize: app.Config.Kafka.CommitBatchSize,
+		CommitInterval:  app.Config.Kafka.CommitInterval,
+	}
+
+	registerConsumers(consumers, &controller.ConsumerHandler{
+		Logger:                 app.Logger,
+		PlaceholderFeedService: dep.PlaceholderFeedService,
+	})
+
+	return consumers.Start(ctx, wg)
+}
+
+// registerConsumers registers the handler of every logical topic of CONSUMER_TOPICS
+func registerConsumers(r *consumer.Runtime, c *controller.ConsumerHandler) {
+	r.Register(common.TopicPlaceholder, c.Placeholder)
 }
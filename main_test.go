@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/health"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/controller"
+	"github.com/dityuiri/go-baseline/controller/middleware"
+	"github.com/dityuiri/go-baseline/controller/openapi"
+	"github.com/dityuiri/go-baseline/service"
+)
+
+func TestAPIDocument(t *testing.T) {
+	var (
+		router      = chi.NewRouter()
+		controllers = httpControllers{
+			HealthCheck: &controller.HealthCheckController{},
+			Placeholder: &controller.PlaceholderController{},
+			Docs:        &openapi.Handler{},
+		}
+	)
+
+	registerRoutes(router, routeMiddlewares{live: config.NewLive(&config.Configuration{Const: &config.Constants{}})}, controllers)
+
+	t.Run("every route is documented", func(t *testing.T) {
+		doc, err := buildAPIDocument("go-baseline", router)
+		assert.NoError(t, err, "document the route in apiEndpoints")
+
+		_, err = json.Marshal(doc)
+		assert.Nil(t, err)
+	})
+
+	t.Run("undocumented route is reported", func(t *testing.T) {
+		router.Get("/v1/undocumented", controllers.HealthCheck.Ping)
+
+		_, err := buildAPIDocument("go-baseline", router)
+		assert.ErrorIs(t, err, openapi.ErrUndocumentedRoute)
+	})
+}
+
+func TestAPIPolicy(t *testing.T) {
+	var (
+		router      = chi.NewRouter()
+		mockCtrl    = gomock.NewController(t)
+		mockLogger  = loggerMock.NewMockILogger(mockCtrl)
+		controllers = httpControllers{
+			HealthCheck: &controller.HealthCheckController{},
+			Placeholder: &controller.PlaceholderController{},
+			Docs:        &openapi.Handler{},
+		}
+
+		// authenticate stands in for the bearer token verification
+		authenticate = func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal := auth.Principal{Subject: "user-1", Scopes: []string{r.Header.Get("X-Test-Scope")}}
+				next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+			})
+		}
+	)
+
+	registerRoutes(router, routeMiddlewares{live: config.NewLive(&config.Configuration{Const: &config.Constants{}})}, controllers, authenticate, middleware.Authorize(apiPolicy, mockLogger))
+
+	t.Run("every authenticated route has a requirement", func(t *testing.T) {
+		apiRouter := chi.NewRouter()
+		registerAPIRoutes(apiRouter, routeMiddlewares{live: config.NewLive(&config.Configuration{Const: &config.Constants{}})}, controllers)
+
+		err := chi.Walk(apiRouter, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			assert.Contains(t, apiPolicy, auth.Route(method, route), "add the requirement of the route to apiPolicy")
+			return nil
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("negative - read scope cannot create", func(t *testing.T) {
+		mockLogger.EXPECT().GetSkip().Return(nil).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/v1/placeholder", nil)
+		request.Header.Set("X-Test-Scope", common.ScopePlaceholderRead)
+
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("negative - write scope cannot delete", func(t *testing.T) {
+		mockLogger.EXPECT().GetSkip().Return(nil).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodDelete, "/v1/placeholder/"+uuid.NewString(), nil)
+		request.Header.Set("X-Test-Scope", common.ScopePlaceholderWrite)
+
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("positive - public route", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestAdminRoutes(t *testing.T) {
+	var (
+		router          = chi.NewRouter()
+		mockCtrl        = gomock.NewController(t)
+		mockLogger      = loggerMock.NewMockILogger(mockCtrl)
+		adminController = &controller.AdminController{Logger: mockLogger, Configuration: config.NewLive(&config.Configuration{})}
+	)
+
+	mockLogger.EXPECT().SetLevel(gomock.Any())
+	adminController.LogLevel = logging.NewLevel(mockLogger, "INFO")
+
+	registerAdminRoutes(router, metrics.New(), &controller.HealthCheckController{HealthCheckService: &service.HealthCheckService{Registry: health.NewRegistry()}}, adminController)
+
+	for _, path := range []string{"/metrics", "/version", "/livez", "/readyz", "/admin/loglevel", "/admin/config", "/debug/pprof/", "/debug/pprof/goroutine", "/debug/pprof/cmdline"} {
+		t.Run("positive - "+path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dityuiri/go-baseline/application"
+	"github.com/dityuiri/go-baseline/repository"
+)
+
+const (
+	defaultMigrationsDir = "db/migrations"
+	defaultSeedsDir      = "db/seeds"
+)
+
+func migrateCommand() *command {
+	return &command{
+		name:  "migrate",
+		short: "Apply or revert the database migrations",
+		help: "Connects as DB_MIGRATION_USER, or DB_USER when it is not set, and nothing else.\n" +
+			"The version is kept in the schema_migrations table of golang-migrate.",
+		subcommands: []*command{
+			{
+				name:  "up",
+				short: "Apply the pending migrations",
+				help:  "Exits 0 once applied, 1 when a migration fails. A failing migration is rolled back.",
+				setup: func(flags *flag.FlagSet) runFunc {
+					dir := flags.String("dir", defaultMigrationsDir, "directory of the migration files")
+					steps := flags.Int("steps", 0, "number of migrations to apply, every pending one with 0")
+
+					return withMigrator(dir, func(ctx context.Context, s streams, migrator repository.IMigrator) int {
+						return migrateUp(ctx, s, migrator, *steps)
+					})
+				},
+			},
+			{
+				name:  "down",
+				short: "Revert the last applied migrations",
+				help:  "Exits 0 once reverted, 1 when a migration fails. A failing migration is rolled back.",
+				setup: func(flags *flag.FlagSet) runFunc {
+					dir := flags.String("dir", defaultMigrationsDir, "directory of the migration files")
+					steps := flags.Int("steps", 1, "number of migrations to revert, every applied one with 0")
+
+					return withMigrator(dir, func(ctx context.Context, s streams, migrator repository.IMigrator) int {
+						return migrateDown(ctx, s, migrator, *steps)
+					})
+				},
+			},
+			{
+				name:  "status",
+				short: "List the applied and the pending migrations",
+				help:  "Exits 0 when the database is at a known version, 1 when it is dirty or unreachable.",
+				setup: func(flags *flag.FlagSet) runFunc {
+					dir := flags.String("dir", defaultMigrationsDir, "directory of the migration files")
+
+					return withMigrator(dir, migrateStatus)
+				},
+			},
+		},
+	}
+}
+
+func seedCommand() *command {
+	return &command{
+		name:  "seed",
+		short: "Fill the database with sample data",
+		help: "Runs the .sql files of the directory in name order, in one transaction. Run the migrations first.\n" +
+			"Exits 0 once seeded, 1 when a seed fails.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			dir := flags.String("dir", defaultSeedsDir, "directory of the seed files")
+
+			return func(ctx context.Context, s streams, _ []string) int {
+				return withDatabase(ctx, s, application.AdapterDatabase, func(app *application.App) int {
+					return seed(ctx, s, &repository.Seeder{DB: app.DB, Seeds: os.DirFS(*dir)})
+				})
+			}
+		},
+	}
+}
+
+// withMigrator runs the migrate command with the migrations of the directory
+func withMigrator(dir *string, run func(ctx context.Context, s streams, migrator repository.IMigrator) int) runFunc {
+	return func(ctx context.Context, s streams, _ []string) int {
+		return withDatabase(ctx, s, application.AdapterMigrationDatabase, func(app *application.App) int {
+			return run(ctx, s, &repository.Migrator{DB: app.DB, Migrations: os.DirFS(*dir)})
+		})
+	}
+}
+
+// withDatabase sets up the app with the database only, then runs the command
+func withDatabase(ctx context.Context, s streams, adapter application.Adapter, run func(app *application.App) int) int {
+	app, err := application.SetupApplication(ctx, adapter)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "failed to set up the app: %s\n", err)
+		return exitFailure
+	}
+
+	defer app.Close()
+
+	return run(app)
+}
+
+func migrateUp(ctx context.Context, s streams, migrator repository.IMigrator, steps int) int {
+	applied, err := migrator.Up(ctx, steps)
+	for _, migration := range applied {
+		_, _ = fmt.Fprintf(s.out, "applied %d %s\n", migration.Version, migration.Description)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "migrate up: %s\n", err)
+		return exitFailure
+	}
+
+	if len(applied) == 0 {
+		_, _ = fmt.Fprintln(s.out, "no migration to apply")
+	}
+
+	return exitOK
+}
+
+func migrateDown(ctx context.Context, s streams, migrator repository.IMigrator, steps int) int {
+	reverted, err := migrator.Down(ctx, steps)
+	for _, migration := range reverted {
+		_, _ = fmt.Fprintf(s.out, "reverted %d %s\n", migration.Version, migration.Description)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "migrate down: %s\n", err)
+		return exitFailure
+	}
+
+	if len(reverted) == 0 {
+		_, _ = fmt.Fprintln(s.out, "no migration to revert")
+	}
+
+	return exitOK
+}
+
+func migrateStatus(ctx context.Context, s streams, migrator repository.IMigrator) int {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "migrate status: %s\n", err)
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(s.out, "version %d\n", status.Version)
+	for _, migration := range status.Applied {
+		_, _ = fmt.Fprintf(s.out, "  applied  %d %s\n", migration.Version, migration.Description)
+	}
+
+	for _, migration := range status.Pending {
+		_, _ = fmt.Fprintf(s.out, "  pending  %d %s\n", migration.Version, migration.Description)
+	}
+
+	return exitOK
+}
+
+func seed(ctx context.Context, s streams, seeder repository.ISeeder) int {
+	files, err := seeder.Seed(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "seed: %s\n", err)
+		return exitFailure
+	}
+
+	for _, file := range files {
+		_, _ = fmt.Fprintf(s.out, "seeded %s\n", file)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestMigrateCommands(t *testing.T) {
+	var (
+		mockCtrl     = gomock.NewController(t)
+		mockMigrator = repositoryMock.NewMockIMigrator(mockCtrl)
+		mockSeeder   = repositoryMock.NewMockISeeder(mockCtrl)
+
+		ctx       = context.Background()
+		first     = model.Migration{Version: 1, Description: "create_placeholder_table"}
+		second    = model.Migration{Version: 2, Description: "add_placeholder_version"}
+		newStream = func() (*bytes.Buffer, *bytes.Buffer, streams) {
+			var out, errOut bytes.Buffer
+			return &out, &errOut, streams{out: &out, err: &errOut}
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - up", func(t *testing.T) {
+		out, _, s := newStream()
+		mockMigrator.EXPECT().Up(ctx, 0).Return([]model.Migration{first, second}, nil)
+
+		assert.Equal(t, exitOK, migrateUp(ctx, s, mockMigrator, 0))
+		assert.Equal(t, "applied 1 create_placeholder_table\napplied 2 add_placeholder_version\n", out.String())
+	})
+
+	t.Run("positive - up to date", func(t *testing.T) {
+		out, _, s := newStream()
+		mockMigrator.EXPECT().Up(ctx, 0).Return(nil, nil)
+
+		assert.Equal(t, exitOK, migrateUp(ctx, s, mockMigrator, 0))
+		assert.Equal(t, "no migration to apply\n", out.String())
+	})
+
+	t.Run("negative - up fails after applying a migration", func(t *testing.T) {
+		out, errOut, s := newStream()
+		mockMigrator.EXPECT().Up(ctx, 0).Return([]model.Migration{first}, errors.New("migration 2 up: error"))
+
+		assert.Equal(t, exitFailure, migrateUp(ctx, s, mockMigrator, 0))
+		assert.Equal(t, "applied 1 create_placeholder_table\n", out.String())
+		assert.Equal(t, "migrate up: migration 2 up: error\n", errOut.String())
+	})
+
+	t.Run("positive - down", func(t *testing.T) {
+		out, _, s := newStream()
+		mockMigrator.EXPECT().Down(ctx, 1).Return([]model.Migration{second}, nil)
+
+		assert.Equal(t, exitOK, migrateDown(ctx, s, mockMigrator, 1))
+		assert.Equal(t, "reverted 2 add_placeholder_version\n", out.String())
+	})
+
+	t.Run("negative - down", func(t *testing.T) {
+		_, errOut, s := newStream()
+		mockMigrator.EXPECT().Down(ctx, 1).Return(nil, common.ErrDirtyMigration)
+
+		assert.Equal(t, exitFailure, migrateDown(ctx, s, mockMigrator, 1))
+		assert.Contains(t, errOut.String(), "dirty")
+	})
+
+	t.Run("positive - status", func(t *testing.T) {
+		out, _, s := newStream()
+		mockMigrator.EXPECT().Status(ctx).Return(model.MigrationStatus{Version: 1, Applied: []model.Migration{first}, Pending: []model.Migration{second}}, nil)
+
+		assert.Equal(t, exitOK, migrateStatus(ctx, s, mockMigrator))
+		assert.Equal(t, "version 1\n  applied  1 create_placeholder_table\n  pending  2 add_placeholder_version\n", out.String())
+	})
+
+	t.Run("negative - status of a dirty database", func(t *testing.T) {
+		_, errOut, s := newStream()
+		mockMigrator.EXPECT().Status(ctx).Return(model.MigrationStatus{Version: 2, Dirty: true}, common.ErrDirtyMigration)
+
+		assert.Equal(t, exitFailure, migrateStatus(ctx, s, mockMigrator))
+		assert.Equal(t, "migrate status: "+common.ErrDirtyMigration.Error()+"\n", errOut.String())
+	})
+
+	t.Run("positive - seed", func(t *testing.T) {
+		out, _, s := newStream()
+		mockSeeder.EXPECT().Seed(ctx).Return([]string{"000001_placeholder.sql"}, nil)
+
+		assert.Equal(t, exitOK, seed(ctx, s, mockSeeder))
+		assert.Equal(t, "seeded 000001_placeholder.sql\n", out.String())
+	})
+
+	t.Run("negative - seed", func(t *testing.T) {
+		_, errOut, s := newStream()
+		mockSeeder.EXPECT().Seed(ctx).Return(nil, errors.New("seed 000001_placeholder.sql: error"))
+
+		assert.Equal(t, exitFailure, seed(ctx, s, mockSeeder))
+		assert.Equal(t, "seed: seed 000001_placeholder.sql: error\n", errOut.String())
+	})
+
+	t.Run("negative - invalid configuration", func(t *testing.T) {
+		_, errOut, s := newStream()
+
+		t.Setenv("CONFIG_DIR", t.TempDir())
+		t.Setenv("ENVIRONMENT", "staging")
+		assert.Equal(t, exitFailure, runCLI(ctx, []string{"migrate", "status"}, s))
+		assert.Contains(t, errOut.String(), "failed to set up the app")
+	})
+}
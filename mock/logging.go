@@ -0,0 +1,91 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/dityuiri/go-adapter/logger/log"
+)
+
+// LogWith matches the options of a log recording err, whose data holds every field like "placeholder_id=1"
+func LogWith(err error, fields ...string) gomock.Matcher {
+	return logMatcher{err: err, fields: fields}
+}
+
+type logMatcher struct {
+	err    error
+	fields []string
+}
+
+func (m logMatcher) Matches(x interface{}) bool {
+	resolved, ok := resolveOptions(x)
+	if !ok {
+		return false
+	}
+
+	if loggedErr := resolved.GetError(); loggedErr == nil || !errors.Is(*loggedErr, m.err) {
+		return false
+	}
+
+	var data string
+	if d := resolved.GetData(); d != nil {
+		data = " " + *d + " "
+	}
+
+	for _, field := range m.fields {
+		if !strings.Contains(data, " "+field+" ") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m logMatcher) String() string {
+	return fmt.Sprintf("is a log of error %v with %v", m.err, m.fields)
+}
+
+// LogContaining matches the options of a log whose data contains text
+func LogContaining(text string) gomock.Matcher {
+	return dataMatcher{text: text}
+}
+
+type dataMatcher struct {
+	text string
+}
+
+func (m dataMatcher) Matches(x interface{}) bool {
+	resolved, ok := resolveOptions(x)
+	if !ok {
+		return false
+	}
+
+	return resolved.GetData() != nil && strings.Contains(*resolved.GetData(), m.text)
+}
+
+func (m dataMatcher) String() string {
+	return fmt.Sprintf("is a log whose data contains %q", m.text)
+}
+
+// resolveOptions applies the log options of a variadic argument, which gomock passes as one option or a slice
+func resolveOptions(x interface{}) (*log.Options, bool) {
+	var options []log.Option
+	switch o := x.(type) {
+	case log.Option:
+		options = []log.Option{o}
+	case []log.Option:
+		options = o
+	default:
+		return nil, false
+	}
+
+	resolved := &log.Options{}
+	for _, option := range options {
+		option(resolved)
+	}
+
+	return resolved, true
+}
@@ -6,9 +6,9 @@ package proxy_mock
 
 import (
 	context "context"
-	"github.com/dityuiri/go-baseline/model/alpha"
 	reflect "reflect"
 
+	alpha "github.com/dityuiri/go-baseline/model/alpha"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -49,3 +49,17 @@ func (mr *MockIAlphaProxyMockRecorder) GetPlaceholderStatus(arg0, arg1 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaceholderStatus", reflect.TypeOf((*MockIAlphaProxy)(nil).GetPlaceholderStatus), arg0, arg1)
 }
+
+// Ping mocks base method.
+func (m *MockIAlphaProxy) Ping(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockIAlphaProxyMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIAlphaProxy)(nil).Ping), arg0)
+}
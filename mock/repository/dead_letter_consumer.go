@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dityuiri/go-baseline/repository (interfaces: IDeadLetterConsumer)
+
+// Package repository_mock is a generated GoMock package.
+package repository_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dityuiri/go-baseline/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIDeadLetterConsumer is a mock of IDeadLetterConsumer interface.
+type MockIDeadLetterConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterConsumerMockRecorder
+}
+
+// MockIDeadLetterConsumerMockRecorder is the mock recorder for MockIDeadLetterConsumer.
+type MockIDeadLetterConsumerMockRecorder struct {
+	mock *MockIDeadLetterConsumer
+}
+
+// NewMockIDeadLetterConsumer creates a new mock instance.
+func NewMockIDeadLetterConsumer(ctrl *gomock.Controller) *MockIDeadLetterConsumer {
+	mock := &MockIDeadLetterConsumer{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterConsumer) EXPECT() *MockIDeadLetterConsumerMockRecorder {
+	return m.recorder
+}
+
+// FetchDeadLetter mocks base method.
+func (m *MockIDeadLetterConsumer) FetchDeadLetter(arg0 context.Context, arg1 string) (model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeadLetter", arg0, arg1)
+	ret0, _ := ret[0].(model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeadLetter indicates an expected call of FetchDeadLetter.
+func (mr *MockIDeadLetterConsumerMockRecorder) FetchDeadLetter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeadLetter", reflect.TypeOf((*MockIDeadLetterConsumer)(nil).FetchDeadLetter), arg0, arg1)
+}
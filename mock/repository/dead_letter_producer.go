@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dityuiri/go-baseline/repository (interfaces: IDeadLetterProducer)
+
+// Package repository_mock is a generated GoMock package.
+package repository_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dityuiri/go-baseline/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIDeadLetterProducer is a mock of IDeadLetterProducer interface.
+type MockIDeadLetterProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterProducerMockRecorder
+}
+
+// MockIDeadLetterProducerMockRecorder is the mock recorder for MockIDeadLetterProducer.
+type MockIDeadLetterProducerMockRecorder struct {
+	mock *MockIDeadLetterProducer
+}
+
+// NewMockIDeadLetterProducer creates a new mock instance.
+func NewMockIDeadLetterProducer(ctrl *gomock.Controller) *MockIDeadLetterProducer {
+	mock := &MockIDeadLetterProducer{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterProducer) EXPECT() *MockIDeadLetterProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceDeadLetter mocks base method.
+func (m *MockIDeadLetterProducer) ProduceDeadLetter(arg0 context.Context, arg1 string, arg2 model.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceDeadLetter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceDeadLetter indicates an expected call of ProduceDeadLetter.
+func (mr *MockIDeadLetterProducerMockRecorder) ProduceDeadLetter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceDeadLetter", reflect.TypeOf((*MockIDeadLetterProducer)(nil).ProduceDeadLetter), arg0, arg1, arg2)
+}
@@ -5,6 +5,7 @@
 package repository_mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,16 +34,44 @@ func (m *MockIHealthCheckRepository) EXPECT() *MockIHealthCheckRepositoryMockRec
 	return m.recorder
 }
 
-// Ping mocks base method.
-func (m *MockIHealthCheckRepository) Ping() error {
+// PingDatabase mocks base method.
+func (m *MockIHealthCheckRepository) PingDatabase(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Ping")
+	ret := m.ctrl.Call(m, "PingDatabase", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Ping indicates an expected call of Ping.
-func (mr *MockIHealthCheckRepositoryMockRecorder) Ping() *gomock.Call {
+// PingDatabase indicates an expected call of PingDatabase.
+func (mr *MockIHealthCheckRepositoryMockRecorder) PingDatabase(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthCheckRepository)(nil).Ping))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingDatabase", reflect.TypeOf((*MockIHealthCheckRepository)(nil).PingDatabase), arg0)
+}
+
+// PingKafka mocks base method.
+func (m *MockIHealthCheckRepository) PingKafka(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingKafka", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingKafka indicates an expected call of PingKafka.
+func (mr *MockIHealthCheckRepositoryMockRecorder) PingKafka(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingKafka", reflect.TypeOf((*MockIHealthCheckRepository)(nil).PingKafka), arg0)
+}
+
+// PingRedis mocks base method.
+func (m *MockIHealthCheckRepository) PingRedis(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingRedis", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingRedis indicates an expected call of PingRedis.
+func (mr *MockIHealthCheckRepositoryMockRecorder) PingRedis(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingRedis", reflect.TypeOf((*MockIHealthCheckRepository)(nil).PingRedis), arg0)
 }
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dityuiri/go-baseline/repository (interfaces: IIdempotencyCache)
+
+// Package repository_mock is a generated GoMock package.
+package repository_mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dityuiri/go-baseline/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIIdempotencyCache is a mock of IIdempotencyCache interface.
+type MockIIdempotencyCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyCacheMockRecorder
+}
+
+// MockIIdempotencyCacheMockRecorder is the mock recorder for MockIIdempotencyCache.
+type MockIIdempotencyCacheMockRecorder struct {
+	mock *MockIIdempotencyCache
+}
+
+// NewMockIIdempotencyCache creates a new mock instance.
+func NewMockIIdempotencyCache(ctrl *gomock.Controller) *MockIIdempotencyCache {
+	mock := &MockIIdempotencyCache{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyCache) EXPECT() *MockIIdempotencyCacheMockRecorder {
+	return m.recorder
+}
+
+// ClaimRecord mocks base method.
+func (m *MockIIdempotencyCache) ClaimRecord(arg0 context.Context, arg1 string, arg2 model.IdempotencyRecord, arg3 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRecord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRecord indicates an expected call of ClaimRecord.
+func (mr *MockIIdempotencyCacheMockRecorder) ClaimRecord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRecord", reflect.TypeOf((*MockIIdempotencyCache)(nil).ClaimRecord), arg0, arg1, arg2, arg3)
+}
+
+// DeleteRecord mocks base method.
+func (m *MockIIdempotencyCache) DeleteRecord(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecord indicates an expected call of DeleteRecord.
+func (mr *MockIIdempotencyCacheMockRecorder) DeleteRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecord", reflect.TypeOf((*MockIIdempotencyCache)(nil).DeleteRecord), arg0, arg1)
+}
+
+// GetRecord mocks base method.
+func (m *MockIIdempotencyCache) GetRecord(arg0 context.Context, arg1 string) (*model.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecord", arg0, arg1)
+	ret0, _ := ret[0].(*model.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecord indicates an expected call of GetRecord.
+func (mr *MockIIdempotencyCacheMockRecorder) GetRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecord", reflect.TypeOf((*MockIIdempotencyCache)(nil).GetRecord), arg0, arg1)
+}
+
+// SetRecord mocks base method.
+func (m *MockIIdempotencyCache) SetRecord(arg0 context.Context, arg1 string, arg2 model.IdempotencyRecord, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecord indicates an expected call of SetRecord.
+func (mr *MockIIdempotencyCacheMockRecorder) SetRecord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecord", reflect.TypeOf((*MockIIdempotencyCache)(nil).SetRecord), arg0, arg1, arg2, arg3)
+}
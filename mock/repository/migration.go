@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dityuiri/go-baseline/repository (interfaces: IMigrator)
+
+// Package repository_mock is a generated GoMock package.
+package repository_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dityuiri/go-baseline/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMigrator is a mock of IMigrator interface.
+type MockIMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigratorMockRecorder
+}
+
+// MockIMigratorMockRecorder is the mock recorder for MockIMigrator.
+type MockIMigratorMockRecorder struct {
+	mock *MockIMigrator
+}
+
+// NewMockIMigrator creates a new mock instance.
+func NewMockIMigrator(ctrl *gomock.Controller) *MockIMigrator {
+	mock := &MockIMigrator{ctrl: ctrl}
+	mock.recorder = &MockIMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrator) EXPECT() *MockIMigratorMockRecorder {
+	return m.recorder
+}
+
+// Down mocks base method.
+func (m *MockIMigrator) Down(arg0 context.Context, arg1 int) ([]model.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", arg0, arg1)
+	ret0, _ := ret[0].([]model.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockIMigratorMockRecorder) Down(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*MockIMigrator)(nil).Down), arg0, arg1)
+}
+
+// Status mocks base method.
+func (m *MockIMigrator) Status(arg0 context.Context) (model.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(model.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIMigratorMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIMigrator)(nil).Status), arg0)
+}
+
+// Up mocks base method.
+func (m *MockIMigrator) Up(arg0 context.Context, arg1 int) ([]model.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up", arg0, arg1)
+	ret0, _ := ret[0].([]model.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockIMigratorMockRecorder) Up(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockIMigrator)(nil).Up), arg0, arg1)
+}
@@ -6,9 +6,9 @@ package repository_mock
 
 import (
 	context "context"
-	dto "github.com/dityuiri/go-baseline/model"
 	reflect "reflect"
 
+	model "github.com/dityuiri/go-baseline/model"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,11 +35,25 @@ func (m *MockIPlaceholderCache) EXPECT() *MockIPlaceholderCacheMockRecorder {
 	return m.recorder
 }
 
+// DeletePlaceholderInfo mocks base method.
+func (m *MockIPlaceholderCache) DeletePlaceholderInfo(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaceholderInfo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaceholderInfo indicates an expected call of DeletePlaceholderInfo.
+func (mr *MockIPlaceholderCacheMockRecorder) DeletePlaceholderInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaceholderInfo", reflect.TypeOf((*MockIPlaceholderCache)(nil).DeletePlaceholderInfo), arg0, arg1)
+}
+
 // GetPlaceholderInfo mocks base method.
-func (m *MockIPlaceholderCache) GetPlaceholderInfo(arg0 context.Context, arg1 string) (*dto.PlaceholderDTO, error) {
+func (m *MockIPlaceholderCache) GetPlaceholderInfo(arg0 context.Context, arg1 string) (*model.PlaceholderDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPlaceholderInfo", arg0, arg1)
-	ret0, _ := ret[0].(*dto.PlaceholderDTO)
+	ret0, _ := ret[0].(*model.PlaceholderDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -51,7 +65,7 @@ func (mr *MockIPlaceholderCacheMockRecorder) GetPlaceholderInfo(arg0, arg1 inter
 }
 
 // SetPlaceholderInfo mocks base method.
-func (m *MockIPlaceholderCache) SetPlaceholderInfo(arg0 context.Context, arg1 dto.PlaceholderDTO) error {
+func (m *MockIPlaceholderCache) SetPlaceholderInfo(arg0 context.Context, arg1 model.PlaceholderDTO) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetPlaceholderInfo", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -6,10 +6,10 @@ package repository_mock
 
 import (
 	context "context"
-	dao "github.com/dityuiri/go-baseline/model"
 	reflect "reflect"
 
 	db "github.com/dityuiri/go-adapter/db"
+	model "github.com/dityuiri/go-baseline/model"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,11 +36,55 @@ func (m *MockIPlaceholderRepository) EXPECT() *MockIPlaceholderRepositoryMockRec
 	return m.recorder
 }
 
+// CountPlaceholders mocks base method.
+func (m *MockIPlaceholderRepository) CountPlaceholders(arg0 context.Context, arg1 model.PlaceholderFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPlaceholders", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlaceholders indicates an expected call of CountPlaceholders.
+func (mr *MockIPlaceholderRepositoryMockRecorder) CountPlaceholders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlaceholders", reflect.TypeOf((*MockIPlaceholderRepository)(nil).CountPlaceholders), arg0, arg1)
+}
+
+// DeletePlaceholder mocks base method.
+func (m *MockIPlaceholderRepository) DeletePlaceholder(arg0 context.Context, arg1 db.ITransaction, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaceholder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaceholder indicates an expected call of DeletePlaceholder.
+func (mr *MockIPlaceholderRepositoryMockRecorder) DeletePlaceholder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaceholder", reflect.TypeOf((*MockIPlaceholderRepository)(nil).DeletePlaceholder), arg0, arg1, arg2)
+}
+
+// GetPlaceholders mocks base method.
+func (m *MockIPlaceholderRepository) GetPlaceholders(arg0 context.Context, arg1 model.PlaceholderFilter) ([]model.PlaceholderDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaceholders", arg0, arg1)
+	ret0, _ := ret[0].([]model.PlaceholderDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaceholders indicates an expected call of GetPlaceholders.
+func (mr *MockIPlaceholderRepositoryMockRecorder) GetPlaceholders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaceholders", reflect.TypeOf((*MockIPlaceholderRepository)(nil).GetPlaceholders), arg0, arg1)
+}
+
 // GetSinglePlaceholder mocks base method.
-func (m *MockIPlaceholderRepository) GetSinglePlaceholder(arg0 context.Context, arg1 string) (dao.PlaceholderDAO, error) {
+func (m *MockIPlaceholderRepository) GetSinglePlaceholder(arg0 context.Context, arg1 string) (model.PlaceholderDAO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSinglePlaceholder", arg0, arg1)
-	ret0, _ := ret[0].(dao.PlaceholderDAO)
+	ret0, _ := ret[0].(model.PlaceholderDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -52,7 +96,7 @@ func (mr *MockIPlaceholderRepositoryMockRecorder) GetSinglePlaceholder(arg0, arg
 }
 
 // InsertPlaceholder mocks base method.
-func (m *MockIPlaceholderRepository) InsertPlaceholder(arg0 context.Context, arg1 db.ITransaction, arg2 dao.PlaceholderDAO) error {
+func (m *MockIPlaceholderRepository) InsertPlaceholder(arg0 context.Context, arg1 db.ITransaction, arg2 model.PlaceholderDAO) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertPlaceholder", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -66,11 +110,12 @@ func (mr *MockIPlaceholderRepositoryMockRecorder) InsertPlaceholder(arg0, arg1,
 }
 
 // UpdatePlaceholder mocks base method.
-func (m *MockIPlaceholderRepository) UpdatePlaceholder(arg0 context.Context, arg1 db.ITransaction, arg2 dao.PlaceholderDAO) error {
+func (m *MockIPlaceholderRepository) UpdatePlaceholder(arg0 context.Context, arg1 db.ITransaction, arg2 model.PlaceholderDAO) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdatePlaceholder", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdatePlaceholder indicates an expected call of UpdatePlaceholder.
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dityuiri/go-baseline/repository (interfaces: ISeeder)
+
+// Package repository_mock is a generated GoMock package.
+package repository_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISeeder is a mock of ISeeder interface.
+type MockISeeder struct {
+	ctrl     *gomock.Controller
+	recorder *MockISeederMockRecorder
+}
+
+// MockISeederMockRecorder is the mock recorder for MockISeeder.
+type MockISeederMockRecorder struct {
+	mock *MockISeeder
+}
+
+// NewMockISeeder creates a new mock instance.
+func NewMockISeeder(ctrl *gomock.Controller) *MockISeeder {
+	mock := &MockISeeder{ctrl: ctrl}
+	mock.recorder = &MockISeederMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISeeder) EXPECT() *MockISeederMockRecorder {
+	return m.recorder
+}
+
+// Seed mocks base method.
+func (m *MockISeeder) Seed(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockISeederMockRecorder) Seed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockISeeder)(nil).Seed), arg0)
+}
@@ -5,8 +5,10 @@
 package service_mock
 
 import (
+	context "context"
 	reflect "reflect"
 
+	health "github.com/dityuiri/go-baseline/common/health"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -33,16 +35,72 @@ func (m *MockIHealthCheckService) EXPECT() *MockIHealthCheckServiceMockRecorder
 	return m.recorder
 }
 
+// Liveness mocks base method.
+func (m *MockIHealthCheckService) Liveness() health.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liveness")
+	ret0, _ := ret[0].(health.Report)
+	return ret0
+}
+
+// Liveness indicates an expected call of Liveness.
+func (mr *MockIHealthCheckServiceMockRecorder) Liveness() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liveness", reflect.TypeOf((*MockIHealthCheckService)(nil).Liveness))
+}
+
 // Ping mocks base method.
-func (m *MockIHealthCheckService) Ping() map[string]string {
+func (m *MockIHealthCheckService) Ping(arg0 context.Context) map[string]string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Ping")
+	ret := m.ctrl.Call(m, "Ping", arg0)
 	ret0, _ := ret[0].(map[string]string)
 	return ret0
 }
 
 // Ping indicates an expected call of Ping.
-func (mr *MockIHealthCheckServiceMockRecorder) Ping() *gomock.Call {
+func (mr *MockIHealthCheckServiceMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthCheckService)(nil).Ping), arg0)
+}
+
+// Readiness mocks base method.
+func (m *MockIHealthCheckService) Readiness(arg0 context.Context) health.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readiness", arg0)
+	ret0, _ := ret[0].(health.Report)
+	return ret0
+}
+
+// Readiness indicates an expected call of Readiness.
+func (mr *MockIHealthCheckServiceMockRecorder) Readiness(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readiness", reflect.TypeOf((*MockIHealthCheckService)(nil).Readiness), arg0)
+}
+
+// Status mocks base method.
+func (m *MockIHealthCheckService) Status(arg0 context.Context) health.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(health.Report)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIHealthCheckServiceMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIHealthCheckService)(nil).Status), arg0)
+}
+
+// Startup mocks base method.
+func (m *MockIHealthCheckService) Startup() health.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Startup")
+	ret0, _ := ret[0].(health.Report)
+	return ret0
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockIHealthCheckServiceMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthCheckService)(nil).Ping))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockIHealthCheckService)(nil).Startup))
 }
@@ -6,9 +6,9 @@ package service_mock
 
 import (
 	context "context"
-	dto "github.com/dityuiri/go-baseline/model"
 	reflect "reflect"
 
+	model "github.com/dityuiri/go-baseline/model"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,10 +36,10 @@ func (m *MockIPlaceholderService) EXPECT() *MockIPlaceholderServiceMockRecorder
 }
 
 // CreateNewPlaceholder mocks base method.
-func (m *MockIPlaceholderService) CreateNewPlaceholder(arg0 context.Context, arg1 dto.PlaceholderCreateRequest) (dto.PlaceholderCreateResponse, error) {
+func (m *MockIPlaceholderService) CreateNewPlaceholder(arg0 context.Context, arg1 model.PlaceholderCreateRequest) (model.PlaceholderCreateResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateNewPlaceholder", arg0, arg1)
-	ret0, _ := ret[0].(dto.PlaceholderCreateResponse)
+	ret0, _ := ret[0].(model.PlaceholderCreateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,11 +50,25 @@ func (mr *MockIPlaceholderServiceMockRecorder) CreateNewPlaceholder(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewPlaceholder", reflect.TypeOf((*MockIPlaceholderService)(nil).CreateNewPlaceholder), arg0, arg1)
 }
 
+// DeletePlaceholder mocks base method.
+func (m *MockIPlaceholderService) DeletePlaceholder(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaceholder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaceholder indicates an expected call of DeletePlaceholder.
+func (mr *MockIPlaceholderServiceMockRecorder) DeletePlaceholder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaceholder", reflect.TypeOf((*MockIPlaceholderService)(nil).DeletePlaceholder), arg0, arg1)
+}
+
 // GetPlaceholder mocks base method.
-func (m *MockIPlaceholderService) GetPlaceholder(arg0 context.Context, arg1 string) (dto.PlaceholderGetResponse, error) {
+func (m *MockIPlaceholderService) GetPlaceholder(arg0 context.Context, arg1 string) (model.PlaceholderGetResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPlaceholder", arg0, arg1)
-	ret0, _ := ret[0].(dto.PlaceholderGetResponse)
+	ret0, _ := ret[0].(model.PlaceholderGetResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -64,3 +78,48 @@ func (mr *MockIPlaceholderServiceMockRecorder) GetPlaceholder(arg0, arg1 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaceholder", reflect.TypeOf((*MockIPlaceholderService)(nil).GetPlaceholder), arg0, arg1)
 }
+
+// ListPlaceholders mocks base method.
+func (m *MockIPlaceholderService) ListPlaceholders(arg0 context.Context, arg1 model.PlaceholderListRequest) (model.PlaceholderListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaceholders", arg0, arg1)
+	ret0, _ := ret[0].(model.PlaceholderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaceholders indicates an expected call of ListPlaceholders.
+func (mr *MockIPlaceholderServiceMockRecorder) ListPlaceholders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaceholders", reflect.TypeOf((*MockIPlaceholderService)(nil).ListPlaceholders), arg0, arg1)
+}
+
+// PatchPlaceholder mocks base method.
+func (m *MockIPlaceholderService) PatchPlaceholder(arg0 context.Context, arg1 string, arg2 int64, arg3 []byte) (model.PlaceholderUpdateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchPlaceholder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.PlaceholderUpdateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchPlaceholder indicates an expected call of PatchPlaceholder.
+func (mr *MockIPlaceholderServiceMockRecorder) PatchPlaceholder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchPlaceholder", reflect.TypeOf((*MockIPlaceholderService)(nil).PatchPlaceholder), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePlaceholder mocks base method.
+func (m *MockIPlaceholderService) UpdatePlaceholder(arg0 context.Context, arg1 string, arg2 int64, arg3 model.PlaceholderUpdateRequest) (model.PlaceholderUpdateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaceholder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.PlaceholderUpdateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlaceholder indicates an expected call of UpdatePlaceholder.
+func (mr *MockIPlaceholderServiceMockRecorder) UpdatePlaceholder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaceholder", reflect.TypeOf((*MockIPlaceholderService)(nil).UpdatePlaceholder), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,64 @@
+package mock
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/requestid"
+)
+
+// NewSpanRecorder installs a tracer provider recording every span, so the contexts passed down can be matched with InSpan
+func NewSpanRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return recorder
+}
+
+// InSpan matches the context a method passes down: it holds the principal and the request ID of ctx,
+// and the span named name the method started as a child of the span of ctx
+func InSpan(ctx context.Context, name string) gomock.Matcher {
+	principal, _ := auth.FromContext(ctx)
+
+	return spanMatcher{
+		principal: principal,
+		requestID: requestid.FromContext(ctx),
+		parent:    trace.SpanContextFromContext(ctx),
+		name:      name,
+	}
+}
+
+type spanMatcher struct {
+	principal auth.Principal
+	requestID string
+	parent    trace.SpanContext
+	name      string
+}
+
+func (m spanMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+
+	if principal, _ := auth.FromContext(ctx); !reflect.DeepEqual(principal, m.principal) || requestid.FromContext(ctx) != m.requestID {
+		return false
+	}
+
+	span, ok := trace.SpanFromContext(ctx).(sdktrace.ReadOnlySpan)
+	return ok && span.Name() == m.name && span.Parent().Equal(m.parent)
+}
+
+func (m spanMatcher) String() string {
+	return "is a context in span " + m.name
+}
@@ -0,0 +1,13 @@
+package model
+
+type (
+	// LogLevelRequest changes the log level of the app
+	LogLevelRequest struct {
+		Level string `json:"level" validate:"required"`
+	}
+
+	// LogLevelResponse is the current log level of the app
+	LogLevelResponse struct {
+		Level string `json:"level"`
+	}
+)
@@ -9,7 +9,15 @@ type (
 	}
 
 	APIErrorResponse struct {
-		Code    string `json:"code"`
+		Code    string           `json:"code"`
+		Message string           `json:"message"`
+		Details []APIErrorDetail `json:"details,omitempty"`
+	}
+
+	// APIErrorDetail describes a single field that failed the request validation
+	APIErrorDetail struct {
+		Field   string `json:"field"`
+		Rule    string `json:"rule"`
 		Message string `json:"message"`
 	}
 
@@ -27,4 +35,14 @@ const (
 	MissingParameter
 	InvalidRequestBody
 	ObjectNotFound
+	RequestTimeout
+	ValidationFailed
+	UpstreamFailure
+	Unauthorized
+	Forbidden
+	TooManyRequests
+	IdempotencyKeyReused
+	RequestInProgress
+	PreconditionFailed
+	PreconditionRequired
 )
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// DeadLetter is a consumed message its handler failed with, kept with the reason of the failure.
+// Value and Headers are the ones of the original message.
+type DeadLetter struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+	Error     string
+	Failures  int
+	FailedAt  time.Time
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	IdempotencyInProgress = "in_progress"
+	IdempotencyCompleted  = "completed"
+)
+
+type (
+	// IdempotencyRecord is the outcome of the first request sent with an Idempotency-Key
+	IdempotencyRecord struct {
+		Fingerprint string      `json:"fingerprint"`
+		State       string      `json:"state"`
+		StatusCode  int         `json:"status_code,omitempty"`
+		Header      http.Header `json:"header,omitempty"`
+		Body        []byte      `json:"body,omitempty"`
+	}
+)
@@ -0,0 +1,20 @@
+package model
+
+type (
+	// Migration is a versioned change of the database schema. UpFile applies it and DownFile, when there is one,
+	// reverts it.
+	Migration struct {
+		Version     uint64
+		Description string
+		UpFile      string
+		DownFile    string
+	}
+
+	// MigrationStatus is the version of the database, with the migrations applied to it and the pending ones
+	MigrationStatus struct {
+		Version uint64
+		Dirty   bool
+		Applied []Migration
+		Pending []Migration
+	}
+)
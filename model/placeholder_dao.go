@@ -2,6 +2,8 @@ package model
 
 import (
 	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -14,6 +16,20 @@ type (
 		CreatedBy string
 		UpdatedAt interface{}
 		UpdatedBy string
+		Version   int64
+	}
+
+	// PlaceholderFilter criteria used to query multiple placeholders
+	PlaceholderFilter struct {
+		NamePrefix  string
+		MinAmount   *int
+		MaxAmount   *int
+		CreatedFrom *time.Time
+		CreatedTo   *time.Time
+		SortBy      string
+		SortOrder   string
+		Limit       int
+		Offset      int
 	}
 )
 
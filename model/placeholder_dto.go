@@ -10,23 +10,61 @@ import (
 type (
 	// PlaceholderCreateRequest POST /v1/placeholder request
 	PlaceholderCreateRequest struct {
-		Name   string `json:"name"`
-		Amount int    `json:"amount"`
+		Name   string `json:"name" validate:"required,notblank,max=255"`
+		Amount int    `json:"amount" validate:"min=0"`
 	}
 
 	// PlaceholderCreateResponse POST /v1/placeholder response
 	PlaceholderCreateResponse struct {
-		ID     string `json:"id"`
-		Name   string `json:"name"`
-		Amount int    `json:"amount"`
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Amount  int    `json:"amount"`
+		Version int64  `json:"version"`
+	}
+
+	// PlaceholderUpdateRequest PUT /v1/placeholder/{placeholderID} request
+	PlaceholderUpdateRequest struct {
+		Name   string `json:"name" validate:"required,notblank,max=255"`
+		Amount int    `json:"amount" validate:"min=0"`
+	}
+
+	// PlaceholderUpdateResponse PUT & PATCH /v1/placeholder/{placeholderID} response
+	PlaceholderUpdateResponse struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Amount  int    `json:"amount"`
+		Version int64  `json:"version"`
+	}
+
+	// PlaceholderListRequest GET /v1/placeholder query parameters
+	PlaceholderListRequest struct {
+		NamePrefix  string
+		MinAmount   *int
+		MaxAmount   *int
+		CreatedFrom *time.Time
+		CreatedTo   *time.Time
+		SortBy      string
+		SortOrder   string
+		Page        int
+		PageSize    int
+	}
+
+	// PlaceholderListResponse GET /v1/placeholder response
+	PlaceholderListResponse struct {
+		Items      []PlaceholderGetResponse `json:"items"`
+		Page       int                      `json:"page"`
+		PageSize   int                      `json:"page_size"`
+		TotalItems int                      `json:"total_items"`
+		TotalPages int                      `json:"total_pages"`
 	}
 
 	// PlaceholderGetResponse GET /v1/placehodler response
 	PlaceholderGetResponse struct {
-		ID     string `json:"id"`
-		Name   string `json:"name"`
-		Amount int    `json:"amount"`
-		Status string `json:"status"`
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Amount  int    `json:"amount"`
+		Version int64  `json:"version"`
+		Status  string `json:"status"`
 	}
 
 	// PlaceholderMessage Message to be exchanged via Kafka / message broker
@@ -49,14 +87,35 @@ type (
 		CreatedBy string
 		UpdatedAt time.Time
 		UpdatedBy string
+		Version   int64
 	}
 )
 
 func (pcr *PlaceholderCreateRequest) ToPlaceholderDTO() PlaceholderDTO {
 	return PlaceholderDTO{
-		ID:     uuid.New(),
-		Name:   pcr.Name,
-		Amount: pcr.Amount,
+		ID:      uuid.New(),
+		Name:    pcr.Name,
+		Amount:  pcr.Amount,
+		Version: 1,
+	}
+}
+
+func (pur *PlaceholderUpdateRequest) ApplyToPlaceholderDTO(pDTO *PlaceholderDTO) {
+	pDTO.Name = pur.Name
+	pDTO.Amount = pur.Amount
+}
+
+func (plr *PlaceholderListRequest) ToPlaceholderFilter() PlaceholderFilter {
+	return PlaceholderFilter{
+		NamePrefix:  plr.NamePrefix,
+		MinAmount:   plr.MinAmount,
+		MaxAmount:   plr.MaxAmount,
+		CreatedFrom: plr.CreatedFrom,
+		CreatedTo:   plr.CreatedTo,
+		SortBy:      plr.SortBy,
+		SortOrder:   plr.SortOrder,
+		Limit:       plr.PageSize,
+		Offset:      (plr.Page - 1) * plr.PageSize,
 	}
 }
 
@@ -73,16 +132,34 @@ func (pDTO *PlaceholderDTO) ToPlaceholderDAO() PlaceholderDAO {
 
 func (pDTO *PlaceholderDTO) ToPlaceholderCreateResponse() PlaceholderCreateResponse {
 	return PlaceholderCreateResponse{
-		ID:     pDTO.ID.String(),
-		Name:   pDTO.Name,
-		Amount: pDTO.Amount,
+		ID:      pDTO.ID.String(),
+		Name:    pDTO.Name,
+		Amount:  pDTO.Amount,
+		Version: pDTO.Version,
 	}
 }
 
 func (pDTO *PlaceholderDTO) ToPlaceholderGetResponse() PlaceholderGetResponse {
 	return PlaceholderGetResponse{
-		ID:     pDTO.ID.String(),
+		ID:      pDTO.ID.String(),
+		Name:    pDTO.Name,
+		Amount:  pDTO.Amount,
+		Version: pDTO.Version,
+	}
+}
+
+func (pDTO *PlaceholderDTO) ToPlaceholderUpdateRequest() PlaceholderUpdateRequest {
+	return PlaceholderUpdateRequest{
 		Name:   pDTO.Name,
 		Amount: pDTO.Amount,
 	}
 }
+
+func (pDTO *PlaceholderDTO) ToPlaceholderUpdateResponse() PlaceholderUpdateResponse {
+	return PlaceholderUpdateResponse{
+		ID:      pDTO.ID.String(),
+		Name:    pDTO.Name,
+		Amount:  pDTO.Amount,
+		Version: pDTO.Version,
+	}
+}
@@ -98,3 +98,95 @@ func TestPlaceholderDTO_ToPlaceholderGetResponse(t *testing.T) {
 		assert.Equal(t, expected, res)
 	})
 }
+
+func TestPlaceholderUpdateRequest_ApplyToPlaceholderDTO(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			input = PlaceholderUpdateRequest{
+				Name:   "Aoi",
+				Amount: 10000,
+			}
+
+			placeholderDTO = PlaceholderDTO{
+				ID:        uuid.New(),
+				Name:      "Minase",
+				Amount:    25000,
+				CreatedBy: "System",
+			}
+		)
+
+		input.ApplyToPlaceholderDTO(&placeholderDTO)
+		assert.Equal(t, input.Name, placeholderDTO.Name)
+		assert.Equal(t, input.Amount, placeholderDTO.Amount)
+		assert.Equal(t, "System", placeholderDTO.CreatedBy)
+	})
+}
+
+func TestPlaceholderListRequest_ToPlaceholderFilter(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			minAmount = 100
+			input     = PlaceholderListRequest{
+				NamePrefix: "Ao",
+				MinAmount:  &minAmount,
+				SortBy:     "amount",
+				SortOrder:  "asc",
+				Page:       3,
+				PageSize:   20,
+			}
+
+			expected = PlaceholderFilter{
+				NamePrefix: input.NamePrefix,
+				MinAmount:  input.MinAmount,
+				SortBy:     input.SortBy,
+				SortOrder:  input.SortOrder,
+				Limit:      20,
+				Offset:     40,
+			}
+		)
+
+		res := input.ToPlaceholderFilter()
+		assert.Equal(t, expected, res)
+	})
+}
+
+func TestPlaceholderDTO_ToPlaceholderUpdateRequest(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			input = PlaceholderDTO{
+				ID:     uuid.New(),
+				Name:   "Minase",
+				Amount: 25000,
+			}
+
+			expected = PlaceholderUpdateRequest{
+				Name:   input.Name,
+				Amount: input.Amount,
+			}
+		)
+
+		res := input.ToPlaceholderUpdateRequest()
+		assert.Equal(t, expected, res)
+	})
+}
+
+func TestPlaceholderDTO_ToPlaceholderUpdateResponse(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		var (
+			input = PlaceholderDTO{
+				ID:     uuid.New(),
+				Name:   "Minase",
+				Amount: 25000,
+			}
+
+			expected = PlaceholderUpdateResponse{
+				ID:     input.ID.String(),
+				Name:   input.Name,
+				Amount: input.Amount,
+			}
+		)
+
+		res := input.ToPlaceholderUpdateResponse()
+		assert.Equal(t, expected, res)
+	})
+}
@@ -9,8 +9,14 @@ import (
 	"net/http"
 
 	"github.com/dityuiri/go-adapter/client"
+	"github.com/dityuiri/go-adapter/client/request"
 	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/requestid"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/common/util"
 	"github.com/dityuiri/go-baseline/config"
 	"github.com/dityuiri/go-baseline/model/alpha"
@@ -19,20 +25,29 @@ import (
 type (
 	IAlphaProxy interface {
 		GetPlaceholderStatus(ctx context.Context, alphaReq alpha.AlphaRequest) (alpha.AlphaResponse, error)
+		Ping(ctx context.Context) error
 	}
 
 	AlphaProxy struct {
 		Logger              logger.ILogger
 		HTTPClient          client.IClient
 		ClientConfiguration config.HttpClient
+		Metrics             *metrics.Metrics
 	}
 )
 
 const (
 	getPlaceholderStatus = "/v1/placeholder/status"
+	getHealthCheck       = "/ping"
+
+	// metricsAlpha labels the metrics of the calls to Alpha
+	metricsAlpha = "alpha"
 )
 
 func (ap *AlphaProxy) GetPlaceholderStatus(ctx context.Context, alphaReq alpha.AlphaRequest) (alpha.AlphaResponse, error) {
+	ctx, span := tracing.StartClient(ctx, "AlphaProxy.GetPlaceholderStatus", tracing.HTTPRequest(http.MethodPost, getPlaceholderStatus)...)
+	defer span.End()
+
 	var (
 		result        = &alpha.AlphaResponse{}
 		header        = http.Header{}
@@ -41,19 +56,31 @@ func (ap *AlphaProxy) GetPlaceholderStatus(ctx context.Context, alphaReq alpha.A
 
 	reqOut, err := common.JsonMarshal(alphaReq)
 	if err != nil {
-		ap.Logger.Error("error marshaling request")
+		logging.WithContext(ctx, ap.Logger, logging.FieldPlaceholderID, alphaReq.PlaceholderID).Error("error marshaling request", log.WithError(err))
 		return *result, err
 	}
 
 	header.Set("Accept", "application/json, text/plain, */*")
-	resp, err := ap.HTTPClient.Post(finalEndpoint, bytes.NewBuffer(reqOut))
+	tracing.InjectHTTP(ctx, header)
+
+	start := common.TimeNow()
+
+	// The client creates a new request ID when there is none in the context
+	resp, err := ap.HTTPClient.Post(finalEndpoint, bytes.NewBuffer(reqOut),
+		request.WithContext(ctx),
+		request.WithHeaders(header),
+		request.WithRequestID(requestid.FromContext(ctx)),
+	)
+	ap.Metrics.ObserveProxyCall(metricsAlpha, getPlaceholderStatus, resp, err, start)
+	tracing.RecordResponse(span, resp, err)
+
 	if err != nil {
-		ap.Logger.Error("error executing POST request to Alpha")
+		logging.WithContext(ctx, ap.Logger, "endpoint", finalEndpoint).Error("error executing POST request to Alpha", log.WithError(err))
 		return *result, err
 	}
 
 	if err = util.HttpResponseBodyParser(resp, result); err != nil {
-		ap.Logger.Error(fmt.Sprintf("error parsing response: %s", err.Error()))
+		logging.WithContext(ctx, ap.Logger, "endpoint", finalEndpoint, "status", resp.StatusCode).Error("error parsing response", log.WithError(err))
 		return *result, err
 	}
 
@@ -68,3 +95,37 @@ func (ap *AlphaProxy) GetPlaceholderStatus(ctx context.Context, alphaReq alpha.A
 		return *result, common.ErrAlphaInternalServerError
 	}
 }
+
+// Ping verifies Alpha is up. Any answer but a server error means it is able to serve requests.
+func (ap *AlphaProxy) Ping(ctx context.Context) error {
+	ctx, span := tracing.StartClient(ctx, "AlphaProxy.Ping", tracing.HTTPRequest(http.MethodGet, getHealthCheck)...)
+	defer span.End()
+
+	var (
+		finalEndpoint = fmt.Sprintf("%s%s", ap.ClientConfiguration.ProxyURLs.AlphaURL, getHealthCheck)
+		header        = http.Header{}
+	)
+
+	tracing.InjectHTTP(ctx, header)
+
+	start := common.TimeNow()
+	resp, err := ap.HTTPClient.Get(finalEndpoint,
+		request.WithContext(ctx),
+		request.WithHeaders(header),
+		request.WithRequestID(requestid.FromContext(ctx)),
+	)
+	ap.Metrics.ObserveProxyCall(metricsAlpha, getHealthCheck, resp, err, start)
+	tracing.RecordResponse(span, resp, err)
+
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: status %d", common.ErrAlphaUnhealthy, resp.StatusCode)
+	}
+
+	return nil
+}
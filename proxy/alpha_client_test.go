@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 
@@ -17,12 +18,20 @@ import (
 
 	"github.com/dityuiri/go-adapter/client"
 	clientMock "github.com/dityuiri/go-adapter/client/mock"
+	"github.com/dityuiri/go-adapter/client/request"
 	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/requestid"
 	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/mock"
 	"github.com/dityuiri/go-baseline/model/alpha"
 )
 
+func TestMain(m *testing.M) {
+	mock.NewSpanRecorder()
+	os.Exit(m.Run())
+}
+
 func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 	var (
 		mockCtrl       = gomock.NewController(t)
@@ -60,6 +69,34 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 		header              = http.Header{}
 	)
 
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive - request id is forwarded", func(t *testing.T) {
+		var (
+			reader   = io.NopCloser(bytes.NewReader(marshalledOutput))
+			response = &http.Response{
+				Header:     header,
+				Body:       reader,
+				StatusCode: http.StatusOK,
+			}
+
+			requestCtx = requestid.NewContext(ctx, "request-1")
+		)
+
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ string, _ io.Reader, opts ...request.Option) (*http.Response, error) {
+				options := request.NewOptions(context.Background(), opts...)
+				assert.Equal(t, "request-1", options.RequestID)
+				assert.True(t, mock.InSpan(requestCtx, "AlphaProxy.GetPlaceholderStatus").Matches(options.Context))
+				assert.NotEmpty(t, options.Headers.Get("traceparent"))
+				return response, nil
+			}).Times(1)
+
+		header.Set("Content-Type", "application/json")
+		_, err := proxy.GetPlaceholderStatus(requestCtx, alphaReq)
+		assert.Nil(t, err)
+	})
+
 	t.Run("positive - statusOK", func(t *testing.T) {
 		var (
 			reader   = io.NopCloser(bytes.NewReader(marshalledOutput))
@@ -70,7 +107,7 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			}
 		)
 
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(response, nil).Times(1)
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(response, nil).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -88,8 +125,8 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			}
 		)
 
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(response, nil).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(response, nil).Times(1)
+		mockLogger.EXPECT().Error("error parsing response", gomock.Any()).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -107,7 +144,7 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			}
 		)
 
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(response, nil).Times(1)
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(response, nil).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -125,7 +162,7 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			}
 		)
 
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(response, nil).Times(1)
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(response, nil).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -143,7 +180,7 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			}
 		)
 
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(response, nil).Times(1)
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(response, nil).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -152,8 +189,10 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 	})
 
 	t.Run("client post method error", func(t *testing.T) {
-		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any()).Return(&http.Response{}, errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		postErr := errors.New("error")
+
+		mockHTTPClient.EXPECT().Post(finalEndpoint, gomock.Any(), gomock.Any()).Return(&http.Response{}, postErr).Times(1)
+		mockLogger.EXPECT().Error("error executing POST request to Alpha", mock.LogWith(postErr, "endpoint="+finalEndpoint)).Times(1)
 
 		header.Set("Content-Type", "application/json")
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
@@ -167,7 +206,7 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 			return []byte{}, errors.New("error")
 		}
 
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockLogger.EXPECT().Error("error marshaling request", gomock.Any()).Times(1)
 		res, err := proxy.GetPlaceholderStatus(ctx, alphaReq)
 		assert.EqualError(t, err, "error")
 		assert.Empty(t, res)
@@ -176,3 +215,49 @@ func TestAlphaProxy_GetPlaceholderStatus(t *testing.T) {
 	})
 
 }
+
+func TestAlphaProxy_Ping(t *testing.T) {
+	var (
+		mockCtrl       = gomock.NewController(t)
+		mockHTTPClient = clientMock.NewMockIClient(mockCtrl)
+
+		proxy = AlphaProxy{
+			HTTPClient: mockHTTPClient,
+			ClientConfiguration: config.HttpClient{
+				ProxyURLs: config.ProxyURLs{
+					AlphaURL: "localhost:8080",
+				},
+			},
+		}
+
+		ctx           = context.Background()
+		finalEndpoint = "localhost:8080" + getHealthCheck
+
+		response = func(statusCode int) *http.Response {
+			return &http.Response{
+				Body:       io.NopCloser(strings.NewReader("")),
+				StatusCode: statusCode,
+			}
+		}
+	)
+
+	t.Run("positive", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Get(finalEndpoint, gomock.Any(), gomock.Any(), gomock.Any()).Return(response(http.StatusOK), nil).Times(1)
+		assert.Nil(t, proxy.Ping(ctx))
+	})
+
+	t.Run("positive - client error still means alpha is up", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Get(finalEndpoint, gomock.Any(), gomock.Any(), gomock.Any()).Return(response(http.StatusNotFound), nil).Times(1)
+		assert.Nil(t, proxy.Ping(ctx))
+	})
+
+	t.Run("negative - server error", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Get(finalEndpoint, gomock.Any(), gomock.Any(), gomock.Any()).Return(response(http.StatusServiceUnavailable), nil).Times(1)
+		assert.ErrorIs(t, proxy.Ping(ctx), common.ErrAlphaUnhealthy)
+	})
+
+	t.Run("negative - request error", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Get(finalEndpoint, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")).Times(1)
+		assert.EqualError(t, proxy.Ping(ctx), "connection refused")
+	})
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	"github.com/dityuiri/go-adapter/kafka/consumer"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/dead_letter_consumer.go . IDeadLetterConsumer
+
+type (
+	// IDeadLetterConsumer reads the messages the consumers failed with
+	IDeadLetterConsumer interface {
+		FetchDeadLetter(ctx context.Context, name string) (model.DeadLetter, error)
+	}
+
+	// DeadLetterConsumer reads the {name}_dlq topic of PRODUCER_TOPICS. It fetches without committing,
+	// so inspecting the dead letters leaves them in place.
+	DeadLetterConsumer struct {
+		Consumer    consumer.IConsumer
+		KafkaConfig *config.Kafka
+	}
+)
+
+// FetchDeadLetter returns the next dead letter of the logical topic name, waiting for it until ctx is done
+func (c *DeadLetterConsumer) FetchDeadLetter(ctx context.Context, name string) (model.DeadLetter, error) {
+	topic, err := deadLetterTopic(c.KafkaConfig, name)
+	if err != nil {
+		return model.DeadLetter{}, err
+	}
+
+	msg, err := c.Consumer.Fetch(ctx, topic)
+	if err != nil {
+		return model.DeadLetter{}, err
+	}
+
+	letter, err := parseDeadLetter(*msg)
+	if err != nil {
+		return letter, fmt.Errorf("%s[%d]%d: %w", topic, msg.Partition, msg.Offset, err)
+	}
+
+	return letter, nil
+}
+
+// parseDeadLetter reads the failure from the dead letter headers, the other headers are the original ones
+func parseDeadLetter(msg kafka.Message) (model.DeadLetter, error) {
+	value, _ := msg.Value.([]byte)
+	letter := model.DeadLetter{
+		Key:     msg.Key,
+		Value:   value,
+		Headers: make(map[string][]byte, len(msg.Headers)),
+	}
+
+	if _, found := msg.Headers[HeaderDeadLetterTopic]; !found {
+		return letter, common.ErrNotDeadLetter
+	}
+
+	for key, value := range msg.Headers {
+		var err error
+		switch key {
+		case HeaderDeadLetterTopic:
+			letter.Topic = string(value)
+		case HeaderDeadLetterPartition:
+			letter.Partition, err = strconv.Atoi(string(value))
+		case HeaderDeadLetterOffset:
+			letter.Offset, err = strconv.ParseInt(string(value), 10, 64)
+		case HeaderDeadLetterError:
+			letter.Error = string(value)
+		case HeaderDeadLetterFailures:
+			letter.Failures, err = strconv.Atoi(string(value))
+		case HeaderDeadLetterFailedAt:
+			letter.FailedAt, err = time.Parse(time.RFC3339Nano, string(value))
+		default:
+			letter.Headers[key] = value
+		}
+
+		if err != nil {
+			return letter, fmt.Errorf("header %s: %w", key, err)
+		}
+	}
+
+	return letter, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	consumerMock "github.com/dityuiri/go-adapter/kafka/consumer/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestDeadLetterConsumer_FetchDeadLetter(t *testing.T) {
+	var (
+		mockCtrl     = gomock.NewController(t)
+		mockConsumer = consumerMock.NewMockIConsumer(mockCtrl)
+
+		reader = DeadLetterConsumer{
+			Consumer: mockConsumer,
+			KafkaConfig: &config.Kafka{
+				ProducerTopics: map[string]string{"placeholder_dlq": "placeholder_dlq"},
+			},
+		}
+
+		ctx     = context.Background()
+		headers = func() kafka.Header {
+			return kafka.Header{
+				"request_id":              []byte("request-1"),
+				HeaderDeadLetterTopic:     []byte("placeholder-record"),
+				HeaderDeadLetterPartition: []byte("2"),
+				HeaderDeadLetterOffset:    []byte("42"),
+				HeaderDeadLetterError:     []byte("alpha unavailable"),
+				HeaderDeadLetterFailures:  []byte("4"),
+				HeaderDeadLetterFailedAt:  []byte("2026-10-18T08:30:00Z"),
+			}
+		}
+	)
+
+	t.Run("positive", func(t *testing.T) {
+		mockConsumer.EXPECT().Fetch(ctx, "placeholder_dlq").Return(&kafka.Message{
+			Offset:  3,
+			Key:     []byte("key"),
+			Value:   []byte(`{"id":"1"}`),
+			Headers: headers(),
+		}, nil)
+
+		letter, err := reader.FetchDeadLetter(ctx, "placeholder")
+		assert.Nil(t, err)
+		assert.Equal(t, model.DeadLetter{
+			Topic:     "placeholder-record",
+			Partition: 2,
+			Offset:    42,
+			Key:       []byte("key"),
+			Value:     []byte(`{"id":"1"}`),
+			Headers:   map[string][]byte{"request_id": []byte("request-1")},
+			Error:     "alpha unavailable",
+			Failures:  4,
+			FailedAt:  time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC),
+		}, letter)
+	})
+
+	t.Run("negative - not a dead letter", func(t *testing.T) {
+		mockConsumer.EXPECT().Fetch(ctx, "placeholder_dlq").Return(&kafka.Message{Offset: 3, Value: []byte("1")}, nil)
+
+		_, err := reader.FetchDeadLetter(ctx, "placeholder")
+		assert.ErrorIs(t, err, common.ErrNotDeadLetter)
+		assert.Contains(t, err.Error(), "placeholder_dlq[0]3")
+	})
+
+	t.Run("negative - malformed header", func(t *testing.T) {
+		malformed := headers()
+		malformed[HeaderDeadLetterFailures] = []byte("four")
+		mockConsumer.EXPECT().Fetch(ctx, "placeholder_dlq").Return(&kafka.Message{Value: []byte("1"), Headers: malformed}, nil)
+
+		_, err := reader.FetchDeadLetter(ctx, "placeholder")
+		assert.Contains(t, err.Error(), HeaderDeadLetterFailures)
+	})
+
+	t.Run("negative - fetch failed", func(t *testing.T) {
+		fetchErr := errors.New("broker unavailable")
+		mockConsumer.EXPECT().Fetch(ctx, "placeholder_dlq").Return(nil, fetchErr)
+
+		_, err := reader.FetchDeadLetter(ctx, "placeholder")
+		assert.ErrorIs(t, err, fetchErr)
+	})
+
+	t.Run("negative - no dead letter topic", func(t *testing.T) {
+		_, err := reader.FetchDeadLetter(ctx, "audit")
+		assert.ErrorIs(t, err, common.ErrDeadLetterTopicNotFound)
+	})
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	"github.com/dityuiri/go-adapter/kafka/producer"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/tracing"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/dead_letter_producer.go . IDeadLetterProducer
+
+type (
+	// IDeadLetterProducer publishes the messages the consumers failed with
+	IDeadLetterProducer interface {
+		ProduceDeadLetter(ctx context.Context, name string, letter model.DeadLetter) error
+	}
+
+	// DeadLetterProducer publishes a failed message of the logical topic name to the {name}_dlq topic of PRODUCER_TOPICS
+	DeadLetterProducer struct {
+		Producer    producer.IProducer
+		KafkaConfig *config.Kafka
+		Metrics     *metrics.Metrics
+	}
+)
+
+// Headers of a dead letter, describing the failure. The headers of the original message are kept along.
+const (
+	HeaderDeadLetterTopic     = "dlq_source_topic"
+	HeaderDeadLetterPartition = "dlq_source_partition"
+	HeaderDeadLetterOffset    = "dlq_source_offset"
+	HeaderDeadLetterError     = "dlq_error"
+	HeaderDeadLetterFailures  = "dlq_failures"
+	HeaderDeadLetterFailedAt  = "dlq_failed_at"
+
+	// deadLetterSuffix names the dead letter topic of a logical topic in PRODUCER_TOPICS
+	deadLetterSuffix = "_dlq"
+)
+
+// ProduceDeadLetter publishes the original key, value and headers of the message with the dead letter headers
+func (p *DeadLetterProducer) ProduceDeadLetter(ctx context.Context, name string, letter model.DeadLetter) error {
+	topic, err := deadLetterTopic(p.KafkaConfig, name)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := tracing.StartProducer(ctx, "DeadLetterProducer.ProduceDeadLetter", tracing.MessagingDestination(topic))
+	defer span.End()
+
+	err = p.Producer.Produce(ctx, topic, constructDeadLetter(letter))
+	p.Metrics.ObserveProduce(topic, 1, err)
+	tracing.RecordError(span, err)
+
+	return err
+}
+
+func constructDeadLetter(letter model.DeadLetter) *kafka.Message {
+	headers := make(kafka.Header, len(letter.Headers)+6)
+	for key, value := range letter.Headers {
+		headers[key] = value
+	}
+
+	headers[HeaderDeadLetterTopic] = []byte(letter.Topic)
+	headers[HeaderDeadLetterPartition] = []byte(strconv.Itoa(letter.Partition))
+	headers[HeaderDeadLetterOffset] = []byte(strconv.FormatInt(letter.Offset, 10))
+	headers[HeaderDeadLetterError] = []byte(letter.Error)
+	headers[HeaderDeadLetterFailures] = []byte(strconv.Itoa(letter.Failures))
+	headers[HeaderDeadLetterFailedAt] = []byte(letter.FailedAt.UTC().Format(time.RFC3339Nano))
+
+	return &kafka.Message{
+		Key:     letter.Key,
+		Value:   letter.Value,
+		Headers: headers,
+	}
+}
+
+// deadLetterTopic returns the dead letter topic of the logical topic name
+func deadLetterTopic(kafkaConfig *config.Kafka, name string) (string, error) {
+	topic := kafkaConfig.ProducerTopics[name+deadLetterSuffix]
+	if topic == "" {
+		return "", fmt.Errorf("%s: %w", name, common.ErrDeadLetterTopicNotFound)
+	}
+
+	return topic, nil
+}
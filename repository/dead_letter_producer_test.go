@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-adapter/kafka"
+	producerMock "github.com/dityuiri/go-adapter/kafka/producer/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/mock"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestDeadLetterProducer_ProduceDeadLetter(t *testing.T) {
+	var (
+		mockCtrl     = gomock.NewController(t)
+		mockProducer = producerMock.NewMockIProducer(mockCtrl)
+
+		producer = DeadLetterProducer{
+			Producer: mockProducer,
+			KafkaConfig: &config.Kafka{
+				ProducerTopics: map[string]string{
+					"placeholder":     "placeholder",
+					"placeholder_dlq": "placeholder_dlq",
+				},
+			},
+		}
+
+		ctx    = context.Background()
+		letter = model.DeadLetter{
+			Topic:     "placeholder-record",
+			Partition: 2,
+			Offset:    42,
+			Key:       []byte("key"),
+			Value:     []byte(`{"id":"1"}`),
+			Headers:   map[string][]byte{"request_id": []byte("request-1")},
+			Error:     "alpha unavailable",
+			Failures:  4,
+			FailedAt:  time.Date(2026, 10, 18, 8, 30, 0, 5, time.UTC),
+		}
+	)
+
+	t.Run("positive", func(t *testing.T) {
+		mockProducer.EXPECT().Produce(mock.InSpan(ctx, "DeadLetterProducer.ProduceDeadLetter"), "placeholder_dlq", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Len(t, messages, 1)
+				assert.Equal(t, &kafka.Message{
+					Key:   []byte("key"),
+					Value: []byte(`{"id":"1"}`),
+					Headers: kafka.Header{
+						"request_id":              []byte("request-1"),
+						HeaderDeadLetterTopic:     []byte("placeholder-record"),
+						HeaderDeadLetterPartition: []byte("2"),
+						HeaderDeadLetterOffset:    []byte("42"),
+						HeaderDeadLetterError:     []byte("alpha unavailable"),
+						HeaderDeadLetterFailures:  []byte("4"),
+						HeaderDeadLetterFailedAt:  []byte("2026-10-18T08:30:00.000000005Z"),
+					},
+				}, messages[0])
+				return nil
+			})
+
+		assert.Nil(t, producer.ProduceDeadLetter(ctx, "placeholder", letter))
+		assert.Equal(t, map[string][]byte{"request_id": []byte("request-1")}, letter.Headers)
+	})
+
+	t.Run("negative - produce failed", func(t *testing.T) {
+		produceErr := errors.New("broker unavailable")
+		mockProducer.EXPECT().Produce(gomock.Any(), "placeholder_dlq", gomock.Any()).Return(produceErr)
+
+		assert.ErrorIs(t, producer.ProduceDeadLetter(ctx, "placeholder", letter), produceErr)
+	})
+
+	t.Run("negative - no dead letter topic", func(t *testing.T) {
+		err := producer.ProduceDeadLetter(ctx, "audit", letter)
+		assert.ErrorIs(t, err, common.ErrDeadLetterTopicNotFound)
+		assert.Contains(t, err.Error(), "audit")
+	})
+}
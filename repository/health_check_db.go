@@ -2,18 +2,64 @@ package repository
 
 //go:generate mockgen -package=repository_mock -destination=../mock/repository/health_check.go . IHealthCheckRepository
 
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/dityuiri/go-adapter/db"
+	"github.com/dityuiri/go-adapter/redis"
+	"github.com/dityuiri/go-baseline/common"
+)
+
 type (
 	// IHealthCheckRepository is a repository interface that consists of repository functions for app health checking
 	IHealthCheckRepository interface {
-		Ping() error
+		PingDatabase(ctx context.Context) error
+		PingRedis(ctx context.Context) error
+		PingKafka(ctx context.Context) error
 	}
 
-	// HealthCheckRepository is a struct that consists of App that has Database adapter inside
+	// HealthCheckRepository is a struct that consists of the adapters to be health checked
 	HealthCheckRepository struct {
+		DB           db.IDatabase
+		Redis        redis.IRedis
+		KafkaBrokers []string
 	}
 )
 
-// Ping is a repository function for app health checking
-func (r *HealthCheckRepository) Ping() error {
+// PingDatabase verifies the connection to the database is alive
+func (r *HealthCheckRepository) PingDatabase(_ context.Context) error {
+	return r.DB.Ping()
+}
+
+// PingRedis verifies redis answers a PING
+func (r *HealthCheckRepository) PingRedis(_ context.Context) error {
+	if connected, _ := r.Redis.Status()["connected"].(bool); !connected {
+		return common.ErrRedisNotConnected
+	}
+
 	return nil
 }
+
+// PingKafka verifies at least one of the brokers accepts connections.
+// The producer and the consumer of the adapter share the brokers, and open their connections lazily.
+func (r *HealthCheckRepository) PingKafka(ctx context.Context) error {
+	var (
+		dialer net.Dialer
+		errs   []error
+	)
+
+	for _, broker := range r.KafkaBrokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		_ = conn.Close()
+		return nil
+	}
+
+	return errors.Join(append([]error{common.ErrKafkaUnreachable}, errs...)...)
+}
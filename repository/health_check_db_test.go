@@ -1,20 +1,77 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+
+	databaseMock "github.com/dityuiri/go-adapter/db/mock"
+	redisMock "github.com/dityuiri/go-adapter/redis/mock"
+	"github.com/dityuiri/go-baseline/common"
 )
 
-func TestPing(t *testing.T) {
+func TestHealthCheckRepository_PingDatabase(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+
+		r = HealthCheckRepository{DB: mockDB}
+	)
+
 	t.Run("ping success", func(t *testing.T) {
-		mockCtrl := gomock.NewController(t)
-		defer mockCtrl.Finish()
+		mockDB.EXPECT().Ping().Return(nil).Times(1)
+		assert.Nil(t, r.PingDatabase(context.Background()))
+	})
 
-		r := HealthCheckRepository{}
+	t.Run("ping error", func(t *testing.T) {
+		mockDB.EXPECT().Ping().Return(errors.New("error")).Times(1)
+		assert.EqualError(t, r.PingDatabase(context.Background()), "error")
+	})
+}
+
+func TestHealthCheckRepository_PingRedis(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockRedis = redisMock.NewMockIRedis(mockCtrl)
+
+		r = HealthCheckRepository{Redis: mockRedis}
+	)
+
+	t.Run("ping success", func(t *testing.T) {
+		mockRedis.EXPECT().Status().Return(map[string]interface{}{"connected": true}).Times(1)
+		assert.Nil(t, r.PingRedis(context.Background()))
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		mockRedis.EXPECT().Status().Return(map[string]interface{}{"connected": false}).Times(1)
+		assert.Equal(t, common.ErrRedisNotConnected, r.PingRedis(context.Background()))
+	})
+}
+
+func TestHealthCheckRepository_PingKafka(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	defer listener.Close()
+
+	// An address nobody listens on anymore
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	unreachable := closed.Addr().String()
+	_ = closed.Close()
+
+	t.Run("ping success", func(t *testing.T) {
+		r := HealthCheckRepository{KafkaBrokers: []string{unreachable, listener.Addr().String()}}
+		assert.Nil(t, r.PingKafka(context.Background()))
+	})
 
-		err := r.Ping()
-		assert.Equal(t, err, nil)
+	t.Run("no broker reachable", func(t *testing.T) {
+		r := HealthCheckRepository{KafkaBrokers: []string{unreachable}}
+		assert.ErrorIs(t, r.PingKafka(context.Background()), common.ErrKafkaUnreachable)
 	})
 }
@@ -0,0 +1,80 @@
+package repository
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/idempotency_cache.go . IIdempotencyCache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dityuiri/go-baseline/model"
+
+	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/redis"
+)
+
+type (
+	IIdempotencyCache interface {
+		GetRecord(ctx context.Context, key string) (*model.IdempotencyRecord, error)
+		SetRecord(ctx context.Context, key string, record model.IdempotencyRecord, ttl time.Duration) error
+		ClaimRecord(ctx context.Context, key string, record model.IdempotencyRecord, ttl time.Duration) (bool, error)
+		DeleteRecord(ctx context.Context, key string) error
+	}
+
+	IdempotencyCache struct {
+		Redis       redis.IRedis
+		RedisClient IRedisClient
+		Logger      logger.ILogger
+	}
+)
+
+const (
+	keyIdempotency = "idempotency:%s"
+)
+
+// GetRecord returns redis.Nil of github.com/go-redis/redis when the key was never used, or its record expired
+func (ic *IdempotencyCache) GetRecord(ctx context.Context, key string) (*model.IdempotencyRecord, error) {
+	var result = &model.IdempotencyRecord{}
+
+	// Redis adapter is not context aware, don't bother calling it once the request is done
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	err := ic.Redis.GetAndParseBytes(fmt.Sprintf(keyIdempotency, key), result)
+	return result, err
+}
+
+func (ic *IdempotencyCache) SetRecord(ctx context.Context, key string, record model.IdempotencyRecord, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := ic.Redis.SetExAsBytes(fmt.Sprintf(keyIdempotency, key), record, ttl)
+	return err
+}
+
+// ClaimRecord sets the record only when the key is not set yet, and reports whether it did.
+// Of concurrent claims of the same key, exactly one succeeds.
+func (ic *IdempotencyCache) ClaimRecord(ctx context.Context, key string, record model.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	return ic.RedisClient.SetNX(fmt.Sprintf(keyIdempotency, key), data, ttl).Result()
+}
+
+func (ic *IdempotencyCache) DeleteRecord(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := ic.Redis.Del(fmt.Sprintf(keyIdempotency, key))
+	return err
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	redisMock "github.com/dityuiri/go-adapter/redis/mock"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	var (
+		mockCtrl        = gomock.NewController(t)
+		mockRedis       = redisMock.NewMockIRedis(mockCtrl)
+		mockRedisClient = repositoryMock.NewMockIRedisClient(mockCtrl)
+		mockLogger      = loggerMock.NewMockILogger(mockCtrl)
+
+		idempotencyCache = IdempotencyCache{
+			Redis:       mockRedis,
+			RedisClient: mockRedisClient,
+			Logger:      mockLogger,
+		}
+
+		ctx    = context.Background()
+		key    = fmt.Sprintf(keyIdempotency, "user-1:key-1")
+		record = model.IdempotencyRecord{Fingerprint: "fingerprint", State: model.IdempotencyCompleted}
+
+		canceledCtx, cancel = context.WithCancel(ctx)
+	)
+
+	cancel()
+
+	t.Run("get record", func(t *testing.T) {
+		mockRedis.EXPECT().GetAndParseBytes(key, gomock.Any()).DoAndReturn(func(_ string, data interface{}) error {
+			*data.(*model.IdempotencyRecord) = record
+			return nil
+		}).Times(1)
+
+		result, err := idempotencyCache.GetRecord(ctx, "user-1:key-1")
+		assert.Nil(t, err)
+		assert.Equal(t, record, *result)
+	})
+
+	t.Run("set record", func(t *testing.T) {
+		mockRedis.EXPECT().SetExAsBytes(key, record, time.Hour).Return(errors.New("error")).Times(1)
+
+		err := idempotencyCache.SetRecord(ctx, "user-1:key-1", record, time.Hour)
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("claim record", func(t *testing.T) {
+		data, _ := json.Marshal(record)
+		mockRedisClient.EXPECT().SetNX(key, data, time.Minute).Return(redis.NewBoolResult(true, nil)).Times(1)
+
+		claimed, err := idempotencyCache.ClaimRecord(ctx, "user-1:key-1", record, time.Minute)
+		assert.Nil(t, err)
+		assert.True(t, claimed)
+	})
+
+	t.Run("claim record already claimed", func(t *testing.T) {
+		mockRedisClient.EXPECT().SetNX(key, gomock.Any(), time.Minute).Return(redis.NewBoolResult(false, nil)).Times(1)
+
+		claimed, err := idempotencyCache.ClaimRecord(ctx, "user-1:key-1", record, time.Minute)
+		assert.Nil(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("delete record", func(t *testing.T) {
+		mockRedis.EXPECT().Del(key).Return(nil).Times(1)
+
+		err := idempotencyCache.DeleteRecord(ctx, "user-1:key-1")
+		assert.Nil(t, err)
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		_, err := idempotencyCache.GetRecord(canceledCtx, "user-1:key-1")
+		assert.ErrorIs(t, err, context.Canceled)
+
+		err = idempotencyCache.SetRecord(canceledCtx, "user-1:key-1", record, time.Hour)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = idempotencyCache.ClaimRecord(canceledCtx, "user-1:key-1", record, time.Minute)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		err = idempotencyCache.DeleteRecord(canceledCtx, "user-1:key-1")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,234 @@
+package repository
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/migration.go . IMigrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/dityuiri/go-adapter/db"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+type (
+	// IMigrator applies and reverts the migrations of the database schema
+	IMigrator interface {
+		Up(ctx context.Context, steps int) ([]model.Migration, error)
+		Down(ctx context.Context, steps int) ([]model.Migration, error)
+		Status(ctx context.Context) (model.MigrationStatus, error)
+	}
+
+	// Migrator runs the migration files of Migrations, named {version}_{description}.{up|down}.sql.
+	// The version is kept in the schema_migrations table of golang-migrate, so both tools can run the same files.
+	Migrator struct {
+		DB         db.IDatabase
+		Migrations fs.FS
+	}
+)
+
+const (
+	queryCreateMigrationTable = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"
+	queryGetMigrationVersion  = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+	queryClearMigrationTable  = "DELETE FROM schema_migrations"
+	querySetMigrationVersion  = "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)"
+)
+
+var migrationFileName = regexp.MustCompile(`^([0-9]+)_(.*)\.(up|down)\.sql$`)
+
+// Up applies the pending migrations in version order, all of them when steps is 0. It returns the applied ones,
+// including those applied before a migration failed.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]model.Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := status.Pending
+	if steps > 0 && steps < len(pending) {
+		pending = pending[:steps]
+	}
+
+	var applied []model.Migration
+	for _, migration := range pending {
+		if err = m.run(ctx, migration.UpFile, migration.Version); err != nil {
+			return applied, fmt.Errorf("migration %d up: %w", migration.Version, err)
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the last applied migrations, the latest first, all of them when steps is 0. It returns the reverted
+// ones, including those reverted before a migration failed.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]model.Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []model.Migration
+	for i := len(status.Applied) - 1; i >= 0; i-- {
+		if steps > 0 && len(reverted) == steps {
+			break
+		}
+
+		// The version drops to the previous migration, none is left after the first one
+		var version uint64
+		if i > 0 {
+			version = status.Applied[i-1].Version
+		}
+
+		migration := status.Applied[i]
+		if migration.DownFile == "" {
+			return reverted, fmt.Errorf("migration %d down: no down file", migration.Version)
+		}
+
+		if err = m.run(ctx, migration.DownFile, version); err != nil {
+			return reverted, fmt.Errorf("migration %d down: %w", migration.Version, err)
+		}
+
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status returns the version of the database and splits the migration files between the applied and the pending
+// ones. It refuses a dirty database, which golang-migrate leaves when a migration fails half way.
+func (m *Migrator) Status(ctx context.Context) (model.MigrationStatus, error) {
+	migrations, err := m.migrations()
+	if err != nil {
+		return model.MigrationStatus{}, err
+	}
+
+	if _, err = m.DB.ExecuteContext(ctx, queryCreateMigrationTable); err != nil {
+		return model.MigrationStatus{}, err
+	}
+
+	var (
+		status  model.MigrationStatus
+		version int64
+	)
+
+	err = m.DB.QueryRowContext(ctx, queryGetMigrationVersion).Scan(&version, &status.Dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return model.MigrationStatus{}, err
+	}
+
+	status.Version = uint64(version)
+	if status.Dirty {
+		return status, common.ErrDirtyMigration
+	}
+
+	found := status.Version == 0
+	for _, migration := range migrations {
+		if migration.Version > status.Version {
+			status.Pending = append(status.Pending, migration)
+			continue
+		}
+
+		status.Applied = append(status.Applied, migration)
+		found = found || migration.Version == status.Version
+	}
+
+	if !found {
+		return status, fmt.Errorf("version %d: %w", status.Version, common.ErrUnknownMigration)
+	}
+
+	return status, nil
+}
+
+// run executes the script and sets the version in one transaction, a failing migration changes nothing.
+// Version 0 clears the version, no migration is applied anymore.
+func (m *Migrator) run(ctx context.Context, file string, version uint64) error {
+	script, err := fs.ReadFile(m.Migrations, file)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err = m.runInTx(ctx, tx, string(script), version); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) runInTx(ctx context.Context, tx db.ITransaction, script string, version uint64) error {
+	if _, err := tx.ExecuteContext(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecuteContext(ctx, queryClearMigrationTable); err != nil {
+		return err
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecuteContext(ctx, querySetMigrationVersion, int64(version))
+	return err
+}
+
+// migrations reads the migration files, ordered by version
+func (m *Migrator) migrations() ([]model.Migration, error) {
+	files, err := fs.Glob(m.Migrations, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*model.Migration)
+	for _, file := range files {
+		match := migrationFileName.FindStringSubmatch(file)
+		if match == nil {
+			return nil, fmt.Errorf("%s: %w", file, common.ErrInvalidMigration)
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%s: %w", file, common.ErrInvalidMigration)
+		}
+
+		migration, found := byVersion[version]
+		if !found {
+			migration = &model.Migration{Version: version, Description: match[2]}
+			byVersion[version] = migration
+		}
+
+		if match[3] == "up" {
+			migration.UpFile = file
+		} else {
+			migration.DownFile = file
+		}
+	}
+
+	migrations := make([]model.Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpFile == "" {
+			return nil, fmt.Errorf("migration %d has no up file: %w", migration.Version, common.ErrInvalidMigration)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
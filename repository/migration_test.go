@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	databaseMock "github.com/dityuiri/go-adapter/db/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/model"
+)
+
+var migrationFiles = fstest.MapFS{
+	"000001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (id INT);")},
+	"000001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+	"000002_add_column.up.sql":     {Data: []byte("ALTER TABLE t ADD COLUMN c INT;")},
+	"000002_add_column.down.sql":   {Data: []byte("ALTER TABLE t DROP COLUMN c;")},
+}
+
+// expectMigrationVersion expects the version of the database to be read, with err as the error of the scan
+func expectMigrationVersion(mockDB *databaseMock.MockIDatabase, mockRow *databaseMock.MockIRow, version int64, dirty bool, err error) {
+	mockDB.EXPECT().ExecuteContext(gomock.Any(), queryCreateMigrationTable).Return(nil, nil)
+	mockDB.EXPECT().QueryRowContext(gomock.Any(), queryGetMigrationVersion).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*dest[0].(*int64), *dest[1].(*bool) = version, dirty
+		return err
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
+
+		migrator = Migrator{DB: mockDB, Migrations: migrationFiles}
+		ctx      = context.Background()
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - no migration applied", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 0, false, sql.ErrNoRows)
+
+		status, err := migrator.Status(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0), status.Version)
+		assert.Empty(t, status.Applied)
+		assert.Equal(t, []model.Migration{
+			{Version: 1, Description: "create_table", UpFile: "000001_create_table.up.sql", DownFile: "000001_create_table.down.sql"},
+			{Version: 2, Description: "add_column", UpFile: "000002_add_column.up.sql", DownFile: "000002_add_column.down.sql"},
+		}, status.Pending)
+	})
+
+	t.Run("positive - first migration applied", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 1, false, nil)
+
+		status, err := migrator.Status(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), status.Version)
+		assert.Len(t, status.Applied, 1)
+		assert.Len(t, status.Pending, 1)
+	})
+
+	t.Run("negative - dirty", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 2, true, nil)
+
+		status, err := migrator.Status(ctx)
+		assert.ErrorIs(t, err, common.ErrDirtyMigration)
+		assert.True(t, status.Dirty)
+	})
+
+	t.Run("negative - version without migration file", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 3, false, nil)
+
+		_, err := migrator.Status(ctx)
+		assert.ErrorIs(t, err, common.ErrUnknownMigration)
+	})
+
+	t.Run("negative - invalid file name", func(t *testing.T) {
+		invalid := Migrator{DB: mockDB, Migrations: fstest.MapFS{"create_table.sql": {}}}
+
+		_, err := invalid.Status(ctx)
+		assert.ErrorIs(t, err, common.ErrInvalidMigration)
+	})
+
+	t.Run("negative - create table error", func(t *testing.T) {
+		mockDB.EXPECT().ExecuteContext(gomock.Any(), queryCreateMigrationTable).Return(nil, errors.New("error"))
+
+		_, err := migrator.Status(ctx)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMigrator_Up(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
+		mockTx   = databaseMock.NewMockITransaction(mockCtrl)
+
+		migrator = Migrator{DB: mockDB, Migrations: migrationFiles}
+		ctx      = context.Background()
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - every pending migration", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 0, false, sql.ErrNoRows)
+
+		gomock.InOrder(
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "CREATE TABLE t (id INT);").Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, queryClearMigrationTable).Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, querySetMigrationVersion, int64(1)).Return(nil, nil),
+			mockTx.EXPECT().Commit().Return(nil),
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "ALTER TABLE t ADD COLUMN c INT;").Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, queryClearMigrationTable).Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, querySetMigrationVersion, int64(2)).Return(nil, nil),
+			mockTx.EXPECT().Commit().Return(nil),
+		)
+
+		applied, err := migrator.Up(ctx, 0)
+		assert.Nil(t, err)
+		assert.Len(t, applied, 2)
+	})
+
+	t.Run("positive - one step", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 0, false, sql.ErrNoRows)
+
+		mockDB.EXPECT().Begin().Return(mockTx, nil)
+		mockTx.EXPECT().ExecuteContext(ctx, gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mockTx.EXPECT().Commit().Return(nil)
+
+		applied, err := migrator.Up(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), applied[0].Version)
+	})
+
+	t.Run("positive - up to date", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 2, false, nil)
+
+		applied, err := migrator.Up(ctx, 0)
+		assert.Nil(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("negative - failing migration is rolled back", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 1, false, nil)
+
+		mockDB.EXPECT().Begin().Return(mockTx, nil)
+		mockTx.EXPECT().ExecuteContext(ctx, "ALTER TABLE t ADD COLUMN c INT;").Return(nil, errors.New("error"))
+		mockTx.EXPECT().Rollback().Return(nil)
+
+		applied, err := migrator.Up(ctx, 0)
+		assert.EqualError(t, err, "migration 2 up: error")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("negative - dirty", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 1, true, nil)
+
+		_, err := migrator.Up(ctx, 0)
+		assert.ErrorIs(t, err, common.ErrDirtyMigration)
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
+		mockTx   = databaseMock.NewMockITransaction(mockCtrl)
+
+		migrator = Migrator{DB: mockDB, Migrations: migrationFiles}
+		ctx      = context.Background()
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - one step sets the previous version", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 2, false, nil)
+
+		gomock.InOrder(
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "ALTER TABLE t DROP COLUMN c;").Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, queryClearMigrationTable).Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, querySetMigrationVersion, int64(1)).Return(nil, nil),
+			mockTx.EXPECT().Commit().Return(nil),
+		)
+
+		reverted, err := migrator.Down(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(2), reverted[0].Version)
+	})
+
+	t.Run("positive - first migration clears the version", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 1, false, nil)
+
+		gomock.InOrder(
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "DROP TABLE t;").Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, queryClearMigrationTable).Return(nil, nil),
+			mockTx.EXPECT().Commit().Return(nil),
+		)
+
+		reverted, err := migrator.Down(ctx, 0)
+		assert.Nil(t, err)
+		assert.Len(t, reverted, 1)
+	})
+
+	t.Run("positive - nothing applied", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 0, false, sql.ErrNoRows)
+
+		reverted, err := migrator.Down(ctx, 1)
+		assert.Nil(t, err)
+		assert.Empty(t, reverted)
+	})
+
+	t.Run("negative - no down file", func(t *testing.T) {
+		upOnly := Migrator{DB: mockDB, Migrations: fstest.MapFS{"000001_create_table.up.sql": {}}}
+		expectMigrationVersion(mockDB, mockRow, 1, false, nil)
+
+		_, err := upOnly.Down(ctx, 1)
+		assert.EqualError(t, err, "migration 1 down: no down file")
+	})
+
+	t.Run("negative - begin error", func(t *testing.T) {
+		expectMigrationVersion(mockDB, mockRow, 2, false, nil)
+		mockDB.EXPECT().Begin().Return(nil, errors.New("error"))
+
+		_, err := migrator.Down(ctx, 1)
+		assert.EqualError(t, err, "migration 2 down: error")
+	})
+}
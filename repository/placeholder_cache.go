@@ -5,9 +5,15 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
+
+	goRedis "github.com/go-redis/redis"
+
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/tracing"
+	"github.com/dityuiri/go-baseline/config"
 	"github.com/dityuiri/go-baseline/model"
 
-	"github.com/dityuiri/go-adapter/logger"
 	"github.com/dityuiri/go-adapter/redis"
 )
 
@@ -15,11 +21,15 @@ type (
 	IPlaceholderCache interface {
 		SetPlaceholderInfo(ctx context.Context, placeholderDTO model.PlaceholderDTO) error
 		GetPlaceholderInfo(ctx context.Context, placeholderID string) (*model.PlaceholderDTO, error)
+		DeletePlaceholderInfo(ctx context.Context, placeholderID string) error
 	}
 
 	PlaceholderCache struct {
-		Redis  redis.IRedis
-		Logger logger.ILogger
+		Redis   redis.IRedis
+		Metrics *metrics.Metrics
+
+		// Config gives the PLACEHOLDER_CACHE_TTL of the entries, they expire after REDIS_EXPIRATION without it
+		Config *config.Live
 	}
 )
 
@@ -28,18 +38,82 @@ const (
 )
 
 func (pc *PlaceholderCache) SetPlaceholderInfo(ctx context.Context, placeholderDTO model.PlaceholderDTO) error {
+	ctx, span := tracing.Start(ctx, "PlaceholderCache.SetPlaceholderInfo")
+	defer span.End()
+
 	var key = fmt.Sprintf(keyPlaceholder, placeholderDTO.ID.String())
 
-	err := pc.Redis.SetAsBytes(key, placeholderDTO)
-	return err
+	// Redis adapter is not context aware, don't bother calling it once the request is done
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var err error
+	if ttl := pc.ttl(); ttl > 0 {
+		err = pc.Redis.SetExAsBytes(key, placeholderDTO, ttl)
+	} else {
+		err = pc.Redis.SetAsBytes(key, placeholderDTO)
+	}
+
+	return wrapCacheError(key, err)
 }
 
 func (pc *PlaceholderCache) GetPlaceholderInfo(ctx context.Context, placeholderID string) (*model.PlaceholderDTO, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderCache.GetPlaceholderInfo", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+
 	var (
 		key    = fmt.Sprintf(keyPlaceholder, placeholderID)
 		result = &model.PlaceholderDTO{}
 	)
 
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
 	err := pc.Redis.GetAndParseBytes(key, result)
+	pc.Metrics.ObserveCacheLookup(metricsPlaceholder, err)
+
+	// A missing key is an expected outcome of the lookup, not a failure
+	if err != goRedis.Nil {
+		tracing.RecordError(span, err)
+		err = wrapCacheError(key, err)
+	}
+
 	return result, err
 }
+
+func (pc *PlaceholderCache) DeletePlaceholderInfo(ctx context.Context, placeholderID string) error {
+	ctx, span := tracing.Start(ctx, "PlaceholderCache.DeletePlaceholderInfo", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+
+	var key = fmt.Sprintf(keyPlaceholder, placeholderID)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return wrapCacheError(key, pc.Redis.Del(key))
+}
+
+// wrapCacheError adds the key to the error for the caller to log
+func wrapCacheError(key string, err error) error {
+	if err == nil {
+		return err
+	}
+
+	return fmt.Errorf("key %s: %w", key, err)
+}
+
+// ttl is the running PLACEHOLDER_CACHE_TTL, it is looked up on every write so a reloaded TTL applies right away
+func (pc *PlaceholderCache) ttl() time.Duration {
+	if pc.Config == nil {
+		return 0
+	}
+
+	if cache := pc.Config.Load().Cache; cache != nil {
+		return cache.PlaceholderTTL
+	}
+
+	return 0
+}
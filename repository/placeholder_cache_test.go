@@ -5,25 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
-	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
 	redisMock "github.com/dityuiri/go-adapter/redis/mock"
+	"github.com/dityuiri/go-baseline/config"
 	"github.com/dityuiri/go-baseline/model"
 )
 
 func TestPlaceholderCache_SetPlaceholderInfo(t *testing.T) {
 	var (
-		mockCtrl   = gomock.NewController(t)
-		mockRedis  = redisMock.NewMockIRedis(mockCtrl)
-		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+		mockCtrl  = gomock.NewController(t)
+		mockRedis = redisMock.NewMockIRedis(mockCtrl)
 
 		placeholderCache = PlaceholderCache{
-			Redis:  mockRedis,
-			Logger: mockLogger,
+			Redis: mockRedis,
 		}
 
 		placeholderDTO = model.PlaceholderDTO{
@@ -42,22 +41,41 @@ func TestPlaceholderCache_SetPlaceholderInfo(t *testing.T) {
 	})
 
 	t.Run("return error", func(t *testing.T) {
-		mockRedis.EXPECT().SetAsBytes(key, placeholderDTO).Return(errors.New("error")).Times(1)
+		cacheErr := errors.New("error")
+
+		mockRedis.EXPECT().SetAsBytes(key, placeholderDTO).Return(cacheErr).Times(1)
+
+		err := placeholderCache.SetPlaceholderInfo(ctx, placeholderDTO)
+		assert.EqualError(t, err, "key "+key+": error")
+		assert.ErrorIs(t, err, cacheErr)
+	})
+
+	t.Run("return ok - with ttl", func(t *testing.T) {
+		placeholderCache := placeholderCache
+		placeholderCache.Config = config.NewLive(&config.Configuration{Cache: &config.Cache{PlaceholderTTL: time.Minute}})
+
+		mockRedis.EXPECT().SetExAsBytes(key, placeholderDTO, time.Minute).Return(nil).Times(1)
 
 		err := placeholderCache.SetPlaceholderInfo(ctx, placeholderDTO)
-		assert.EqualError(t, err, "error")
+		assert.Nil(t, err)
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := placeholderCache.SetPlaceholderInfo(canceledCtx, placeholderDTO)
+		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
 
 func TestPlaceholderCache_GetPlaceholderInfo(t *testing.T) {
 	var (
-		mockCtrl   = gomock.NewController(t)
-		mockRedis  = redisMock.NewMockIRedis(mockCtrl)
-		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+		mockCtrl  = gomock.NewController(t)
+		mockRedis = redisMock.NewMockIRedis(mockCtrl)
 
 		placeholderCache = PlaceholderCache{
-			Redis:  mockRedis,
-			Logger: mockLogger,
+			Redis: mockRedis,
 		}
 
 		placeholderDTO = model.PlaceholderDTO{
@@ -77,10 +95,63 @@ func TestPlaceholderCache_GetPlaceholderInfo(t *testing.T) {
 	})
 
 	t.Run("return error", func(t *testing.T) {
-		mockRedis.EXPECT().GetAndParseBytes(key, gomock.Any()).Return(errors.New("error")).Times(1)
+		cacheErr := errors.New("error")
+
+		mockRedis.EXPECT().GetAndParseBytes(key, gomock.Any()).Return(cacheErr).Times(1)
 
 		res, err := placeholderCache.GetPlaceholderInfo(ctx, placeholderDTO.ID.String())
 		assert.Empty(t, res)
-		assert.EqualError(t, err, "error")
+		assert.EqualError(t, err, "key "+key+": error")
+		assert.ErrorIs(t, err, cacheErr)
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		res, err := placeholderCache.GetPlaceholderInfo(canceledCtx, placeholderDTO.ID.String())
+		assert.Empty(t, res)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestPlaceholderCache_DeletePlaceholderInfo(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockRedis = redisMock.NewMockIRedis(mockCtrl)
+
+		placeholderCache = PlaceholderCache{
+			Redis: mockRedis,
+		}
+
+		placeholderID = uuid.New().String()
+
+		ctx = context.Background()
+		key = fmt.Sprintf(keyPlaceholder, placeholderID)
+	)
+
+	t.Run("return ok", func(t *testing.T) {
+		mockRedis.EXPECT().Del(key).Return(nil).Times(1)
+
+		err := placeholderCache.DeletePlaceholderInfo(ctx, placeholderID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("return error", func(t *testing.T) {
+		cacheErr := errors.New("error")
+
+		mockRedis.EXPECT().Del(key).Return(cacheErr).Times(1)
+
+		err := placeholderCache.DeletePlaceholderInfo(ctx, placeholderID)
+		assert.EqualError(t, err, "key "+key+": error")
+		assert.ErrorIs(t, err, cacheErr)
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := placeholderCache.DeletePlaceholderInfo(canceledCtx, placeholderID)
+		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
@@ -2,10 +2,16 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/model"
 
 	"github.com/dityuiri/go-adapter/db"
-	"github.com/dityuiri/go-adapter/logger"
 )
 
 //go:generate mockgen -package=repository_mock -destination=../mock/repository/placeholder_db.go . IPlaceholderRepository
@@ -13,27 +19,299 @@ import (
 type (
 	IPlaceholderRepository interface {
 		GetSinglePlaceholder(ctx context.Context, placeholderID string) (model.PlaceholderDAO, error)
+		GetPlaceholders(ctx context.Context, filter model.PlaceholderFilter) ([]model.PlaceholderDAO, error)
+		CountPlaceholders(ctx context.Context, filter model.PlaceholderFilter) (int, error)
 		InsertPlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) error
-		UpdatePlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) error
+		UpdatePlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) (int64, error)
+		DeletePlaceholder(ctx context.Context, tx db.ITransaction, placeholderID string) error
 	}
 
 	PlaceholderRepository struct {
-		Logger logger.ILogger
-		DB     db.IDatabase
+		DB      db.IDatabase
+		Metrics *metrics.Metrics
 	}
 )
 
+const (
+	placeholderColumns = "id, name, amount, created_at, created_by, updated_at, updated_by, version"
+
+	queryGetSinglePlaceholder = "SELECT " + placeholderColumns + " FROM placeholder WHERE id = $1"
+	queryGetPlaceholders      = "SELECT " + placeholderColumns + " FROM placeholder%s ORDER BY %s %s LIMIT %d OFFSET %d"
+	queryCountPlaceholders    = "SELECT COUNT(1) FROM placeholder%s"
+	queryInsertPlaceholder    = "INSERT INTO placeholder (id, name, amount, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, NOW(), $4, NOW(), $5)"
+	queryUpdatePlaceholder    = "WITH updated AS (UPDATE placeholder SET name = $2, amount = $3, updated_at = NOW(), updated_by = $4, version = version + 1 WHERE id = $1 AND version = $5 RETURNING version) SELECT (SELECT version FROM updated), EXISTS (SELECT 1 FROM placeholder WHERE id = $1)"
+	queryUpdateAnyPlaceholder = "UPDATE placeholder SET name = $2, amount = $3, updated_at = NOW(), updated_by = $4, version = version + 1 WHERE id = $1 RETURNING version"
+	queryDeletePlaceholder    = "DELETE FROM placeholder WHERE id = $1"
+
+	// AnyVersion as the version of an update updates the placeholder whatever its stored version
+	AnyVersion int64 = 0
+
+	// metricsPlaceholder labels the metrics of the placeholder repository, cache and producer
+	metricsPlaceholder = "placeholder"
+)
+
+// placeholderSortColumns whitelists the columns a placeholder list can be sorted by
+var placeholderSortColumns = map[string]string{
+	"":           "created_at",
+	"name":       "name",
+	"amount":     "amount",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
 func (pr *PlaceholderRepository) GetSinglePlaceholder(ctx context.Context, placeholderID string) (model.PlaceholderDAO, error) {
-	// Your implementation goes here
-	return model.PlaceholderDAO{}, nil
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.GetSinglePlaceholder", tracing.DBStatement(queryGetSinglePlaceholder))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "get_single_placeholder", common.TimeNow())
+
+	var placeholder model.PlaceholderDAO
+
+	row := pr.DB.QueryRowContext(ctx, queryGetSinglePlaceholder, placeholderID)
+	err := row.Scan(
+		&placeholder.ID,
+		&placeholder.Name,
+		&placeholder.Amount,
+		&placeholder.CreatedAt,
+		&placeholder.CreatedBy,
+		&placeholder.UpdatedAt,
+		&placeholder.UpdatedBy,
+		&placeholder.Version,
+	)
+
+	return placeholder, wrapQueryError("get_single_placeholder", err)
+}
+
+func (pr *PlaceholderRepository) GetPlaceholders(ctx context.Context, filter model.PlaceholderFilter) ([]model.PlaceholderDAO, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.GetPlaceholders", tracing.DBStatement(queryGetPlaceholders))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "get_placeholders", common.TimeNow())
+
+	var placeholders = make([]model.PlaceholderDAO, 0)
+
+	sortColumn, ok := placeholderSortColumns[filter.SortBy]
+	if !ok {
+		return placeholders, common.ErrInvalidSortParameter
+	}
+
+	sortOrder := strings.ToUpper(filter.SortOrder)
+	switch sortOrder {
+	case "":
+		sortOrder = "DESC"
+	case "ASC", "DESC":
+	default:
+		return placeholders, common.ErrInvalidSortParameter
+	}
+
+	where, args := pr.buildFilterClause(filter)
+	query := fmt.Sprintf(queryGetPlaceholders, where, sortColumn, sortOrder, filter.Limit, filter.Offset)
+
+	rows, err := pr.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return placeholders, wrapQueryError("get_placeholders", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var placeholder model.PlaceholderDAO
+
+		err = rows.Scan(
+			&placeholder.ID,
+			&placeholder.Name,
+			&placeholder.Amount,
+			&placeholder.CreatedAt,
+			&placeholder.CreatedBy,
+			&placeholder.UpdatedAt,
+			&placeholder.UpdatedBy,
+			&placeholder.Version,
+		)
+		if err != nil {
+			return placeholders, wrapQueryError("get_placeholders", err)
+		}
+
+		placeholders = append(placeholders, placeholder)
+	}
+
+	return placeholders, wrapQueryError("get_placeholders", rows.Err())
+}
+
+func (pr *PlaceholderRepository) CountPlaceholders(ctx context.Context, filter model.PlaceholderFilter) (int, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.CountPlaceholders", tracing.DBStatement(queryCountPlaceholders))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "count_placeholders", common.TimeNow())
+
+	var (
+		count       int
+		where, args = pr.buildFilterClause(filter)
+	)
+
+	row := pr.DB.QueryRowContext(ctx, fmt.Sprintf(queryCountPlaceholders, where), args...)
+	err := row.Scan(&count)
+
+	return count, wrapQueryError("count_placeholders", err)
 }
 
 func (pr *PlaceholderRepository) InsertPlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) error {
-	// Your implementation goes here
-	return nil
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.InsertPlaceholder", tracing.DBStatement(queryInsertPlaceholder))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "insert_placeholder", common.TimeNow())
+
+	_, err := pr.executor(tx).ExecuteContext(ctx, queryInsertPlaceholder,
+		placeholder.ID,
+		placeholder.Name,
+		placeholder.Amount,
+		placeholder.CreatedBy,
+		placeholder.UpdatedBy,
+	)
+
+	return wrapQueryError("insert_placeholder", err)
+}
+
+// UpdatePlaceholder updates the placeholder as long as its stored version is still placeholder.Version, or whatever
+// its stored version with AnyVersion, and returns the incremented version. It returns sql.ErrNoRows when there is
+// no placeholder to be updated, and common.ErrVersionMismatch when the placeholder has been modified since that version.
+func (pr *PlaceholderRepository) UpdatePlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) (int64, error) {
+	if placeholder.Version == AnyVersion {
+		return pr.updateAnyPlaceholder(ctx, tx, placeholder)
+	}
+
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.UpdatePlaceholder", tracing.DBStatement(queryUpdatePlaceholder))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "update_placeholder", common.TimeNow())
+
+	var (
+		updated sql.NullInt64
+		found   bool
+	)
+
+	// The version is compared by the update itself, so concurrent updates of the same version can't both succeed
+	row := pr.executor(tx).QueryRowContext(ctx, queryUpdatePlaceholder,
+		placeholder.ID,
+		placeholder.Name,
+		placeholder.Amount,
+		placeholder.UpdatedBy,
+		placeholder.Version,
+	)
+	if err := row.Scan(&updated, &found); err != nil {
+		return 0, wrapQueryError("update_placeholder", err)
+	}
+
+	switch {
+	case !found:
+		return 0, sql.ErrNoRows
+	case !updated.Valid:
+		return 0, common.ErrVersionMismatch
+	}
+
+	return updated.Int64, nil
+}
+
+// updateAnyPlaceholder updates the placeholder without comparing its version, so an update landing meanwhile
+// doesn't fail it
+func (pr *PlaceholderRepository) updateAnyPlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) (int64, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.UpdatePlaceholder", tracing.DBStatement(queryUpdateAnyPlaceholder))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "update_any_placeholder", common.TimeNow())
+
+	var version int64
+
+	row := pr.executor(tx).QueryRowContext(ctx, queryUpdateAnyPlaceholder,
+		placeholder.ID,
+		placeholder.Name,
+		placeholder.Amount,
+		placeholder.UpdatedBy,
+	)
+	if err := row.Scan(&version); err != nil {
+		return 0, wrapQueryError("update_any_placeholder", err)
+	}
+
+	return version, nil
+}
+
+// DeletePlaceholder returns sql.ErrNoRows when there is no placeholder to be deleted
+func (pr *PlaceholderRepository) DeletePlaceholder(ctx context.Context, tx db.ITransaction, placeholderID string) error {
+	ctx, span := tracing.Start(ctx, "PlaceholderRepository.DeletePlaceholder", tracing.DBStatement(queryDeletePlaceholder))
+	defer span.End()
+	defer pr.Metrics.ObserveQuery(metricsPlaceholder, "delete_placeholder", common.TimeNow())
+
+	result, err := pr.executor(tx).ExecuteContext(ctx, queryDeletePlaceholder, placeholderID)
+	if err == nil {
+		err = pr.ensureAffected(result)
+	}
+	return wrapQueryError("delete_placeholder", err)
+}
+
+// wrapQueryError adds the query to the error for the caller to log. A missing row is an expected outcome,
+// returned as is for the caller to decide about it.
+func wrapQueryError(query string, err error) error {
+	if err == nil || err == sql.ErrNoRows {
+		return err
+	}
+
+	return fmt.Errorf("query %s: %w", query, err)
 }
 
-func (pr *PlaceholderRepository) UpdatePlaceholder(ctx context.Context, tx db.ITransaction, placeholder model.PlaceholderDAO) error {
-	// Your implementation goes here
+// executor runs the query inside the given transaction, or directly on the database when there is none
+func (pr *PlaceholderRepository) executor(tx db.ITransaction) db.IQuery {
+	if tx != nil {
+		return tx
+	}
+
+	return pr.DB
+}
+
+func (*PlaceholderRepository) ensureAffected(result db.IResult) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
+
+func (*PlaceholderRepository) buildFilterClause(filter model.PlaceholderFilter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.NamePrefix != "" {
+		addCondition("name LIKE $%d", escapeLike(filter.NamePrefix)+"%")
+	}
+
+	if filter.MinAmount != nil {
+		addCondition("amount >= $%d", *filter.MinAmount)
+	}
+
+	if filter.MaxAmount != nil {
+		addCondition("amount <= $%d", *filter.MaxAmount)
+	}
+
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= $%d", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		addCondition("created_at < $%d", *filter.CreatedTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes the LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
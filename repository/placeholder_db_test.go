@@ -2,26 +2,35 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	databaseMock "github.com/dityuiri/go-adapter/db/mock"
-	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/mock"
 	"github.com/dityuiri/go-baseline/model"
 )
 
+func TestMain(m *testing.M) {
+	mock.NewSpanRecorder()
+	os.Exit(m.Run())
+}
+
 func TestPlaceholderRepository_GetSinglePlaceholder(t *testing.T) {
 	var (
-		mockCtrl   = gomock.NewController(t)
-		mockLogger = loggerMock.NewMockILogger(mockCtrl)
-		mockDB     = databaseMock.NewMockIDatabase(mockCtrl)
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
 
 		repo = PlaceholderRepository{
-			Logger: mockLogger,
-			DB:     mockDB,
+			DB: mockDB,
 		}
 
 		ctx           = context.Background()
@@ -31,22 +40,154 @@ func TestPlaceholderRepository_GetSinglePlaceholder(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	t.Run("positive", func(t *testing.T) {
+		mockDB.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.GetSinglePlaceholder"), queryGetSinglePlaceholder, placeholderID).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
 		res, err := repo.GetSinglePlaceholder(ctx, placeholderID)
 		assert.Nil(t, err)
 		assert.Empty(t, res)
 	})
+
+	t.Run("no rows", func(t *testing.T) {
+		mockDB.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.GetSinglePlaceholder"), queryGetSinglePlaceholder, placeholderID).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.GetSinglePlaceholder(ctx, placeholderID)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestPlaceholderRepository_GetPlaceholders(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRows = databaseMock.NewMockIRows(mockCtrl)
+
+		repo = PlaceholderRepository{
+			DB: mockDB,
+		}
+
+		ctx         = context.Background()
+		minAmount   = 100
+		createdFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter      = model.PlaceholderFilter{
+			NamePrefix:  "Ao_",
+			MinAmount:   &minAmount,
+			CreatedFrom: &createdFrom,
+			SortBy:      "amount",
+			SortOrder:   "asc",
+			Limit:       20,
+			Offset:      40,
+		}
+
+		expectedQuery = "SELECT " + placeholderColumns + " FROM placeholder WHERE name LIKE $1 AND amount >= $2 AND created_at >= $3 ORDER BY amount ASC LIMIT 20 OFFSET 40"
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive", func(t *testing.T) {
+		gomock.InOrder(
+			mockDB.EXPECT().QueryContext(mock.InSpan(ctx, "PlaceholderRepository.GetPlaceholders"), expectedQuery, `Ao\_%`, minAmount, createdFrom).Return(mockRows, nil),
+			mockRows.EXPECT().Next().Return(true),
+			mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+			mockRows.EXPECT().Next().Return(false),
+			mockRows.EXPECT().Err().Return(nil),
+			mockRows.EXPECT().Close().Return(nil),
+		)
+
+		res, err := repo.GetPlaceholders(ctx, filter)
+		assert.Nil(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("positive - default sorting without filter", func(t *testing.T) {
+		query := "SELECT " + placeholderColumns + " FROM placeholder ORDER BY created_at DESC LIMIT 20 OFFSET 0"
+
+		mockDB.EXPECT().QueryContext(mock.InSpan(ctx, "PlaceholderRepository.GetPlaceholders"), query).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Err().Return(nil)
+		mockRows.EXPECT().Close().Return(nil)
+
+		res, err := repo.GetPlaceholders(ctx, model.PlaceholderFilter{Limit: 20})
+		assert.Nil(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("invalid sort column", func(t *testing.T) {
+		_, err := repo.GetPlaceholders(ctx, model.PlaceholderFilter{SortBy: "id; DROP TABLE placeholder"})
+		assert.Equal(t, common.ErrInvalidSortParameter, err)
+	})
+
+	t.Run("invalid sort order", func(t *testing.T) {
+		_, err := repo.GetPlaceholders(ctx, model.PlaceholderFilter{SortOrder: "sideways"})
+		assert.Equal(t, common.ErrInvalidSortParameter, err)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		queryErr := errors.New("error")
+
+		mockDB.EXPECT().QueryContext(mock.InSpan(ctx, "PlaceholderRepository.GetPlaceholders"), expectedQuery, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows, queryErr)
+
+		_, err := repo.GetPlaceholders(ctx, filter)
+		assert.EqualError(t, err, "query get_placeholders: error")
+		assert.ErrorIs(t, err, queryErr)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		queryErr := errors.New("error")
+
+		mockDB.EXPECT().QueryContext(mock.InSpan(ctx, "PlaceholderRepository.GetPlaceholders"), expectedQuery, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(queryErr)
+		mockRows.EXPECT().Close().Return(nil)
+
+		_, err := repo.GetPlaceholders(ctx, filter)
+		assert.EqualError(t, err, "query get_placeholders: error")
+		assert.ErrorIs(t, err, queryErr)
+	})
+}
+
+func TestPlaceholderRepository_CountPlaceholders(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
+
+		repo = PlaceholderRepository{
+			DB: mockDB,
+		}
+
+		ctx       = context.Background()
+		maxAmount = 500
+		filter    = model.PlaceholderFilter{
+			MaxAmount: &maxAmount,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive", func(t *testing.T) {
+		mockDB.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.CountPlaceholders"), "SELECT COUNT(1) FROM placeholder WHERE amount <= $1", maxAmount).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*int)) = 3
+			return nil
+		})
+
+		res, err := repo.CountPlaceholders(ctx, filter)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res)
+	})
 }
 
 func TestPlaceholderRepository_InsertPlaceholder(t *testing.T) {
 	var (
 		mockCtrl   = gomock.NewController(t)
-		mockLogger = loggerMock.NewMockILogger(mockCtrl)
 		mockDB     = databaseMock.NewMockIDatabase(mockCtrl)
 		mockTx     = databaseMock.NewMockITransaction(mockCtrl)
+		mockResult = databaseMock.NewMockIResult(mockCtrl)
 
 		repo = PlaceholderRepository{
-			Logger: mockLogger,
-			DB:     mockDB,
+			DB: mockDB,
 		}
 
 		ctx         = context.Background()
@@ -59,34 +200,164 @@ func TestPlaceholderRepository_InsertPlaceholder(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	t.Run("positive", func(t *testing.T) {
+		mockTx.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.InsertPlaceholder"), queryInsertPlaceholder, placeholder.ID, placeholder.Name, placeholder.Amount, placeholder.CreatedBy, placeholder.UpdatedBy).Return(mockResult, nil)
+
 		err := repo.InsertPlaceholder(ctx, mockTx, placeholder)
 		assert.Nil(t, err)
 	})
+
+	t.Run("positive - without transaction", func(t *testing.T) {
+		mockDB.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.InsertPlaceholder"), queryInsertPlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResult, nil)
+
+		err := repo.InsertPlaceholder(ctx, nil, placeholder)
+		assert.Nil(t, err)
+	})
+
+	t.Run("execute error", func(t *testing.T) {
+		queryErr := errors.New("error")
+
+		mockTx.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.InsertPlaceholder"), queryInsertPlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, queryErr)
+
+		err := repo.InsertPlaceholder(ctx, mockTx, placeholder)
+		assert.EqualError(t, err, "query insert_placeholder: error")
+		assert.ErrorIs(t, err, queryErr)
+	})
 }
 
 func TestPlaceholderRepository_UpdatePlaceholder(t *testing.T) {
 	var (
-		mockCtrl   = gomock.NewController(t)
-		mockLogger = loggerMock.NewMockILogger(mockCtrl)
-		mockDB     = databaseMock.NewMockIDatabase(mockCtrl)
-		mockTx     = databaseMock.NewMockITransaction(mockCtrl)
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockTx   = databaseMock.NewMockITransaction(mockCtrl)
+		mockRow  = databaseMock.NewMockIRow(mockCtrl)
 
 		repo = PlaceholderRepository{
-			Logger: mockLogger,
-			DB:     mockDB,
+			DB: mockDB,
 		}
 
 		ctx         = context.Background()
 		placeholder = model.PlaceholderDAO{
-			ID:   uuid.New(),
-			Name: "you know, a placeholder",
+			ID:      uuid.New(),
+			Name:    "you know, a placeholder",
+			Version: 3,
+		}
+
+		// scanResult fakes the row holding the updated version, when the placeholder was updated, and whether it was found
+		scanResult = func(updated sql.NullInt64, found bool) func(dest ...interface{}) error {
+			return func(dest ...interface{}) error {
+				*dest[0].(*sql.NullInt64) = updated
+				*dest[1].(*bool) = found
+				return nil
+			}
 		}
 	)
 
 	defer mockCtrl.Finish()
 
 	t.Run("positive", func(t *testing.T) {
-		err := repo.UpdatePlaceholder(ctx, mockTx, placeholder)
+		mockTx.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdatePlaceholder, placeholder.ID, placeholder.Name, placeholder.Amount, placeholder.UpdatedBy, placeholder.Version).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(scanResult(sql.NullInt64{Int64: 4, Valid: true}, true))
+
+		version, err := repo.UpdatePlaceholder(ctx, mockTx, placeholder)
 		assert.Nil(t, err)
+		assert.Equal(t, int64(4), version)
+	})
+
+	t.Run("positive - any version is updated without comparing it", func(t *testing.T) {
+		anyVersion := placeholder
+		anyVersion.Version = AnyVersion
+
+		// The version was bumped to 7 since the placeholder was read, the update doesn't fail on it
+		mockTx.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdateAnyPlaceholder, placeholder.ID, placeholder.Name, placeholder.Amount, placeholder.UpdatedBy).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*dest[0].(*int64) = 8
+			return nil
+		})
+
+		version, err := repo.UpdatePlaceholder(ctx, mockTx, anyVersion)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(8), version)
+	})
+
+	t.Run("any version placeholder not found", func(t *testing.T) {
+		anyVersion := placeholder
+		anyVersion.Version = AnyVersion
+
+		mockTx.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdateAnyPlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.UpdatePlaceholder(ctx, mockTx, anyVersion)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+
+	t.Run("placeholder not found", func(t *testing.T) {
+		mockTx.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdatePlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(scanResult(sql.NullInt64{}, false))
+
+		_, err := repo.UpdatePlaceholder(ctx, mockTx, placeholder)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		mockTx.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdatePlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(scanResult(sql.NullInt64{}, true))
+
+		_, err := repo.UpdatePlaceholder(ctx, mockTx, placeholder)
+		assert.Equal(t, common.ErrVersionMismatch, err)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		queryErr := errors.New("error")
+
+		mockDB.EXPECT().QueryRowContext(mock.InSpan(ctx, "PlaceholderRepository.UpdatePlaceholder"), queryUpdatePlaceholder, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(queryErr)
+
+		_, err := repo.UpdatePlaceholder(ctx, nil, placeholder)
+		assert.EqualError(t, err, "query update_placeholder: error")
+		assert.ErrorIs(t, err, queryErr)
+	})
+}
+
+func TestPlaceholderRepository_DeletePlaceholder(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = databaseMock.NewMockIDatabase(mockCtrl)
+		mockResult = databaseMock.NewMockIResult(mockCtrl)
+
+		repo = PlaceholderRepository{
+			DB: mockDB,
+		}
+
+		ctx           = context.Background()
+		placeholderID = uuid.New().String()
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive", func(t *testing.T) {
+		mockDB.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.DeletePlaceholder"), queryDeletePlaceholder, placeholderID).Return(mockResult, nil)
+		mockResult.EXPECT().RowsAffected().Return(int64(1), nil)
+
+		err := repo.DeletePlaceholder(ctx, nil, placeholderID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("no rows affected", func(t *testing.T) {
+		mockDB.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.DeletePlaceholder"), queryDeletePlaceholder, placeholderID).Return(mockResult, nil)
+		mockResult.EXPECT().RowsAffected().Return(int64(0), nil)
+
+		err := repo.DeletePlaceholder(ctx, nil, placeholderID)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+
+	t.Run("rows affected error", func(t *testing.T) {
+		queryErr := errors.New("error")
+
+		mockDB.EXPECT().ExecuteContext(mock.InSpan(ctx, "PlaceholderRepository.DeletePlaceholder"), queryDeletePlaceholder, placeholderID).Return(mockResult, nil)
+		mockResult.EXPECT().RowsAffected().Return(int64(0), queryErr)
+
+		err := repo.DeletePlaceholder(ctx, nil, placeholderID)
+		assert.EqualError(t, err, "query delete_placeholder: error")
+		assert.ErrorIs(t, err, queryErr)
 	})
 }
@@ -7,6 +7,11 @@ import (
 
 	"github.com/dityuiri/go-adapter/kafka"
 	"github.com/dityuiri/go-adapter/kafka/producer"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/metrics"
+	"github.com/dityuiri/go-baseline/common/requestid"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/config"
 	"github.com/dityuiri/go-baseline/model"
 )
@@ -21,15 +26,26 @@ type (
 	PlaceholderProducer struct {
 		Producer    producer.IProducer
 		KafkaConfig *config.Kafka
+		Metrics     *metrics.Metrics
 	}
 )
 
 func (p *PlaceholderProducer) ProducePlaceholderRecord(ctx context.Context, placeholderMsg model.PlaceholderMessage) error {
-	msg := p.constructMessage(placeholderMsg)
-	return p.Producer.Produce(ctx, p.KafkaConfig.ProducerTopics["placeholder"], msg)
+	topic := p.KafkaConfig.ProducerTopics[common.TopicPlaceholder]
+
+	ctx, span := tracing.StartProducer(ctx, "PlaceholderProducer.ProducePlaceholderRecord", tracing.MessagingDestination(topic))
+	defer span.End()
+
+	msg := p.constructMessage(ctx, placeholderMsg)
+
+	err := p.Producer.Produce(ctx, topic, msg)
+	p.Metrics.ObserveProduce(topic, 1, err)
+	tracing.RecordError(span, err)
+
+	return err
 }
 
-func (*PlaceholderProducer) constructMessage(placeholderMsg model.PlaceholderMessage) *kafka.Message {
+func (*PlaceholderProducer) constructMessage(ctx context.Context, placeholderMsg model.PlaceholderMessage) *kafka.Message {
 	var message *kafka.Message
 	data, _ := json.Marshal(placeholderMsg)
 	message = &kafka.Message{
@@ -39,5 +55,13 @@ func (*PlaceholderProducer) constructMessage(placeholderMsg model.PlaceholderMes
 		},
 	}
 
+	// Consumers restore the request ID so their logs can be connected to the originating request
+	if requestID := requestid.FromContext(ctx); requestID != "" {
+		message.Headers[requestid.MessageHeader] = []byte(requestID)
+	}
+
+	// Consumers continue the trace of the producer span
+	tracing.InjectMessage(ctx, message)
+
 	return message
 }
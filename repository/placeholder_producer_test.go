@@ -7,9 +7,14 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/dityuiri/go-adapter/kafka"
 	producerMock "github.com/dityuiri/go-adapter/kafka/producer/mock"
+	"github.com/dityuiri/go-baseline/common/requestid"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/config"
+	"github.com/dityuiri/go-baseline/mock"
 	"github.com/dityuiri/go-baseline/model"
 )
 
@@ -35,10 +40,42 @@ func TestPlaceholderProducer_ProducePlaceholderRecord(t *testing.T) {
 	)
 
 	t.Run("positive", func(t *testing.T) {
-		mockProducer.EXPECT().Produce(ctx, "placeholder", gomock.Any())
+		mockProducer.EXPECT().Produce(mock.InSpan(ctx, "PlaceholderProducer.ProducePlaceholderRecord"), "placeholder", gomock.Any())
 
 		err := producer.ProducePlaceholderRecord(ctx, placeholderMessage)
 		assert.Nil(t, err)
 	})
 
+	t.Run("positive - request id header", func(t *testing.T) {
+		requestCtx := requestid.NewContext(ctx, "request-1")
+
+		mockProducer.EXPECT().Produce(mock.InSpan(requestCtx, "PlaceholderProducer.ProducePlaceholderRecord"), "placeholder", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Len(t, messages, 1)
+				assert.Equal(t, []byte("request-1"), messages[0].Headers[requestid.MessageHeader])
+				assert.Equal(t, []byte(placeholderMessage.ID), messages[0].Headers["message_id"])
+				return nil
+			})
+
+		err := producer.ProducePlaceholderRecord(requestCtx, placeholderMessage)
+		assert.Nil(t, err)
+	})
+
+	t.Run("positive - trace context header", func(t *testing.T) {
+		spanCtx, span := tracing.Start(ctx, "test")
+		defer span.End()
+
+		mockProducer.EXPECT().Produce(mock.InSpan(spanCtx, "PlaceholderProducer.ProducePlaceholderRecord"), "placeholder", gomock.Any()).DoAndReturn(
+			func(produceCtx context.Context, _ string, messages ...*kafka.Message) error {
+				assert.Len(t, messages, 1)
+				assert.Contains(t, messages[0].Headers, "traceparent")
+
+				consumerCtx := tracing.ExtractMessage(context.Background(), *messages[0])
+				assert.Equal(t, trace.SpanContextFromContext(produceCtx).WithRemote(true), trace.SpanContextFromContext(consumerCtx))
+				return nil
+			})
+
+		err := producer.ProducePlaceholderRecord(spanCtx, placeholderMessage)
+		assert.Nil(t, err)
+	})
 }
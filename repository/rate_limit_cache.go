@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+
+	"github.com/dityuiri/go-adapter/logger"
+
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/ratelimit"
+	"github.com/dityuiri/go-baseline/config"
+)
+
+type (
+	// RateLimitCache is a limiter shared by every replica. It counts the requests of the current and
+	// the previous fixed window, and weighs the previous one by its overlap with the sliding window.
+	// The counters are read, checked and incremented by one script, which redis runs atomically.
+	RateLimitCache struct {
+		Redis  IRedisClient
+		Logger logger.ILogger
+	}
+)
+
+const (
+	keyRateLimit = "ratelimit:%s:%d"
+)
+
+// rateLimitScript increments the current window counter, KEYS[1], when the estimated count of the sliding
+// window stays within the limit, ARGV[1]. The previous window counter, KEYS[2], weighs ARGV[2]. The counter
+// expires ARGV[3] milliseconds after its first request, it is read as the previous window during the next one.
+// It returns whether the request is allowed, the current count, including the request when it is allowed,
+// and the previous count.
+var rateLimitScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+
+if previous * tonumber(ARGV[2]) + current + 1 > tonumber(ARGV[1]) then
+	return {0, current, previous}
+end
+
+current = redis.call("INCR", KEYS[1])
+if current == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+
+return {1, current, previous}
+`)
+
+func (rc *RateLimitCache) Allow(ctx context.Context, key string, rule config.RateLimitRule) (ratelimit.Result, error) {
+	var (
+		result = ratelimit.Result{Limit: rule.Requests}
+
+		now     = common.TimeNow().UnixNano()
+		window  = int64(rule.Window)
+		index   = now / window
+		elapsed = time.Duration(now % window)
+		left    = rule.Window - elapsed
+		weight  = float64(left) / float64(rule.Window)
+
+		currentKey  = fmt.Sprintf(keyRateLimit, key, index)
+		previousKey = fmt.Sprintf(keyRateLimit, key, index-1)
+	)
+
+	// Redis client is not context aware, don't bother calling it once the request is done
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	reply, err := rateLimitScript.Run(rc.Redis, []string{currentKey, previousKey},
+		rule.Requests, strconv.FormatFloat(weight, 'f', -1, 64), (left + rule.Window).Milliseconds()).Result()
+	if err != nil {
+		return result, err
+	}
+
+	allowed, current, previous, err := parseRateLimitReply(reply)
+	if err != nil {
+		return result, err
+	}
+
+	result.Reset = left
+	if !allowed {
+		result.RetryAfter = retryAfter(rule, previous, current, left)
+		return result, nil
+	}
+
+	result.Allowed = true
+	result.Remaining = int(float64(rule.Requests) - float64(previous)*weight - float64(current))
+
+	return result, nil
+}
+
+// parseRateLimitReply reads the reply of rateLimitScript
+func parseRateLimitReply(reply interface{}) (allowed bool, current int, previous int, err error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	counts := make([]int64, len(values))
+	for i, value := range values {
+		if counts[i], ok = value.(int64); !ok {
+			return false, 0, 0, fmt.Errorf("unexpected rate limit reply %v", reply)
+		}
+	}
+
+	return counts[0] == 1, int(counts[1]), int(counts[2]), nil
+}
+
+// retryAfter is the wait until the sliding window counts few enough requests to allow another one: until enough
+// of the previous window slides out, or, when the current window alone is over the limit, until enough of it
+// slides out once it is the previous window
+func retryAfter(rule config.RateLimitRule, previous int, current int, left time.Duration) time.Duration {
+	allowed := float64(rule.Requests - 1)
+
+	if float64(current) > allowed {
+		// current * (window - elapsed) / window <= requests - 1, elapsed in the next window
+		return left + time.Duration(float64(rule.Window)*(float64(current)-allowed)/float64(current))
+	}
+
+	if previous == 0 {
+		return 0
+	}
+
+	// previous * (left - wait) / window + current <= requests - 1
+	wait := left - time.Duration((allowed-float64(current))*float64(rule.Window)/float64(previous))
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
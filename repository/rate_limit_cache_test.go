@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/config"
+	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+)
+
+func TestRateLimitCache_Allow(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockRedis  = repositoryMock.NewMockIRedisClient(mockCtrl)
+		mockLogger = loggerMock.NewMockILogger(mockCtrl)
+
+		rateLimitCache = RateLimitCache{
+			Redis:  mockRedis,
+			Logger: mockLogger,
+		}
+
+		ctx  = context.Background()
+		rule = config.RateLimitRule{Requests: 10, Window: 10 * time.Second}
+
+		// 4 seconds into the 10th window, the previous window still weighs 60%
+		keys = []string{"ratelimit:client-1:10", "ratelimit:client-1:9"}
+
+		// expectScript expects the script to be run with the limit, the weight of the previous window, and the
+		// expiration of the current counter, then to reply with whether it allowed the request and the counts
+		expectScript = func(allowed int64, current int64, previous int64) *gomock.Call {
+			return mockRedis.EXPECT().EvalSha(gomock.Any(), keys, 10, "0.6", int64(16000)).
+				Return(redis.NewCmdResult([]interface{}{allowed, current, previous}, nil)).Times(1)
+		}
+	)
+
+	common.TimeNow = func() time.Time { return time.Unix(104, 0) }
+	defer func() { common.TimeNow = time.Now }()
+
+	t.Run("positive - first request of the window", func(t *testing.T) {
+		expectScript(1, 1, 5)
+
+		result, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 10, result.Limit)
+		assert.Equal(t, 6, result.Remaining)
+		assert.Equal(t, 6*time.Second, result.Reset)
+	})
+
+	t.Run("positive - counted window", func(t *testing.T) {
+		expectScript(1, 3, 5)
+
+		result, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 4, result.Remaining)
+	})
+
+	t.Run("positive - script loaded once evicted", func(t *testing.T) {
+		mockRedis.EXPECT().EvalSha(gomock.Any(), keys, 10, "0.6", int64(16000)).
+			Return(redis.NewCmdResult(nil, errors.New("NOSCRIPT No matching script"))).Times(1)
+		mockRedis.EXPECT().Eval(gomock.Any(), keys, 10, "0.6", int64(16000)).
+			Return(redis.NewCmdResult([]interface{}{int64(1), int64(1), int64(0)}, nil)).Times(1)
+
+		result, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 9, result.Remaining)
+	})
+
+	t.Run("negative - limited until the previous window slides out", func(t *testing.T) {
+		expectScript(0, 7, 5)
+
+		result, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, 2*time.Second, result.RetryAfter)
+	})
+
+	t.Run("negative - limited until the current window slides out once it is the previous one", func(t *testing.T) {
+		expectScript(0, 10, 0)
+
+		// 1s into the next window, 10 * 0.9 + 1 request are within the limit
+		result, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 7*time.Second, result.RetryAfter)
+	})
+
+	t.Run("script returning error", func(t *testing.T) {
+		mockRedis.EXPECT().EvalSha(gomock.Any(), keys, 10, "0.6", int64(16000)).
+			Return(redis.NewCmdResult(nil, errors.New("error"))).Times(1)
+
+		_, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("script returning unexpected reply", func(t *testing.T) {
+		mockRedis.EXPECT().EvalSha(gomock.Any(), keys, 10, "0.6", int64(16000)).
+			Return(redis.NewCmdResult([]interface{}{int64(1)}, nil)).Times(1)
+
+		_, err := rateLimitCache.Allow(ctx, "client-1", rule)
+		assert.EqualError(t, err, "unexpected rate limit reply [1]")
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := rateLimitCache.Allow(canceledCtx, "client-1", rule)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
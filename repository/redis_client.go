@@ -0,0 +1,23 @@
+package repository
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/redis_client.go . IRedisClient
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+type (
+	// IRedisClient holds the commands of github.com/go-redis/redis the redis adapter doesn't expose,
+	// those which read and write a key atomically. *redis.Client implements it.
+	IRedisClient interface {
+		SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+		Eval(script string, keys []string, args ...interface{}) *redis.Cmd
+		EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd
+		ScriptExists(hashes ...string) *redis.BoolSliceCmd
+		ScriptLoad(script string) *redis.StringCmd
+	}
+)
+
+var _ IRedisClient = (*redis.Client)(nil)
@@ -0,0 +1,61 @@
+package repository
+
+//go:generate mockgen -package=repository_mock -destination=../mock/repository/seed.go . ISeeder
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/dityuiri/go-adapter/db"
+)
+
+type (
+	// ISeeder fills the database with the data the app needs to be tried out
+	ISeeder interface {
+		Seed(ctx context.Context) ([]string, error)
+	}
+
+	// Seeder runs the .sql files of Seeds in name order. The seeds are run again on every call, so they must
+	// leave the existing rows alone, like INSERT ... ON CONFLICT DO NOTHING.
+	Seeder struct {
+		DB    db.IDatabase
+		Seeds fs.FS
+	}
+)
+
+// Seed runs every seed in one transaction, a failing seed changes nothing. It returns the seed files run.
+func (s *Seeder) Seed(ctx context.Context) ([]string, error) {
+	files, err := fs.Glob(s.Seeds, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if err = s.run(ctx, tx, file); err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("seed %s: %w", file, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (s *Seeder) run(ctx context.Context, tx db.ITransaction, file string) error {
+	script, err := fs.ReadFile(s.Seeds, file)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecuteContext(ctx, string(script))
+	return err
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	databaseMock "github.com/dityuiri/go-adapter/db/mock"
+)
+
+func TestSeeder_Seed(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = databaseMock.NewMockIDatabase(mockCtrl)
+		mockTx   = databaseMock.NewMockITransaction(mockCtrl)
+
+		seeder = Seeder{DB: mockDB, Seeds: fstest.MapFS{
+			"000002_second.sql": {Data: []byte("INSERT INTO t VALUES (2);")},
+			"000001_first.sql":  {Data: []byte("INSERT INTO t VALUES (1);")},
+			"README.md":         {Data: []byte("not a seed")},
+		}}
+		ctx = context.Background()
+	)
+
+	defer mockCtrl.Finish()
+
+	t.Run("positive - seeds run in name order", func(t *testing.T) {
+		gomock.InOrder(
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "INSERT INTO t VALUES (1);").Return(nil, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "INSERT INTO t VALUES (2);").Return(nil, nil),
+			mockTx.EXPECT().Commit().Return(nil),
+		)
+
+		files, err := seeder.Seed(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"000001_first.sql", "000002_second.sql"}, files)
+	})
+
+	t.Run("negative - failing seed is rolled back", func(t *testing.T) {
+		gomock.InOrder(
+			mockDB.EXPECT().Begin().Return(mockTx, nil),
+			mockTx.EXPECT().ExecuteContext(ctx, "INSERT INTO t VALUES (1);").Return(nil, errors.New("error")),
+			mockTx.EXPECT().Rollback().Return(nil),
+		)
+
+		_, err := seeder.Seed(ctx)
+		assert.EqualError(t, err, "seed 000001_first.sql: error")
+	})
+
+	t.Run("negative - begin error", func(t *testing.T) {
+		mockDB.EXPECT().Begin().Return(nil, errors.New("error"))
+
+		_, err := seeder.Seed(ctx)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dityuiri/go-adapter/server"
+	"github.com/dityuiri/go-baseline/application"
+)
+
+// serveOptions are what the serve, consume and all commands run
+type serveOptions struct {
+	http    bool
+	consume bool
+
+	// httpPort and adminPort override HTTP_PORT and ADMIN_PORT when they are set
+	httpPort  int
+	adminPort int
+}
+
+func serveCommand() *command {
+	return &command{
+		name:  "serve",
+		short: "Serve the HTTP API",
+		help: "Serves the API on HTTP_PORT and the operational endpoints on ADMIN_PORT until SIGINT or SIGTERM.\n" +
+			"Exits 0 once stopped, 1 when the app can't start.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			options := serveOptions{http: true}
+			flags.IntVar(&options.httpPort, "port", 0, "port of the HTTP server, HTTP_PORT by default")
+			flags.IntVar(&options.adminPort, "admin-port", 0, "port of the admin server, ADMIN_PORT by default")
+
+			return func(ctx context.Context, s streams, args []string) int {
+				return serve(ctx, s, options)
+			}
+		},
+	}
+}
+
+func consumeCommand() *command {
+	return &command{
+		name:  "consume",
+		short: "Run the kafka consumers",
+		help: "Consumes the CONSUMER_TOPICS and serves the operational endpoints on ADMIN_PORT until SIGINT or SIGTERM.\n" +
+			"Exits 0 once stopped, 1 when the app can't start.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			options := serveOptions{consume: true}
+			flags.IntVar(&options.adminPort, "admin-port", 0, "port of the admin server, ADMIN_PORT by default")
+
+			return func(ctx context.Context, s streams, args []string) int {
+				return serve(ctx, s, options)
+			}
+		},
+	}
+}
+
+func allCommand() *command {
+	return &command{
+		name:  "all",
+		short: "Serve the HTTP API and run the kafka consumers",
+		help:  "Runs serve and consume in one process. It is the default command. Exits 0 once stopped, 1 when the app can't start.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			options := serveOptions{http: true, consume: true}
+			flags.IntVar(&options.httpPort, "port", 0, "port of the HTTP server, HTTP_PORT by default")
+			flags.IntVar(&options.adminPort, "admin-port", 0, "port of the admin server, ADMIN_PORT by default")
+
+			return func(ctx context.Context, s streams, args []string) int {
+				return serve(ctx, s, options)
+			}
+		},
+	}
+}
+
+// adapters returns the adapters needed by what runs
+func (o serveOptions) adapters() []application.Adapter {
+	adapters := []application.Adapter{application.AdapterTracing}
+	if o.http {
+		adapters = append(adapters, application.AdapterDatabase, application.AdapterRedis, application.AdapterAuth, application.AdapterHTTPClient)
+	}
+
+	if o.consume {
+		adapters = append(adapters, application.AdapterKafkaConsumer, application.AdapterKafkaProducer)
+	}
+
+	return adapters
+}
+
+// serve runs the servers and the consumers of the options until SIGINT or SIGTERM and returns the exit code
+func serve(ctx context.Context, s streams, options serveOptions) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	app, err := application.SetupApplication(ctx, options.adapters()...)
+	if err != nil {
+		_, _ = fmt.Fprintf(s.err, "failed to set up the app: %s\n", err)
+		return exitFailure
+	}
+
+	// Closed on return, after wg.Wait, so the producer is flushed once the consumers are stopped
+	defer app.Close()
+
+	if !application.WatchConfiguration(ctx, app.LiveConfig, app.LogLevel, app.Logger) {
+		app.Logger.Info("no configuration file to watch, the configuration is not reloaded")
+	}
+
+	// Setup dependency injection
+	dep := application.SetupDependency(app)
+
+	// The app stays unready until its dependencies are available, the probes are served meanwhile
+	dep.HealthRegistry.BeginStartup()
+
+	// Goroutine to cancel when Os interrupt happens
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(c)
+
+		select {
+		case <-c:
+		case <-ctx.Done():
+			return
+		}
+
+		log.Println("Received system interrupt. Stopping servers...")
+
+		// Readiness fails from now on, the servers keep serving until the load balancer stops routing to them
+		dep.HealthRegistry.ShutDown()
+		time.Sleep(app.Config.Health.ShutdownDelay)
+		cancel()
+	}()
+
+	servers := []server.IServer{serveAdmin(app, dep, portOr(options.adminPort, app.Config.Const.AdminPort))}
+	if options.http {
+		servers = append(servers, serveHTTP(app, dep, portOr(options.httpPort, app.Config.Const.HTTPPort)))
+	}
+
+	code := exitOK
+	for _, srv := range servers {
+		if err = srv.Serve(); err != nil {
+			_, _ = fmt.Fprintf(s.err, "failed to start the server: %s\n", err)
+			code = exitFailure
+			cancel()
+		}
+	}
+
+	var wg sync.WaitGroup
+	if code == exitOK {
+		// A critical dependency still not available after STARTUP_MAX_WAIT stops the app
+		err = application.WaitForDependencies(ctx, dep.HealthRegistry, app.Config.Startup, app.Logger)
+		if err == nil && options.consume {
+			err = consumeKafkaMessages(ctx, app, dep, &wg)
+		}
+
+		if err != nil && ctx.Err() == nil {
+			_, _ = fmt.Fprintf(s.err, "failed to start: %s\n", err)
+			code = exitFailure
+			cancel()
+		}
+	}
+
+	<-ctx.Done()
+	for _, srv := range servers {
+		_ = srv.Close()
+	}
+
+	wg.Wait()
+
+	return code
+}
+
+func portOr(port, configured int) int {
+	if port != 0 {
+		return port
+	}
+
+	return configured
+}
@@ -3,28 +3,58 @@ package service
 //go:generate mockgen -package=service_mock -destination=../mock/service/health_check.go . IHealthCheckService
 
 import (
-	"github.com/dityuiri/go-baseline/repository"
+	"context"
+	"time"
+
+	"github.com/dityuiri/go-baseline/common/health"
 )
 
+// statusMaxAge is how long the status reported to the public endpoints is served without probing the dependencies
+const statusMaxAge = 5 * time.Second
+
 type (
 	// IHealthCheckService is an interface that has all the function to be implemented inside health check service
 	IHealthCheckService interface {
-		Ping() map[string]string
+		Ping(ctx context.Context) map[string]string
+		Liveness() health.Report
+		Readiness(ctx context.Context) health.Report
+		Status(ctx context.Context) health.Report
+		Startup() health.Report
 	}
 
 	// HealthCheckService is a struct that consists of all the dependencies needed for health check service
 	HealthCheckService struct {
-		HealthCheckRepo repository.IHealthCheckRepository
+		Registry *health.Registry
 	}
 )
 
-// Ping is a service function to do health check
-func (s *HealthCheckService) Ping() map[string]string {
+// Ping is a service function to do health check, from the status probed within statusMaxAge
+func (s *HealthCheckService) Ping(ctx context.Context) map[string]string {
 	var status = "OK"
 
-	if err := s.HealthCheckRepo.Ping(); err != nil {
+	if !s.Status(ctx).IsReady() {
 		status = "NOT OK"
 	}
 
 	return map[string]string{"status": status}
 }
+
+// Liveness reports the process is alive, without probing the dependencies
+func (s *HealthCheckService) Liveness() health.Report {
+	return s.Registry.Liveness()
+}
+
+// Readiness probes the dependencies registered in the health check registry
+func (s *HealthCheckService) Readiness(ctx context.Context) health.Report {
+	return s.Registry.Readiness(ctx)
+}
+
+// Status reports the readiness without the components, probing the dependencies at most every statusMaxAge
+func (s *HealthCheckService) Status(ctx context.Context) health.Report {
+	return s.Registry.Status(ctx, statusMaxAge)
+}
+
+// Startup reports whether the app is done waiting for its dependencies
+func (s *HealthCheckService) Startup() health.Report {
+	return s.Registry.Startup()
+}
@@ -1,47 +1,89 @@
 package service
 
-//go:generate mockgen -package=service_mock -destination=../mock/service/health_check.go . IHealthCheckService
-
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
-	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
+	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/health"
 )
 
-func TestPing(t *testing.T) {
-	t.Run("ping success", func(t *testing.T) {
-		mockCtrl := gomock.NewController(t)
-		defer mockCtrl.Finish()
-
-		mockRepository := repositoryMock.NewMockIHealthCheckRepository(mockCtrl)
+func TestHealthCheckService(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		now     = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		failing bool
 
-		mockRepository.EXPECT().Ping().Return(nil).Times(1)
+		registry = health.NewRegistry()
+		s        = HealthCheckService{Registry: registry}
+	)
 
-		s := HealthCheckService{
-			HealthCheckRepo: mockRepository,
+	registry.Register(health.Check{Name: "db", Critical: true, Func: func(context.Context) error {
+		if failing {
+			return errors.New("error")
 		}
 
-		result := s.Ping()
+		return nil
+	}})
+
+	common.TimeNow = func() time.Time { return now }
+	defer func() { common.TimeNow = time.Now }()
+
+	t.Run("ping success", func(t *testing.T) {
+		failing = false
+
+		result := s.Ping(ctx)
+		assert.Equal(t, result["status"], "OK")
+	})
+
+	t.Run("ping from the status probed within statusMaxAge", func(t *testing.T) {
+		failing = true
+
+		result := s.Ping(ctx)
 		assert.Equal(t, result["status"], "OK")
 	})
 
 	t.Run("ping error", func(t *testing.T) {
-		mockCtrl := gomock.NewController(t)
-		defer mockCtrl.Finish()
+		failing = true
+		now = now.Add(statusMaxAge)
 
-		mockRepository := repositoryMock.NewMockIHealthCheckRepository(mockCtrl)
+		result := s.Ping(ctx)
+		assert.Equal(t, result["status"], "NOT OK")
+	})
 
-		mockRepository.EXPECT().Ping().Return(errors.New("error")).Times(1)
+	t.Run("status without the components", func(t *testing.T) {
+		failing = false
 
-		s := HealthCheckService{
-			HealthCheckRepo: mockRepository,
-		}
+		report := s.Status(ctx)
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Empty(t, report.Components)
+	})
 
-		result := s.Ping()
-		assert.Equal(t, result["status"], "NOT OK")
+	t.Run("readiness", func(t *testing.T) {
+		failing = true
+
+		report := s.Readiness(ctx)
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, "error", report.Components["db"].LastError)
+	})
+
+	t.Run("liveness", func(t *testing.T) {
+		failing = true
+
+		report := s.Liveness()
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.Equal(t, health.StatusDown, report.Components["db"].Status)
+	})
+
+	t.Run("startup", func(t *testing.T) {
+		registry.BeginStartup()
+		assert.Equal(t, health.StatusStarting, s.Startup().Status)
+
+		registry.EndStartup()
+		assert.Equal(t, health.StatusUp, s.Startup().Status)
 	})
 }
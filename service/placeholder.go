@@ -3,12 +3,18 @@ package service
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/go-redis/redis"
+	"github.com/google/uuid"
 
 	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/tracing"
+	"github.com/dityuiri/go-baseline/common/util"
+	"github.com/dityuiri/go-baseline/common/validator"
 	"github.com/dityuiri/go-baseline/model"
 	"github.com/dityuiri/go-baseline/model/alpha"
 	"github.com/dityuiri/go-baseline/proxy"
@@ -17,10 +23,17 @@ import (
 
 //go:generate mockgen -package=service_mock -destination=../mock/service/placeholder.go . IPlaceholderService
 
+// AnyVersion updates the placeholder whatever its stored version, as If-Match: * asks
+const AnyVersion = repository.AnyVersion
+
 type (
 	IPlaceholderService interface {
 		CreateNewPlaceholder(ctx context.Context, placeholderRequest model.PlaceholderCreateRequest) (model.PlaceholderCreateResponse, error)
 		GetPlaceholder(ctx context.Context, placeholderID string) (model.PlaceholderGetResponse, error)
+		ListPlaceholders(ctx context.Context, listRequest model.PlaceholderListRequest) (model.PlaceholderListResponse, error)
+		UpdatePlaceholder(ctx context.Context, placeholderID string, version int64, placeholderRequest model.PlaceholderUpdateRequest) (model.PlaceholderUpdateResponse, error)
+		PatchPlaceholder(ctx context.Context, placeholderID string, version int64, mergePatch []byte) (model.PlaceholderUpdateResponse, error)
+		DeletePlaceholder(ctx context.Context, placeholderID string) error
 	}
 
 	PlaceholderService struct {
@@ -28,17 +41,25 @@ type (
 		PlaceholderRepository repository.IPlaceholderRepository
 		PlaceholderCache      repository.IPlaceholderCache
 		AlphaProxy            proxy.IAlphaProxy
+		Ownership             auth.Ownership
 	}
 )
 
 func (ps *PlaceholderService) CreateNewPlaceholder(ctx context.Context, placeholderRequest model.PlaceholderCreateRequest) (model.PlaceholderCreateResponse, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.CreateNewPlaceholder")
+	defer span.End()
+
 	// Implement your code here
 	var response model.PlaceholderCreateResponse
 	// Insert placeholder
 	placeholderDTO := placeholderRequest.ToPlaceholderDTO()
+	placeholderDTO.CreatedBy = auth.SubjectFromContext(ctx)
+	placeholderDTO.UpdatedBy = placeholderDTO.CreatedBy
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderDTO.ID.String())
+
 	err := ps.PlaceholderRepository.InsertPlaceholder(ctx, nil, placeholderDTO.ToPlaceholderDAO())
 	if err != nil {
-		ps.Logger.Error("error inserting placeholder")
+		logging.WithContext(ctx, ps.Logger).Error("error inserting placeholder", log.WithError(err))
 		return response, err
 	}
 
@@ -46,6 +67,10 @@ func (ps *PlaceholderService) CreateNewPlaceholder(ctx context.Context, placehol
 }
 
 func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID string) (model.PlaceholderGetResponse, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.GetPlaceholder", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderID)
+
 	// Implement your code here
 	// Redis cache + db repository + http proxy example
 	var placeholderResp model.PlaceholderGetResponse
@@ -54,7 +79,7 @@ func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID
 	placeholderDTO, err := ps.PlaceholderCache.GetPlaceholderInfo(ctx, placeholderID)
 	if err != nil {
 		if err != redis.Nil {
-			ps.Logger.Error("error getting placeholder cache from redis")
+			logging.WithContext(ctx, ps.Logger).Error("error getting placeholder cache from redis", log.WithError(err))
 			return placeholderResp, err
 		}
 
@@ -63,10 +88,10 @@ func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID
 		placeholderDAO, err := ps.PlaceholderRepository.GetSinglePlaceholder(ctx, placeholderID)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				ps.Logger.Info(fmt.Sprintf("placeholder with id %s not found", placeholderID))
+				logging.WithContext(ctx, ps.Logger).Info("placeholder not found")
 				err = common.ErrPlaceholderNotFound
 			} else {
-				ps.Logger.Error("error getting placeholder data from db")
+				logging.WithContext(ctx, ps.Logger).Error("error getting placeholder data from db", log.WithError(err))
 			}
 
 			return placeholderResp, err
@@ -78,7 +103,7 @@ func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID
 		// Set to redis
 		err = ps.PlaceholderCache.SetPlaceholderInfo(ctx, placeholderFromDB)
 		if err != nil {
-			ps.Logger.Error("error set placeholder to redis cache")
+			logging.WithContext(ctx, ps.Logger).Error("error set placeholder to redis cache", log.WithError(err))
 			return placeholderResp, err
 		}
 	}
@@ -89,7 +114,7 @@ func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID
 	alphaReq := ps.mapPlaceholderDTOToAlphaStatusRequest(*placeholderDTO)
 	alphaResp, err := ps.AlphaProxy.GetPlaceholderStatus(ctx, alphaReq)
 	if err != nil {
-		ps.Logger.Error("get placeholder status error")
+		logging.WithContext(ctx, ps.Logger).Error("get placeholder status error", log.WithError(err))
 		return placeholderResp, err
 	}
 
@@ -98,6 +123,191 @@ func (ps *PlaceholderService) GetPlaceholder(ctx context.Context, placeholderID
 	return placeholderResp, err
 }
 
+func (ps *PlaceholderService) ListPlaceholders(ctx context.Context, listRequest model.PlaceholderListRequest) (model.PlaceholderListResponse, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.ListPlaceholders")
+	defer span.End()
+
+	var (
+		filter   = listRequest.ToPlaceholderFilter()
+		response = model.PlaceholderListResponse{
+			Items:    make([]model.PlaceholderGetResponse, 0),
+			Page:     listRequest.Page,
+			PageSize: listRequest.PageSize,
+		}
+	)
+
+	placeholderDAOs, err := ps.PlaceholderRepository.GetPlaceholders(ctx, filter)
+	if err != nil {
+		logging.WithContext(ctx, ps.Logger, "page", listRequest.Page, "page_size", listRequest.PageSize).Error("error getting placeholders from db", log.WithError(err))
+		return response, err
+	}
+
+	totalItems, err := ps.PlaceholderRepository.CountPlaceholders(ctx, filter)
+	if err != nil {
+		logging.WithContext(ctx, ps.Logger, "page", listRequest.Page, "page_size", listRequest.PageSize).Error("error counting placeholders from db", log.WithError(err))
+		return response, err
+	}
+
+	for _, placeholderDAO := range placeholderDAOs {
+		placeholderDTO := placeholderDAO.ToPlaceholderDTO()
+		response.Items = append(response.Items, placeholderDTO.ToPlaceholderGetResponse())
+	}
+
+	response.TotalItems = totalItems
+	if listRequest.PageSize > 0 {
+		response.TotalPages = (totalItems + listRequest.PageSize - 1) / listRequest.PageSize
+	}
+
+	return response, nil
+}
+
+// UpdatePlaceholder replaces the placeholder, as long as it is still at the given version, or AnyVersion
+func (ps *PlaceholderService) UpdatePlaceholder(ctx context.Context, placeholderID string, version int64, placeholderRequest model.PlaceholderUpdateRequest) (model.PlaceholderUpdateResponse, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.UpdatePlaceholder", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderID)
+
+	if _, err := uuid.Parse(placeholderID); err != nil {
+		return model.PlaceholderUpdateResponse{}, common.ErrInvalidUUIDPlaceholderID
+	}
+
+	placeholderDTO, err := ps.getOwnedPlaceholder(ctx, placeholderID)
+	if err != nil {
+		return model.PlaceholderUpdateResponse{}, err
+	}
+
+	placeholderRequest.ApplyToPlaceholderDTO(&placeholderDTO)
+	placeholderDTO.Version = version
+
+	return ps.updatePlaceholder(ctx, placeholderDTO)
+}
+
+// PatchPlaceholder applies a JSON Merge Patch (RFC 7386) document on top of the stored placeholder,
+// as long as it is still at the given version, or AnyVersion
+func (ps *PlaceholderService) PatchPlaceholder(ctx context.Context, placeholderID string, version int64, mergePatch []byte) (model.PlaceholderUpdateResponse, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.PatchPlaceholder", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderID)
+
+	var response model.PlaceholderUpdateResponse
+
+	placeholderDTO, err := ps.getOwnedPlaceholder(ctx, placeholderID)
+	if err != nil {
+		return response, err
+	}
+
+	placeholderRequest := placeholderDTO.ToPlaceholderUpdateRequest()
+
+	original, err := common.JsonMarshal(placeholderRequest)
+	if err != nil {
+		logging.WithContext(ctx, ps.Logger).Error("error marshaling placeholder", log.WithError(err))
+		return response, err
+	}
+
+	patched, err := util.MergePatch(original, mergePatch)
+	if err != nil {
+		return response, common.ErrInvalidRequestBody
+	}
+
+	placeholderRequest = model.PlaceholderUpdateRequest{}
+	if err = common.JsonUnmarshal(patched, &placeholderRequest); err != nil {
+		return response, common.ErrInvalidRequestBody
+	}
+
+	// The patched placeholder has to satisfy the same rules as a full update
+	if err = validator.Validate(placeholderRequest); err != nil {
+		return response, err
+	}
+
+	placeholderRequest.ApplyToPlaceholderDTO(&placeholderDTO)
+	placeholderDTO.Version = version
+
+	return ps.updatePlaceholder(ctx, placeholderDTO)
+}
+
+func (ps *PlaceholderService) DeletePlaceholder(ctx context.Context, placeholderID string) error {
+	ctx, span := tracing.Start(ctx, "PlaceholderService.DeletePlaceholder", tracing.PlaceholderID(placeholderID))
+	defer span.End()
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderID)
+
+	err := ps.PlaceholderRepository.DeletePlaceholder(ctx, nil, placeholderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.WithContext(ctx, ps.Logger).Info("placeholder not found")
+			err = common.ErrPlaceholderNotFound
+		} else {
+			logging.WithContext(ctx, ps.Logger).Error("error deleting placeholder from db", log.WithError(err))
+		}
+
+		return err
+	}
+
+	// Make sure the deleted placeholder is no longer served from the cache
+	err = ps.PlaceholderCache.DeletePlaceholderInfo(ctx, placeholderID)
+	if err != nil {
+		logging.WithContext(ctx, ps.Logger).Error("error deleting placeholder cache from redis", log.WithError(err))
+		return err
+	}
+
+	return nil
+}
+
+// getOwnedPlaceholder gets the placeholder from db, as long as the principal is allowed to modify it
+func (ps *PlaceholderService) getOwnedPlaceholder(ctx context.Context, placeholderID string) (model.PlaceholderDTO, error) {
+	placeholderDAO, err := ps.PlaceholderRepository.GetSinglePlaceholder(ctx, placeholderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.WithContext(ctx, ps.Logger).Info("placeholder not found")
+			err = common.ErrPlaceholderNotFound
+		} else {
+			logging.WithContext(ctx, ps.Logger).Error("error getting placeholder data from db", log.WithError(err))
+		}
+
+		return model.PlaceholderDTO{}, err
+	}
+
+	if err = ps.Ownership.Check(ctx, placeholderDAO.CreatedBy); err != nil {
+		logging.WithContext(ctx, ps.Logger).Info("placeholder is not owned by the principal", log.WithError(err))
+		return model.PlaceholderDTO{}, err
+	}
+
+	return placeholderDAO.ToPlaceholderDTO(), nil
+}
+
+// updatePlaceholder stores the placeholder on behalf of the authenticated principal.
+// The repository only updates it when placeholderDTO.Version is still the stored version, or is AnyVersion.
+func (ps *PlaceholderService) updatePlaceholder(ctx context.Context, placeholderDTO model.PlaceholderDTO) (model.PlaceholderUpdateResponse, error) {
+	var response model.PlaceholderUpdateResponse
+
+	placeholderDTO.UpdatedBy = auth.SubjectFromContext(ctx)
+
+	version, err := ps.PlaceholderRepository.UpdatePlaceholder(ctx, nil, placeholderDTO.ToPlaceholderDAO())
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			logging.WithContext(ctx, ps.Logger).Info("placeholder not found")
+			err = common.ErrPlaceholderNotFound
+		case common.ErrVersionMismatch:
+			logging.WithContext(ctx, ps.Logger, "version", placeholderDTO.Version).Info("placeholder is no longer at the version", log.WithError(err))
+		default:
+			logging.WithContext(ctx, ps.Logger).Error("error updating placeholder", log.WithError(err))
+		}
+
+		return response, err
+	}
+
+	placeholderDTO.Version = version
+
+	// Invalidate the cache so the next read picks up the updated placeholder
+	err = ps.PlaceholderCache.DeletePlaceholderInfo(ctx, placeholderDTO.ID.String())
+	if err != nil {
+		logging.WithContext(ctx, ps.Logger).Error("error deleting placeholder cache from redis", log.WithError(err))
+		return response, err
+	}
+
+	return placeholderDTO.ToPlaceholderUpdateResponse(), nil
+}
+
 func (ps *PlaceholderService) mapPlaceholderDTOToAlphaStatusRequest(placeholderDTO model.PlaceholderDTO) alpha.AlphaRequest {
 	return alpha.AlphaRequest{
 		PlaceholderID: placeholderDTO.ID.String(),
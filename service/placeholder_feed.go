@@ -4,7 +4,11 @@ import (
 	"context"
 
 	"github.com/dityuiri/go-adapter/logger"
+	"github.com/dityuiri/go-adapter/logger/log"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/logging"
+	"github.com/dityuiri/go-baseline/common/tracing"
 	"github.com/dityuiri/go-baseline/model"
 	"github.com/dityuiri/go-baseline/repository"
 )
@@ -23,10 +27,26 @@ type (
 )
 
 func (fs *PlaceholderFeedService) PlaceholderRecorded(ctx context.Context, placeholderMsg model.PlaceholderMessage) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PlaceholderFeedService.PlaceholderRecorded", tracing.PlaceholderID(placeholderMsg.ID))
+	defer span.End()
+	ctx = logging.NewContext(ctx, logging.FieldPlaceholderID, placeholderMsg.ID)
+
 	placeholderMsg.EventName = common.EventPlaceholderRecorded
+
+	// Attribute the message to the authenticated principal unless the caller already did
+	if subject := auth.SubjectFromContext(ctx); subject != "" {
+		if placeholderMsg.CreatedBy == "" {
+			placeholderMsg.CreatedBy = subject
+		}
+
+		if placeholderMsg.UpdatedBy == "" {
+			placeholderMsg.UpdatedBy = subject
+		}
+	}
+
 	err := fs.PlaceholderProducer.ProducePlaceholderRecord(ctx, placeholderMsg)
 	if err != nil {
-		fs.Logger.Error("failed to produce placeholder message")
+		logging.WithContext(ctx, fs.Logger).Error("failed to produce placeholder message", log.WithError(err))
 		return false, err
 	}
 
@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/mock"
 	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
 	"github.com/dityuiri/go-baseline/model"
 )
@@ -28,17 +30,33 @@ func TestPlaceholderFeedService_PlaceholderRecorded(t *testing.T) {
 		placeholderMsg = model.PlaceholderMessage{}
 	)
 
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
 	t.Run("positive", func(t *testing.T) {
-		mockPlaceholderProducer.EXPECT().ProducePlaceholderRecord(ctx, gomock.Any()).Return(nil).Times(1)
+		mockPlaceholderProducer.EXPECT().ProducePlaceholderRecord(mock.InSpan(ctx, "PlaceholderFeedService.PlaceholderRecorded"), gomock.Any()).Return(nil).Times(1)
 
 		isSuccess, err := placeholderFeedService.PlaceholderRecorded(ctx, placeholderMsg)
 		assert.Nil(t, err)
 		assert.True(t, isSuccess)
 	})
 
+	t.Run("positive - stamped with the principal", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-1"})
+		mockPlaceholderProducer.EXPECT().ProducePlaceholderRecord(mock.InSpan(authCtx, "PlaceholderFeedService.PlaceholderRecorded"), gomock.Any()).DoAndReturn(
+			func(_ context.Context, msg model.PlaceholderMessage) error {
+				assert.Equal(t, "user-1", msg.CreatedBy)
+				assert.Equal(t, "someone", msg.UpdatedBy)
+				return nil
+			}).Times(1)
+
+		isSuccess, err := placeholderFeedService.PlaceholderRecorded(authCtx, model.PlaceholderMessage{UpdatedBy: "someone"})
+		assert.Nil(t, err)
+		assert.True(t, isSuccess)
+	})
+
 	t.Run("producer returning error", func(t *testing.T) {
-		mockPlaceholderProducer.EXPECT().ProducePlaceholderRecord(ctx, gomock.Any()).Return(errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockPlaceholderProducer.EXPECT().ProducePlaceholderRecord(mock.InSpan(ctx, "PlaceholderFeedService.PlaceholderRecorded"), gomock.Any()).Return(errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		isSuccess, err := placeholderFeedService.PlaceholderRecorded(ctx, placeholderMsg)
 		assert.EqualError(t, err, "error")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/go-redis/redis"
@@ -13,12 +14,20 @@ import (
 
 	loggerMock "github.com/dityuiri/go-adapter/logger/mock"
 	"github.com/dityuiri/go-baseline/common"
+	"github.com/dityuiri/go-baseline/common/auth"
+	"github.com/dityuiri/go-baseline/common/validator"
+	"github.com/dityuiri/go-baseline/mock"
 	proxyMock "github.com/dityuiri/go-baseline/mock/proxy"
 	repositoryMock "github.com/dityuiri/go-baseline/mock/repository"
 	"github.com/dityuiri/go-baseline/model"
 	"github.com/dityuiri/go-baseline/model/alpha"
 )
 
+func TestMain(m *testing.M) {
+	mock.NewSpanRecorder()
+	os.Exit(m.Run())
+}
+
 func TestPlaceholderService_CreateNewPlaceholder(t *testing.T) {
 	var (
 		mockCtrl             = gomock.NewController(t)
@@ -38,17 +47,32 @@ func TestPlaceholderService_CreateNewPlaceholder(t *testing.T) {
 		placeholderCreateRequest = model.PlaceholderCreateRequest{}
 	)
 
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
 	t.Run("positive", func(t *testing.T) {
-		mockPlaceholderRepo.EXPECT().InsertPlaceholder(ctx, nil, gomock.Any()).Return(nil)
+		mockPlaceholderRepo.EXPECT().InsertPlaceholder(mock.InSpan(ctx, "PlaceholderService.CreateNewPlaceholder"), nil, gomock.Any()).Return(nil)
 
 		res, err := placeholderService.CreateNewPlaceholder(ctx, placeholderCreateRequest)
 		assert.Nil(t, err)
 		assert.NotEmpty(t, res)
 	})
 
+	t.Run("positive - stamped with the principal", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-1"})
+		mockPlaceholderRepo.EXPECT().InsertPlaceholder(mock.InSpan(authCtx, "PlaceholderService.CreateNewPlaceholder"), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ interface{}, placeholder model.PlaceholderDAO) error {
+				assert.Equal(t, "user-1", placeholder.CreatedBy)
+				assert.Equal(t, "user-1", placeholder.UpdatedBy)
+				return nil
+			}).Times(1)
+
+		_, err := placeholderService.CreateNewPlaceholder(authCtx, placeholderCreateRequest)
+		assert.Nil(t, err)
+	})
+
 	t.Run("insert placeholder returning error", func(t *testing.T) {
-		mockPlaceholderRepo.EXPECT().InsertPlaceholder(ctx, nil, gomock.Any()).Return(errors.New("error"))
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockPlaceholderRepo.EXPECT().InsertPlaceholder(mock.InSpan(ctx, "PlaceholderService.CreateNewPlaceholder"), nil, gomock.Any()).Return(errors.New("error"))
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		res, err := placeholderService.CreateNewPlaceholder(ctx, placeholderCreateRequest)
 		assert.EqualError(t, err, "error")
@@ -75,9 +99,11 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 		placeholderID = uuid.New()
 	)
 
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
 	t.Run("positive - found the cache", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, nil).Times(1)
-		mockAlphaProxy.EXPECT().GetPlaceholderStatus(ctx, gomock.Any()).Return(alpha.AlphaResponse{}, nil).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, nil).Times(1)
+		mockAlphaProxy.EXPECT().GetPlaceholderStatus(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), gomock.Any()).Return(alpha.AlphaResponse{}, nil).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.Nil(t, err)
@@ -85,9 +111,9 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("negative - found the cache - get status from proxy error", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, nil).Times(1)
-		mockAlphaProxy.EXPECT().GetPlaceholderStatus(ctx, gomock.Any()).Return(alpha.AlphaResponse{}, errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, nil).Times(1)
+		mockAlphaProxy.EXPECT().GetPlaceholderStatus(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), gomock.Any()).Return(alpha.AlphaResponse{}, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.EqualError(t, err, "error")
@@ -95,8 +121,8 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("negative - get from cache returning error", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.EqualError(t, err, "error")
@@ -104,9 +130,11 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("negative - get single placeholder return error", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
-		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(ctx, placeholderID.String()).Return(model.PlaceholderDAO{}, errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		dbErr := errors.New("error")
+
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, dbErr).Times(1)
+		mockLogger.EXPECT().Error("error getting placeholder data from db", mock.LogWith(dbErr, "placeholder_id="+placeholderID.String())).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.EqualError(t, err, "error")
@@ -114,9 +142,9 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("negative - placeholder not found", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
-		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(ctx, placeholderID.String()).Return(model.PlaceholderDAO{}, sql.ErrNoRows).Times(1)
-		mockLogger.EXPECT().Info(gomock.Any()).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, sql.ErrNoRows).Times(1)
+		mockLogger.EXPECT().Info("placeholder not found", gomock.Any()).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.EqualError(t, err, common.ErrPlaceholderNotFound.Error())
@@ -124,10 +152,10 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("negative - set placeholder to cache returning error", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
-		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(ctx, placeholderID.String()).Return(model.PlaceholderDAO{}, nil).Times(1)
-		mockPlaceholderCache.EXPECT().SetPlaceholderInfo(ctx, gomock.Any()).Return(errors.New("error")).Times(1)
-		mockLogger.EXPECT().Error(gomock.Any()).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, nil).Times(1)
+		mockPlaceholderCache.EXPECT().SetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), gomock.Any()).Return(errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.EqualError(t, err, "error")
@@ -135,10 +163,10 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 	t.Run("positive - set cache when placeholder not found", func(t *testing.T) {
-		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(ctx, placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
-		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(ctx, placeholderID.String()).Return(model.PlaceholderDAO{}, nil).Times(1)
-		mockPlaceholderCache.EXPECT().SetPlaceholderInfo(ctx, gomock.Any()).Return(nil).Times(1)
-		mockAlphaProxy.EXPECT().GetPlaceholderStatus(ctx, gomock.Any()).Return(alpha.AlphaResponse{}, nil).Times(1)
+		mockPlaceholderCache.EXPECT().GetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(&model.PlaceholderDTO{}, redis.Nil).Times(1)
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, nil).Times(1)
+		mockPlaceholderCache.EXPECT().SetPlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), gomock.Any()).Return(nil).Times(1)
+		mockAlphaProxy.EXPECT().GetPlaceholderStatus(mock.InSpan(ctx, "PlaceholderService.GetPlaceholder"), gomock.Any()).Return(alpha.AlphaResponse{}, nil).Times(1)
 
 		res, err := placeholderService.GetPlaceholder(ctx, placeholderID.String())
 		assert.Nil(t, err)
@@ -146,3 +174,362 @@ func TestPlaceholderService_GetPlaceholder(t *testing.T) {
 	})
 
 }
+
+func TestPlaceholderService_ListPlaceholders(t *testing.T) {
+	var (
+		mockCtrl             = gomock.NewController(t)
+		mockLogger           = loggerMock.NewMockILogger(mockCtrl)
+		mockPlaceholderRepo  = repositoryMock.NewMockIPlaceholderRepository(mockCtrl)
+		mockPlaceholderCache = repositoryMock.NewMockIPlaceholderCache(mockCtrl)
+		mockAlphaProxy       = proxyMock.NewMockIAlphaProxy(mockCtrl)
+
+		placeholderService = PlaceholderService{
+			Logger:                mockLogger,
+			PlaceholderRepository: mockPlaceholderRepo,
+			PlaceholderCache:      mockPlaceholderCache,
+			AlphaProxy:            mockAlphaProxy,
+		}
+
+		ctx         = context.Background()
+		listRequest = model.PlaceholderListRequest{
+			Page:     2,
+			PageSize: 2,
+		}
+		filter = listRequest.ToPlaceholderFilter()
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetPlaceholders(mock.InSpan(ctx, "PlaceholderService.ListPlaceholders"), filter).Return([]model.PlaceholderDAO{{ID: uuid.New()}, {ID: uuid.New()}}, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().CountPlaceholders(mock.InSpan(ctx, "PlaceholderService.ListPlaceholders"), filter).Return(5, nil).Times(1)
+
+		res, err := placeholderService.ListPlaceholders(ctx, listRequest)
+		assert.Nil(t, err)
+		assert.Len(t, res.Items, 2)
+		assert.Equal(t, 5, res.TotalItems)
+		assert.Equal(t, 3, res.TotalPages)
+		assert.Equal(t, 2, res.Page)
+	})
+
+	t.Run("negative - get placeholders returning error", func(t *testing.T) {
+		dbErr := errors.New("error")
+
+		mockPlaceholderRepo.EXPECT().GetPlaceholders(mock.InSpan(ctx, "PlaceholderService.ListPlaceholders"), filter).Return(nil, dbErr).Times(1)
+		mockLogger.EXPECT().Error("error getting placeholders from db", mock.LogWith(dbErr, "page=2", "page_size=2")).Times(1)
+
+		_, err := placeholderService.ListPlaceholders(ctx, listRequest)
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("negative - count placeholders returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetPlaceholders(mock.InSpan(ctx, "PlaceholderService.ListPlaceholders"), filter).Return([]model.PlaceholderDAO{}, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().CountPlaceholders(mock.InSpan(ctx, "PlaceholderService.ListPlaceholders"), filter).Return(0, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.ListPlaceholders(ctx, listRequest)
+		assert.EqualError(t, err, "error")
+	})
+}
+
+func TestPlaceholderService_UpdatePlaceholder(t *testing.T) {
+	var (
+		mockCtrl             = gomock.NewController(t)
+		mockLogger           = loggerMock.NewMockILogger(mockCtrl)
+		mockPlaceholderRepo  = repositoryMock.NewMockIPlaceholderRepository(mockCtrl)
+		mockPlaceholderCache = repositoryMock.NewMockIPlaceholderCache(mockCtrl)
+		mockAlphaProxy       = proxyMock.NewMockIAlphaProxy(mockCtrl)
+
+		placeholderService = PlaceholderService{
+			Logger:                mockLogger,
+			PlaceholderRepository: mockPlaceholderRepo,
+			PlaceholderCache:      mockPlaceholderCache,
+			AlphaProxy:            mockAlphaProxy,
+			Ownership:             auth.Ownership{BypassRoles: []string{common.RoleAdmin}},
+		}
+
+		ctx                      = context.Background()
+		placeholderID            = uuid.New()
+		placeholderUpdateRequest = model.PlaceholderUpdateRequest{
+			Name:   "Aoi",
+			Amount: 10000,
+		}
+		placeholderDAO = model.PlaceholderDAO{
+			ID:        placeholderID,
+			Name:      "Aoi",
+			Amount:    5000,
+			CreatedBy: "user-1",
+			Version:   3,
+		}
+		version = int64(3)
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ interface{}, placeholder model.PlaceholderDAO) (int64, error) {
+				assert.Equal(t, version, placeholder.Version)
+				return placeholder.Version + 1, nil
+			}).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(nil).Times(1)
+
+		res, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Nil(t, err)
+		assert.Equal(t, placeholderID.String(), res.ID)
+		assert.Equal(t, placeholderUpdateRequest.Name, res.Name)
+		assert.Equal(t, version+1, res.Version)
+	})
+
+	t.Run("positive - any version updates the placeholder modified since it was read", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ interface{}, placeholder model.PlaceholderDAO) (int64, error) {
+				assert.Equal(t, AnyVersion, placeholder.Version)
+
+				// Another update bumped the version read to 4 meanwhile, this one makes it 5
+				return placeholderDAO.Version + 2, nil
+			}).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(nil).Times(1)
+
+		res, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), AnyVersion, placeholderUpdateRequest)
+		assert.Nil(t, err)
+		assert.Equal(t, placeholderDAO.Version+2, res.Version)
+	})
+
+	t.Run("negative - placeholder modified since the expected version", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).Return(int64(0), common.ErrVersionMismatch).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version-1, placeholderUpdateRequest)
+		assert.Equal(t, common.ErrVersionMismatch, err)
+	})
+
+	t.Run("positive - stamped with the principal", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-1"})
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ interface{}, placeholder model.PlaceholderDAO) (int64, error) {
+				assert.Equal(t, "user-1", placeholder.UpdatedBy)
+				assert.Equal(t, "user-1", placeholder.CreatedBy)
+				return placeholder.Version + 1, nil
+			}).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(nil).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(authCtx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("negative - invalid placeholder id", func(t *testing.T) {
+		_, err := placeholderService.UpdatePlaceholder(ctx, "sausage", version, placeholderUpdateRequest)
+		assert.Equal(t, common.ErrInvalidUUIDPlaceholderID, err)
+	})
+
+	t.Run("positive - admin updates a placeholder of another user", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-2", Roles: []string{common.RoleAdmin}})
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).Return(version+1, nil).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(nil).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(authCtx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("negative - not the owner", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-2"})
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(authCtx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(authCtx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Equal(t, common.ErrNotOwner, err)
+	})
+
+	t.Run("negative - placeholder to update not found", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, sql.ErrNoRows).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Equal(t, common.ErrPlaceholderNotFound, err)
+	})
+
+	t.Run("negative - placeholder not found", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).Return(int64(0), sql.ErrNoRows).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.Equal(t, common.ErrPlaceholderNotFound, err)
+	})
+
+	t.Run("negative - update placeholder returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).Return(int64(0), errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("negative - delete placeholder cache returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), nil, gomock.Any()).Return(version+1, nil).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.UpdatePlaceholder"), placeholderID.String()).Return(errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.UpdatePlaceholder(ctx, placeholderID.String(), version, placeholderUpdateRequest)
+		assert.EqualError(t, err, "error")
+	})
+}
+
+func TestPlaceholderService_PatchPlaceholder(t *testing.T) {
+	var (
+		mockCtrl             = gomock.NewController(t)
+		mockLogger           = loggerMock.NewMockILogger(mockCtrl)
+		mockPlaceholderRepo  = repositoryMock.NewMockIPlaceholderRepository(mockCtrl)
+		mockPlaceholderCache = repositoryMock.NewMockIPlaceholderCache(mockCtrl)
+		mockAlphaProxy       = proxyMock.NewMockIAlphaProxy(mockCtrl)
+
+		placeholderService = PlaceholderService{
+			Logger:                mockLogger,
+			PlaceholderRepository: mockPlaceholderRepo,
+			PlaceholderCache:      mockPlaceholderCache,
+			AlphaProxy:            mockAlphaProxy,
+		}
+
+		ctx            = context.Background()
+		placeholderID  = uuid.New()
+		placeholderDAO = model.PlaceholderDAO{
+			ID:        placeholderID,
+			Name:      "Aoi",
+			Amount:    10000,
+			CreatedBy: "user-1",
+			Version:   3,
+		}
+		version = int64(3)
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockPlaceholderRepo.EXPECT().UpdatePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ interface{}, placeholder model.PlaceholderDAO) (int64, error) {
+				assert.Equal(t, "Aoi", placeholder.Name)
+				assert.Equal(t, 25000, placeholder.Amount)
+				assert.Equal(t, version, placeholder.Version)
+				return placeholder.Version + 1, nil
+			}).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(nil).Times(1)
+
+		res, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`{"amount":25000}`))
+		assert.Nil(t, err)
+		assert.Equal(t, 25000, res.Amount)
+		assert.Equal(t, "Aoi", res.Name)
+	})
+
+	t.Run("negative - not the owner", func(t *testing.T) {
+		authCtx := auth.NewContext(ctx, auth.Principal{Subject: "user-2"})
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(authCtx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(authCtx, placeholderID.String(), version, []byte(`{"amount":25000}`))
+		assert.Equal(t, common.ErrNotOwner, err)
+	})
+
+	t.Run("negative - placeholder not found", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, sql.ErrNoRows).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`{"amount":25000}`))
+		assert.Equal(t, common.ErrPlaceholderNotFound, err)
+	})
+
+	t.Run("negative - get single placeholder returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(model.PlaceholderDAO{}, errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`{"amount":25000}`))
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("negative - invalid merge patch", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`potato`))
+		assert.Equal(t, common.ErrInvalidRequestBody, err)
+	})
+
+	t.Run("negative - patched document does not fit the request", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`{"amount":"a lot"}`))
+		assert.Equal(t, common.ErrInvalidRequestBody, err)
+	})
+
+	t.Run("negative - patched document fails validation", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().GetSinglePlaceholder(mock.InSpan(ctx, "PlaceholderService.PatchPlaceholder"), placeholderID.String()).Return(placeholderDAO, nil).Times(1)
+
+		_, err := placeholderService.PatchPlaceholder(ctx, placeholderID.String(), version, []byte(`{"name":null,"amount":-1}`))
+
+		var validationErrors validator.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrors)
+		assert.Len(t, validationErrors, 2)
+	})
+}
+
+func TestPlaceholderService_DeletePlaceholder(t *testing.T) {
+	var (
+		mockCtrl             = gomock.NewController(t)
+		mockLogger           = loggerMock.NewMockILogger(mockCtrl)
+		mockPlaceholderRepo  = repositoryMock.NewMockIPlaceholderRepository(mockCtrl)
+		mockPlaceholderCache = repositoryMock.NewMockIPlaceholderCache(mockCtrl)
+		mockAlphaProxy       = proxyMock.NewMockIAlphaProxy(mockCtrl)
+
+		placeholderService = PlaceholderService{
+			Logger:                mockLogger,
+			PlaceholderRepository: mockPlaceholderRepo,
+			PlaceholderCache:      mockPlaceholderCache,
+			AlphaProxy:            mockAlphaProxy,
+		}
+
+		ctx           = context.Background()
+		placeholderID = uuid.New().String()
+	)
+
+	mockLogger.EXPECT().GetSkip().Return(nil).AnyTimes()
+
+	t.Run("positive", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().DeletePlaceholder(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), nil, placeholderID).Return(nil).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), placeholderID).Return(nil).Times(1)
+
+		err := placeholderService.DeletePlaceholder(ctx, placeholderID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("negative - placeholder not found", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().DeletePlaceholder(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), nil, placeholderID).Return(sql.ErrNoRows).Times(1)
+		mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+		err := placeholderService.DeletePlaceholder(ctx, placeholderID)
+		assert.Equal(t, common.ErrPlaceholderNotFound, err)
+	})
+
+	t.Run("negative - delete placeholder returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().DeletePlaceholder(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), nil, placeholderID).Return(errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		err := placeholderService.DeletePlaceholder(ctx, placeholderID)
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("negative - delete placeholder cache returning error", func(t *testing.T) {
+		mockPlaceholderRepo.EXPECT().DeletePlaceholder(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), nil, placeholderID).Return(nil).Times(1)
+		mockPlaceholderCache.EXPECT().DeletePlaceholderInfo(mock.InSpan(ctx, "PlaceholderService.DeletePlaceholder"), placeholderID).Return(errors.New("error")).Times(1)
+		mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+		err := placeholderService.DeletePlaceholder(ctx, placeholderID)
+		assert.EqualError(t, err, "error")
+	})
+}
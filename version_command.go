@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/dityuiri/go-baseline/common/buildinfo"
+)
+
+func versionCommand() *command {
+	return &command{
+		name:  "version",
+		short: "Print the build of the binary",
+		help:  "Prints the commit, the build time and the Go version, as served on /version. Exits 0.",
+		setup: func(flags *flag.FlagSet) runFunc {
+			asJSON := flags.Bool("json", false, "print the build as JSON")
+
+			return func(_ context.Context, s streams, _ []string) int {
+				info := buildinfo.Get()
+				if *asJSON {
+					_ = json.NewEncoder(s.out).Encode(info)
+					return exitOK
+				}
+
+				_, _ = fmt.Fprintf(s.out, "commit:     %s\nbuild time: %s\ngo version: %s\n", info.Commit, info.BuildTime, info.GoVersion)
+
+				return exitOK
+			}
+		},
+	}
+}
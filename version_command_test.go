@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dityuiri/go-baseline/common/buildinfo"
+)
+
+func TestVersionCommand(t *testing.T) {
+	var ctx = context.Background()
+
+	t.Run("positive - text", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"version"}, streams{out: &out, err: &out}))
+		assert.Contains(t, out.String(), "commit:     "+buildinfo.Get().Commit)
+	})
+
+	t.Run("positive - json", func(t *testing.T) {
+		var (
+			out  bytes.Buffer
+			info buildinfo.Info
+		)
+
+		assert.Equal(t, exitOK, runCLI(ctx, []string{"version", "-json"}, streams{out: &out, err: &out}))
+		assert.Nil(t, json.Unmarshal(out.Bytes(), &info))
+		assert.Equal(t, buildinfo.Get(), info)
+	})
+}